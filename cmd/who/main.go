@@ -3,11 +3,16 @@
 package main
 
 import (
+	"context"
+	"flag"
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
 
 	"github.com/organic-programming/sophia-who/internal/cli"
 	"github.com/organic-programming/sophia-who/internal/server"
+	"github.com/organic-programming/sophia-who/pkg/identity"
 )
 
 func main() {
@@ -16,38 +21,308 @@ func main() {
 		os.Exit(1)
 	}
 
+	// The first argument is always a subcommand, never a target. New
+	// subcommands must be added to identity.CommandNames so that no
+	// alias can take their name.
 	var err error
 	switch os.Args[1] {
 	case "new":
-		err = cli.RunNew()
+		fs := flag.NewFlagSet("new", flag.ExitOnError)
+		var opts cli.NewOptions
+		fs.StringVar(&opts.Clade, "clade", "", "clade, by name or menu number")
+		fs.StringVar(&opts.Reproduction, "reproduction", "", "reproduction mode, by name or menu number")
+		fs.StringVar(&opts.Template, "template", "", "render HOLON.md from this template file")
+		fs.StringVar(&opts.OutputDir, "output-dir", "", "directory to create the holon in (default: <output_root>/<slug>)")
+		parents := fs.String("parents", "", "comma-separated parent UUIDs or prefixes (implies --reproduction bred)")
+		fs.BoolVar(&opts.AllowUnknownParents, "allow-unknown-parents", false, "keep parents that are not found under the working directory")
+		fs.BoolVar(&opts.TrackChildren, "track-children", false, "add the new holon to its parents' children lists")
+		fs.StringVar(&opts.PreWriteHook, "pre-write-hook", "", "shell command that must accept the rendered HOLON.md on stdin")
+		fs.StringVar(&opts.NameTemplate, "name-template", "", "text/template for the default given name, e.g. 'Holon-{{printf \"%04d\" .Counter}}'")
+		fs.BoolVar(&opts.Print, "print", false, "print the HOLON.md to stdout instead of creating the holon")
+		fs.BoolVar(&opts.NoBody, "no-body", false, "write only the frontmatter and title line")
+		fs.BoolVar(&opts.SortLists, "sort-lists", false, "write aliases and parents in sorted order")
+		tags := fs.String("tags", "", "comma-separated tags, e.g. audio,experimental")
+		fs.BoolVar(&opts.Strict, "strict", false, "refuse the holon on warnings, e.g. a given name equal to the family name")
+		interactive := fs.Bool("interactive", true, "print prompts; with --interactive=false, answers are read from stdin silently")
+		fs.Func("seed", "derive the UUID from this seed, for reproducible fixtures", func(v string) error {
+			seed, err := strconv.ParseUint(v, 10, 64)
+			if err != nil {
+				return fmt.Errorf("want a non-negative integer")
+			}
+			opts.Generator = identity.NewSeededGenerator(seed)
+			return nil
+		})
+		if args := parseArgs(fs, os.Args[2:]); len(args) != 0 {
+			fmt.Fprintln(os.Stderr, "usage: who new [--clade C] [--reproduction R] [--parents UUID,...] [--allow-unknown-parents] [--track-children] [--template FILE] [--output-dir DIR] [--pre-write-hook CMD] [--seed N] [--name-template T] [--tags T,...] [--strict] [--interactive=false] [--print] [--no-body] [--sort-lists]")
+			os.Exit(1)
+		}
+		if *parents != "" {
+			opts.Parents = strings.Split(*parents, ",")
+		}
+		if *tags != "" {
+			opts.Tags = strings.Split(*tags, ",")
+		}
+		opts.NoPrompts = !*interactive
+		err = cli.RunNew(opts)
 	case "show":
-		if len(os.Args) < 3 {
-			fmt.Fprintln(os.Stderr, "usage: who show <uuid>")
+		fs := flag.NewFlagSet("show", flag.ExitOnError)
+		var opts cli.ShowOptions
+		fs.BoolVar(&opts.RawBody, "raw-body", false, "print only the markdown body")
+		fs.BoolVar(&opts.RawFrontmatter, "raw-frontmatter", false, "print only the YAML frontmatter")
+		fs.BoolVar(&opts.YAML, "yaml", false, "print the normalized frontmatter as YAML")
+		fs.BoolVar(&opts.FieldsOnly, "fields-only", false, "print key=value lines, one per field, for shell scripts")
+		fs.BoolVar(&opts.Open, "open", false, "open the holon directory with the OS handler")
+		fs.BoolVar(&opts.NoHeader, "no-header", false, "omit the resolved path header")
+		fs.BoolVar(&opts.IncludeSiblings, "include-siblings", false, "also list the holons sharing a parent with it")
+		fs.BoolVar(&opts.Verify, "verify", false, "validate the holon first, printing problems instead of it")
+		args := parseArgs(fs, os.Args[2:])
+		if len(args) != 1 {
+			fmt.Fprintln(os.Stderr, "usage: who show [--raw-body | --raw-frontmatter | --yaml | --fields-only] [--no-header] [--include-siblings] [--verify] [--open] <uuid | name | alias | path>")
 			os.Exit(1)
 		}
-		err = cli.RunShow(os.Args[2])
+		err = cli.RunShow(args[0], opts)
 	case "list":
-		if len(os.Args) > 3 {
-			fmt.Fprintln(os.Stderr, "usage: who list [root]")
+		fs := flag.NewFlagSet("list", flag.ExitOnError)
+		var opts cli.ListOptions
+		fs.BoolVar(&opts.JSONL, "jsonl", false, "print one JSON object per holon")
+		fs.BoolVar(&opts.OnlyUUID, "only-uuid", false, "print only the UUID of each holon, one per line")
+		fs.BoolVar(&opts.IncludeIgnored, "include-ignored", false, "list holons next to a .holonignore marker")
+		fs.Func("symlinks", "symlinked HOLON.md files: follow (default, warning if outside root), skip, or reject", func(v string) error {
+			policy, err := identity.ParseSymlinkPolicy(v)
+			opts.Symlinks = policy
+			return err
+		})
+		fs.BoolVar(&opts.Long, "long", false, "add a motto column to the table")
+		fs.BoolVar(&opts.FullUUID, "full-uuid", false, "show complete UUIDs instead of their first 8 characters")
+		fs.StringVar(&opts.Dedupe, "dedupe", cli.DedupeUUID, "collapse duplicates by uuid or content")
+		fields := fs.String("fields", "", "comma-separated columns to show, e.g. uuid,name,clade")
+		fs.BoolVar(&opts.Tree, "tree", false, "group holons by directory")
+		fs.BoolVar(&opts.Watch, "watch", false, "keep the list on screen and redraw it as holons change")
+		fs.IntVar(&opts.Limit, "limit", 0, "print at most N holons")
+		fs.IntVar(&opts.Offset, "offset", 0, "skip the first M holons")
+		fs.StringVar(&opts.GitRef, "git-ref", "", "list holons as committed at this git ref instead of the work tree")
+		fs.StringVar(&opts.ChangedSince, "changed-since", "", "list only holons whose HOLON.md changed since this git ref")
+		fs.BoolVar(&opts.Invalid, "invalid", false, "list only HOLON.md files that fail to parse or validate, with the reason")
+		fs.Func("tag", "list only holons with this tag (repeatable: all must match)", func(v string) error {
+			opts.Tags = append(opts.Tags, v)
+			return nil
+		})
+		args := parseArgs(fs, os.Args[2:])
+		if len(args) > 1 {
+			fmt.Fprintln(os.Stderr, "usage: who list [--jsonl | --only-uuid | --tree | --watch] [--long] [--full-uuid] [--fields F,...] [--dedupe uuid|content] [--limit N] [--offset M] [--include-ignored] [--symlinks follow|skip|reject] [--git-ref REF] [--changed-since REF] [--invalid] [--tag T]... [root]")
+			os.Exit(1)
+		}
+		if *fields != "" {
+			if opts.Fields, err = cli.ParseListFields(*fields); err != nil {
+				fmt.Fprintf(os.Stderr, "error: %v\n", err)
+				os.Exit(1)
+			}
+		}
+		root := "."
+		if len(args) == 1 {
+			root = args[0]
+		}
+		err = cli.RunList(root, opts)
+	case "rename":
+		fs := flag.NewFlagSet("rename", flag.ExitOnError)
+		var opts cli.RenameOptions
+		fs.StringVar(&opts.GivenName, "given", "", "new given name")
+		fs.StringVar(&opts.FamilyName, "family", "", "new family name")
+		fs.BoolVar(&opts.MoveDir, "move-dir", false, "rename the holon directory to the new slug")
+		args := parseArgs(fs, os.Args[2:])
+		if len(args) != 1 {
+			fmt.Fprintln(os.Stderr, "usage: who rename <uuid> [--given X] [--family Y] [--move-dir]")
+			os.Exit(1)
+		}
+		err = cli.RunRename(".", args[0], opts)
+	case "status":
+		fs := flag.NewFlagSet("status", flag.ExitOnError)
+		var opts cli.StatusOptions
+		fs.IntVar(&opts.Concurrency, "concurrency", 0, "holons rewritten at once (default: GOMAXPROCS)")
+		args := parseArgs(fs, os.Args[2:])
+		if len(args) < 2 || opts.Concurrency < 0 {
+			fmt.Fprintln(os.Stderr, "usage: who status [--concurrency N] <new-status> <uuid>...")
+			os.Exit(1)
+		}
+		err = cli.RunStatus(".", args[0], args[1:], opts)
+	case "reparent":
+		if len(os.Args) < 4 {
+			fmt.Fprintln(os.Stderr, "usage: who reparent <uuid> <parent-uuid>...")
 			os.Exit(1)
 		}
+		err = cli.RunReparent(".", os.Args[2], os.Args[3:])
+	case "verify-lineage":
+		fs := flag.NewFlagSet("verify-lineage", flag.ExitOnError)
+		var expected []string
+		fs.Func("expect-parent", "UUID or prefix of a required parent (repeatable)", func(v string) error {
+			expected = append(expected, v)
+			return nil
+		})
+		args := parseArgs(fs, os.Args[2:])
+		if len(args) != 1 || len(expected) == 0 {
+			fmt.Fprintln(os.Stderr, "usage: who verify-lineage <uuid> --expect-parent <uuid>...")
+			os.Exit(1)
+		}
+		err = cli.RunVerifyLineage(".", args[0], expected)
+	case "validate":
+		if len(os.Args) != 3 {
+			fmt.Fprintln(os.Stderr, "usage: who validate <HOLON.md | ->")
+			os.Exit(1)
+		}
+		err = cli.RunValidate(os.Args[2])
+	case "whoami":
+		err = cli.RunWhoami()
+	case "migrate-layout":
+		fs := flag.NewFlagSet("migrate-layout", flag.ExitOnError)
+		dryRun := fs.Bool("dry-run", false, "print the moves without making them")
+		args := parseArgs(fs, os.Args[2:])
+		if len(args) > 1 {
+			fmt.Fprintln(os.Stderr, "usage: who migrate-layout [--dry-run] [root]")
+			os.Exit(1)
+		}
+		root := "."
+		if len(args) == 1 {
+			root = args[0]
+		}
+		err = cli.RunMigrateLayout(root, *dryRun)
+	case "audit":
 		root := "."
+		if len(os.Args) > 3 {
+			fmt.Fprintln(os.Stderr, "usage: who audit [root]")
+			os.Exit(1)
+		}
 		if len(os.Args) == 3 {
 			root = os.Args[2]
 		}
-		err = cli.RunList(root)
-	case "serve":
-		listenURI := "tcp://:9090"
-		for i, arg := range os.Args[2:] {
-			if arg == "--listen" && i+1 < len(os.Args[2:]) {
-				listenURI = os.Args[2+i+1]
+		err = cli.RunAudit(root)
+	case "export":
+		fs := flag.NewFlagSet("export", flag.ExitOnError)
+		markdown := fs.String("markdown", "", "write a Markdown catalog to this file (- for stdout)")
+		args := parseArgs(fs, os.Args[2:])
+		if *markdown == "" || len(args) > 1 {
+			fmt.Fprintln(os.Stderr, "usage: who export --markdown <file | -> [root]")
+			os.Exit(1)
+		}
+		root := "."
+		if len(args) == 1 {
+			root = args[0]
+		}
+		err = cli.RunExportMarkdown(root, *markdown)
+	case "doctor":
+		fs := flag.NewFlagSet("doctor", flag.ExitOnError)
+		opts := cli.DoctorOptions{Lint: identity.DefaultLintOptions()}
+		noStatusConsistency := fs.Bool("no-status-consistency", false, "skip the status/proto_status consistency check")
+		fs.IntVar(&opts.Lint.MaxMottoLength, "max-motto-length", opts.Lint.MaxMottoLength, "warn about mottos longer than this many characters (0 disables)")
+		fs.BoolVar(&opts.Fix, "fix", false, "rewrite files to repair BOMs, CRLF line endings, trailing newlines, and duplicate aliases")
+		fs.StringVar(&opts.Format, "format", cli.DoctorFormatText, "report format: text or json")
+		args := parseArgs(fs, os.Args[2:])
+		if len(args) > 1 {
+			fmt.Fprintln(os.Stderr, "usage: who doctor [--fix] [--format text|json] [--no-status-consistency] [--max-motto-length N] [root]")
+			os.Exit(1)
+		}
+		opts.Lint.StatusConsistency = !*noStatusConsistency
+		root := "."
+		if len(args) == 1 {
+			root = args[0]
+		}
+		err = cli.RunDoctor(root, opts)
+	case "fmt":
+		fs := flag.NewFlagSet("fmt", flag.ExitOnError)
+		check := fs.Bool("check", false, "report files that need formatting instead of rewriting them")
+		args := parseArgs(fs, os.Args[2:])
+		if len(args) > 1 {
+			fmt.Fprintln(os.Stderr, "usage: who fmt [--check] [root]")
+			os.Exit(1)
+		}
+		root := "."
+		if len(args) == 1 {
+			root = args[0]
+		}
+		err = cli.RunFmt(root, *check)
+	case "client":
+		const usage = "usage: who client list|new [--server URI] [--token T] [--context K=V]... [args]"
+		if len(os.Args) < 3 {
+			fmt.Fprintln(os.Stderr, usage)
+			os.Exit(1)
+		}
+		var opts cli.ClientOptions
+		switch os.Args[2] {
+		case "list":
+			fs := flag.NewFlagSet("client list", flag.ExitOnError)
+			clientFlags(fs, &opts)
+			args := parseArgs(fs, os.Args[3:])
+			if len(args) > 1 {
+				fmt.Fprintln(os.Stderr, "usage: who client list [--server URI] [--token T] [--context K=V]... [root_dir]")
+				os.Exit(1)
+			}
+			rootDir := ""
+			if len(args) == 1 {
+				rootDir = args[0]
+			}
+			err = cli.RunClientList(opts, rootDir)
+		case "new":
+			fs := flag.NewFlagSet("client new", flag.ExitOnError)
+			clientFlags(fs, &opts)
+			var fields cli.ClientNewOptions
+			fs.StringVar(&fields.GivenName, "given", "", "given name")
+			fs.StringVar(&fields.FamilyName, "family", "", "family name")
+			fs.StringVar(&fields.Motto, "motto", "", "motto")
+			fs.StringVar(&fields.Composer, "composer", "", "composer")
+			fs.StringVar(&fields.Clade, "clade", "", "clade, e.g. deterministic/pure")
+			fs.StringVar(&fields.Reproduction, "reproduction", "", "reproduction mode, e.g. manual")
+			fs.StringVar(&fields.Lang, "lang", "", "implementation language")
+			fs.StringVar(&fields.Born, "born", "", "birth date, YYYY-MM-DD (default: today on the server)")
+			fs.StringVar(&fields.OutputDir, "output-dir", "", "directory to create the holon in, relative to the server's root")
+			aliases := fs.String("aliases", "", "comma-separated aliases")
+			parents := fs.String("parents", "", "comma-separated parent UUIDs")
+			tags := fs.String("tags", "", "comma-separated tags")
+			if args := parseArgs(fs, os.Args[3:]); len(args) > 0 {
+				fmt.Fprintln(os.Stderr, "usage: who client new [--server URI] --given G --family F [--motto M] [--composer C] [--clade C] [--output-dir DIR] [flags]")
+				os.Exit(1)
+			}
+			if *aliases != "" {
+				fields.Aliases = strings.Split(*aliases, ",")
 			}
-			// Backward compatibility: --port 9090 → tcp://:9090
-			if arg == "--port" && i+1 < len(os.Args[2:]) {
-				listenURI = "tcp://:" + os.Args[2+i+1]
+			if *parents != "" {
+				fields.Parents = strings.Split(*parents, ",")
+			}
+			if *tags != "" {
+				fields.Tags = strings.Split(*tags, ",")
+			}
+			err = cli.RunClientNew(opts, fields)
+		default:
+			fmt.Fprintln(os.Stderr, usage)
+			os.Exit(1)
+		}
+	case "serve":
+		cfg, perr := parseServeArgs(os.Args[2:], os.Stderr)
+		if perr == flag.ErrHelp {
+			return
+		}
+		if perr != nil {
+			os.Exit(2)
+		}
+		if cfg.workdir != "" {
+			if err := enterWorkdir(cfg.workdir); err != nil {
+				fmt.Fprintf(os.Stderr, "error: %v\n", err)
+				os.Exit(1)
 			}
 		}
-		err = server.ListenAndServe(listenURI, true)
+		opts := cfg.opts
+		if info, statErr := os.Stat(opts.Root); statErr != nil || !info.IsDir() {
+			fmt.Fprintf(os.Stderr, "error: --root %s is not a directory\n", opts.Root)
+			os.Exit(1)
+		}
+		if opts.Tracing, err = server.TracingFromEnv(cfg.otel); err != nil {
+			break
+		}
+		opts.Defaults, err = identity.LoadConfig(opts.Root)
+		if err == nil {
+			err = server.ListenAndServeWithOptions(cfg.listenURI, opts)
+		}
+		if shutdownErr := opts.Tracing.Shutdown(context.Background()); err == nil {
+			err = shutdownErr
+		}
 	default:
 		printUsage()
 		os.Exit(1)
@@ -59,14 +334,121 @@ func main() {
 	}
 }
 
+// clientFlags registers the flags shared by the who client subcommands.
+func clientFlags(fs *flag.FlagSet, opts *cli.ClientOptions) {
+	fs.StringVar(&opts.Server, "server", "", "server URI, tcp://host:port, unix://path or ws://host:port (default $SOPHIA_WHO_SERVER or "+cli.DefaultServer+")")
+	fs.StringVar(&opts.Token, "token", "", "bearer token sent as authorization metadata")
+	fs.Func("context", "extra RPC metadata as key=value (repeatable)", func(v string) error {
+		opts.Context = append(opts.Context, v)
+		return nil
+	})
+	fs.DurationVar(&opts.Timeout, "timeout", 0, "deadline of each RPC (default 30s)")
+}
+
+// parseArgs parses flags interspersed with positional arguments,
+// so that both `who show --raw-body <uuid>` and `who show <uuid> --raw-body`
+// work. It returns the positional arguments in order.
+func parseArgs(fs *flag.FlagSet, args []string) []string {
+	var positional []string
+	for {
+		_ = fs.Parse(args) // ExitOnError: Parse exits on failure
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
 func printUsage() {
 	fmt.Fprintln(os.Stderr, `Sophia Who? — holon identity manager
 
 Usage:
   who new                                     create a new holon identity
-  who show <uuid>                             display a holon's identity
+  who new --clade 4 --reproduction manual     preset clade/reproduction
+  who new --parents <uuid>,<uuid>             record parents (implies bred)
+  who new --parents <uuid> --track-children   also list the child in each parent
+  who new --tags audio,experimental           categorize the holon
+  who new --seed 42                           reproducible UUID, for fixtures
+  who new --print                             print the HOLON.md, write nothing
+  who new --no-body                           frontmatter and title only, no scaffold
+  who new --sort-lists                        write aliases and parents sorted (.holonrc: sort_lists)
+  who new --interactive=false < answers       read the answers from stdin without prompting
+  who new --name-template 'H-{{.Counter}}'    default to the first free generated name
+  who show <uuid>                             display a holon's identity (also by name, alias, or path)
+  who show --raw-frontmatter <uuid>           print only the YAML frontmatter
+  who show --raw-body <uuid>                  print only the markdown body
+  who show --yaml <uuid>                      print the normalized frontmatter
+  who show --fields-only <uuid>               print key=value lines for shell scripts
+  who show --include-siblings <uuid>          also list holons sharing a parent with it
+  who show --verify <uuid>                    validate the holon before printing it
+  who show --open <uuid>                      open the holon directory
   who list [root]                             list all known holons in root
+  who list --jsonl [root]                     stream holons as JSON Lines
+  who list --only-uuid [root]                 print one UUID per line, for shell loops
+  who list --long [root]                      include each holon's motto
+  who list --full-uuid [root]                 show complete UUIDs, not 8-char prefixes
+  who list --fields uuid,name,clade [root]    choose and order the columns
+  who list --tree [root]                      group holons by directory
+  who list --watch [root]                     live view, redrawn as holons change
+  who list --dedupe=content [root]            collapse identical copies
+  who list --limit 20 --offset 40 [root]      print one page of holons
+  who list --git-ref main [root]              list holons as committed on a branch
+  who list --changed-since main [root]        list holons whose HOLON.md changed since main
+  who list --invalid [root]                   list only broken HOLON.md files
+  who list --symlinks reject [root]           fail on symlinked HOLON.md files
+  who list --tag audio [root]                 list only holons with a tag
+  who rename <uuid> --given X --family Y      rename a holon (--move-dir to rename its directory)
+  who status <status> <uuid>...               move holons to a lifecycle status
+  who status --concurrency 4 <status> <id>... rewrite at most 4 holons at once
+  who reparent <uuid> <parent-uuid>...        replace a holon's parents
+  who verify-lineage <uuid> --expect-parent P fail unless P is a parent of the holon
+  who validate <file | ->                     validate a HOLON.md file or stdin
+  who whoami                                  show the holon enclosing the cwd
+  who migrate-layout [--dry-run] [root]       move holons from .holon/ to holons/
+  who audit [root]                            print the create/update audit log
+  who export --markdown catalog.md [root]     write a Markdown catalog grouped by clade
+  who doctor [root]                           report suspicious holon identities
+  who doctor --format json [root]             report findings as JSON (fails on errors)
+  who doctor --fix [root]                     repair BOMs, line endings, duplicate aliases
+  who fmt [root]                              normalize HOLON.md files (as doctor --fix)
+  who fmt --check [root]                      fail if any HOLON.md needs formatting
   who serve [--listen tcp://:9090]            start gRPC server
   who serve --listen unix:///tmp/who.sock     Unix domain socket
-  who serve --listen stdio://                 stdin/stdout pipe`)
+  who serve --listen stdio://                 stdin/stdout pipe
+  who serve --listen fd://3                   inherited socket (systemd socket activation)
+  who serve --listen ws://127.0.0.1:9091      WebSocket (gRPC subprotocol)
+  who serve --listen h2c://:9090              HTTP/2 cleartext (for proxies)
+  who serve --root <dir>                      serve holons under dir (env: SOPHIA_WHO_ROOT)
+  who serve --workdir /srv/holons             cd there first, then serve
+  who client list --server tcp://host:9090    list holons through a running server
+  who client list --token T --context k=v     authenticate, attach RPC metadata
+  who client new --given G --family F         create a holon through a running server
+
+Serve limits (off by default):
+  --rate-limit 10,CreateIdentity=1            requests/second per method
+  --rate-burst <n>                            token bucket size
+  --max-in-flight <n>                         concurrent request cap
+  --max-request-bytes <n>                     maximum request message size
+  --max-scan-results <n>                      ListIdentities result cap (default 100000, 0 = none)
+  --bulk-concurrency <n>                      holons UpdateStatus rewrites at once (default GOMAXPROCS)
+  --cache-list <ttl>                          reuse ListIdentities scans for up to ttl (e.g. 30s)
+  --allowed-roots <dir>,...                   directories a request's root_dir may scan
+  --pre-write-hook <cmd>                      command that must accept each new HOLON.md on stdin
+  --redact <field>,...                        blank fields such as composer in show and list responses
+  --unix-socket-perms <mode>                  unix:// socket permissions, e.g. 0660
+  --unix-socket-group <group>                 unix:// socket group (name or GID)
+
+Serve connections:
+  --keepalive-time <d>                        ping clients idle this long (default 1m, off disables)
+  --keepalive-timeout <d>                     close if a ping is not acked in time (default 20s)
+  --keepalive-min-time <d>                    minimum client ping interval (default 10s)
+  --keepalive-max-idle <d>                    close connections without RPCs this long (default 15m, off disables)
+  --connection-timeout <d>                    connection setup deadline (default 20s)
+
+Serve tracing:
+  --otel                                      export a span per RPC to an OTLP collector
+                                              (also OTEL_TRACES_EXPORTER=otlp|console|none,
+                                              OTEL_EXPORTER_OTLP_*, OTEL_SERVICE_NAME)`)
 }
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/organic-programming/sophia-who/internal/server"
+)
+
+// defaultMaxScanResults bounds ListIdentities so a huge tree cannot
+// exhaust server memory; real projects stay far below it.
+const defaultMaxScanResults = 100000
+
+// serveConfig is the parsed command line of who serve.
+type serveConfig struct {
+	listenURI string
+	workdir   string
+	opts      server.Options
+	otel      bool
+}
+
+// parseServeArgs parses the flags of who serve. Unknown flags, missing
+// values, and positional arguments are errors, reported to output with
+// the usage; -h prints the usage and returns flag.ErrHelp. --listen
+// and --port both set the listen URI, the last one given winning.
+func parseServeArgs(args []string, output io.Writer) (serveConfig, error) {
+	cfg := serveConfig{
+		listenURI: "tcp://:9090",
+		opts:      server.Options{Reflect: true, Root: os.Getenv("SOPHIA_WHO_ROOT"), MaxScanResults: defaultMaxScanResults},
+	}
+	opts := &cfg.opts
+
+	fs := flag.NewFlagSet("serve", flag.ContinueOnError)
+	fs.SetOutput(output)
+	fs.Usage = func() {
+		fmt.Fprintln(output, "usage: who serve [--listen URI | --port N] [--root DIR] [flags]")
+		fs.PrintDefaults()
+	}
+	fs.Func("listen", "transport URI to listen on (default tcp://:9090)", func(v string) error {
+		cfg.listenURI = v
+		return nil
+	})
+	fs.Func("port", "TCP port, same as --listen tcp://:<port>", func(v string) error {
+		// Backward compatibility: --port 9090 → tcp://:9090
+		if _, err := strconv.ParseUint(v, 10, 16); err != nil {
+			return fmt.Errorf("want a port number")
+		}
+		cfg.listenURI = "tcp://:" + v
+		return nil
+	})
+	fs.StringVar(&cfg.workdir, "workdir", "", "change to this directory before anything else, as cd DIR && who serve would")
+	fs.StringVar(&opts.Root, "root", opts.Root, "base directory for all RPCs (default $SOPHIA_WHO_ROOT or .)")
+	fs.Func("rate-limit", "requests per second, e.g. 10,CreateIdentity=1", func(v string) error {
+		return server.ParseRateLimits(v, &opts.Limits)
+	})
+	fs.Func("rate-burst", "token bucket size for --rate-limit", nonNegativeInt(&opts.Limits.Burst))
+	fs.Func("max-in-flight", "maximum concurrent requests", nonNegativeInt(&opts.Limits.MaxInFlight))
+	fs.Func("max-request-bytes", "maximum request size", nonNegativeInt(&opts.Limits.MaxRequestBytes))
+	fs.Func("bulk-concurrency", "holons a bulk RPC such as UpdateStatus rewrites at once (default: GOMAXPROCS)", nonNegativeInt(&opts.BulkConcurrency))
+	fs.Func("max-scan-results", "maximum holons per ListIdentities (0: unlimited)", nonNegativeInt(&opts.MaxScanResults))
+	fs.Func("cache-list", "cache ListIdentities results for this long, e.g. 30s", func(v string) error {
+		ttl, err := time.ParseDuration(v)
+		if err != nil || ttl < 0 {
+			return fmt.Errorf("want a duration like 30s")
+		}
+		opts.ListCacheTTL = ttl
+		return nil
+	})
+	fs.Func("allowed-roots", "comma-separated directories root_dir may point into", func(v string) error {
+		for _, root := range strings.Split(v, ",") {
+			if root = strings.TrimSpace(root); root != "" {
+				opts.AllowedRoots = append(opts.AllowedRoots, root)
+			}
+		}
+		return nil
+	})
+	fs.StringVar(&opts.PreWriteHook, "pre-write-hook", "", "shell command vetting each new HOLON.md on stdin")
+	fs.BoolVar(&opts.TrackChildren, "track-children", false, "add created holons to their parents' children lists")
+	fs.Func("redact", "comma-separated fields blanked in show and list responses, e.g. composer,aliases", func(v string) error {
+		return server.ParseRedactFields(v, &opts.RedactFields)
+	})
+	fs.Func("unix-socket-perms", "octal permissions of a unix:// socket, e.g. 0660", func(v string) error {
+		perms, err := strconv.ParseUint(v, 8, 32)
+		if err != nil || perms > 0o777 {
+			return fmt.Errorf("want octal permissions like 0660")
+		}
+		opts.UnixSocketPerms = os.FileMode(perms)
+		return nil
+	})
+	fs.StringVar(&opts.UnixSocketGroup, "unix-socket-group", "", "group owning a unix:// socket")
+	fs.Func("keepalive-time", "ping clients idle this long (off disables)", durationOrOff(&opts.Keepalive.Time))
+	fs.Func("keepalive-timeout", "close if a ping is not acked in time", durationOrOff(&opts.Keepalive.Timeout))
+	fs.Func("keepalive-min-time", "minimum client ping interval", durationOrOff(&opts.Keepalive.MinTime))
+	fs.Func("keepalive-max-idle", "close connections without RPCs this long (off disables)", durationOrOff(&opts.Keepalive.MaxConnectionIdle))
+	fs.Func("connection-timeout", "connection setup deadline", durationOrOff(&opts.Keepalive.ConnectionTimeout))
+	fs.BoolVar(&cfg.otel, "otel", false, "export a span per RPC (see OTEL_TRACES_EXPORTER)")
+
+	if err := fs.Parse(args); err != nil {
+		return serveConfig{}, err
+	}
+	if fs.NArg() > 0 {
+		err := fmt.Errorf("unexpected argument %q", fs.Arg(0))
+		fmt.Fprintln(output, err)
+		fs.Usage()
+		return serveConfig{}, err
+	}
+	if opts.Root == "" {
+		opts.Root = "."
+	}
+	return cfg, nil
+}
+
+// enterWorkdir makes dir the working directory of the process, which
+// a relative --root and the server's scans are then resolved against.
+func enterWorkdir(dir string) error {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return fmt.Errorf("--workdir %s: %w", dir, err)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("--workdir %s is not a directory", dir)
+	}
+	if err := os.Chdir(dir); err != nil {
+		return fmt.Errorf("--workdir %s: %w", dir, err)
+	}
+	return nil
+}
+
+// nonNegativeInt returns a flag.Func setter parsing a non-negative
+// integer into dst.
+func nonNegativeInt(dst *int) func(string) error {
+	return func(v string) error {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return fmt.Errorf("want a non-negative integer")
+		}
+		*dst = n
+		return nil
+	}
+}
+
+// durationOrOff returns a flag.Func setter parsing a duration such as
+// 30s into dst. "off" is stored as a negative duration, which disables
+// the setting where that is meaningful.
+func durationOrOff(dst *time.Duration) func(string) error {
+	return func(v string) error {
+		if v == "off" {
+			*dst = -1
+			return nil
+		}
+		d, err := time.ParseDuration(v)
+		if err != nil || d < 0 {
+			return fmt.Errorf("want a duration like 30s or off")
+		}
+		*dst = d
+		return nil
+	}
+}
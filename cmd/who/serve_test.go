@@ -0,0 +1,179 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	pb "github.com/organic-programming/sophia-who/gen/go/sophia_who/v1"
+	"github.com/organic-programming/sophia-who/internal/server"
+	"github.com/organic-programming/sophia-who/pkg/identity"
+)
+
+func TestParseServeArgsListenPosition(t *testing.T) {
+	t.Setenv("SOPHIA_WHO_ROOT", "")
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"default", nil, "tcp://:9090"},
+		{"first", []string{"--listen", "unix:///tmp/who.sock", "--root", "holons", "--otel"}, "unix:///tmp/who.sock"},
+		{"middle", []string{"--root", "holons", "--listen", "tcp://:7070", "--max-in-flight", "4"}, "tcp://:7070"},
+		{"last", []string{"--root", "holons", "--otel", "--listen", "stdio://"}, "stdio://"},
+		{"equals", []string{"--root=holons", "--listen=tcp://127.0.0.1:7070"}, "tcp://127.0.0.1:7070"},
+		{"port", []string{"--port", "8080", "--root", "holons"}, "tcp://:8080"},
+		{"last wins", []string{"--port", "8080", "--listen", "tcp://:7070"}, "tcp://:7070"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			cfg, err := parseServeArgs(tt.args, &out)
+			if err != nil {
+				t.Fatalf("parseServeArgs: %v\n%s", err, out.String())
+			}
+			if cfg.listenURI != tt.want {
+				t.Errorf("listenURI = %q, want %q", cfg.listenURI, tt.want)
+			}
+			if want := "holons"; strings.Contains(strings.Join(tt.args, " "), want) && cfg.opts.Root != want {
+				t.Errorf("Root = %q, want %q", cfg.opts.Root, want)
+			}
+		})
+	}
+}
+
+func TestParseServeArgsOptions(t *testing.T) {
+	t.Setenv("SOPHIA_WHO_ROOT", "from-env")
+	cfg, err := parseServeArgs([]string{
+		"--max-scan-results", "0",
+		"--cache-list", "30s",
+		"--keepalive-time", "off",
+		"--allowed-roots", "a, b,",
+		"--unix-socket-perms", "0660",
+		"--redact", "composer",
+		"--otel",
+	}, &bytes.Buffer{})
+	if err != nil {
+		t.Fatalf("parseServeArgs: %v", err)
+	}
+	opts := cfg.opts
+	if opts.Root != "from-env" {
+		t.Errorf("Root = %q, want $SOPHIA_WHO_ROOT", opts.Root)
+	}
+	if !opts.Reflect || opts.MaxScanResults != 0 || opts.ListCacheTTL != 30*time.Second {
+		t.Errorf("Reflect %v, MaxScanResults %d, ListCacheTTL %v", opts.Reflect, opts.MaxScanResults, opts.ListCacheTTL)
+	}
+	if opts.Keepalive.Time != -1 {
+		t.Errorf("Keepalive.Time = %v, want -1 for off", opts.Keepalive.Time)
+	}
+	if strings.Join(opts.AllowedRoots, ",") != "a,b" {
+		t.Errorf("AllowedRoots = %q", opts.AllowedRoots)
+	}
+	if opts.UnixSocketPerms != 0o660 || !cfg.otel {
+		t.Errorf("UnixSocketPerms %o, otel %v", opts.UnixSocketPerms, cfg.otel)
+	}
+	if strings.Join(opts.RedactFields, ",") != "composer" {
+		t.Errorf("RedactFields = %q", opts.RedactFields)
+	}
+}
+
+func TestParseServeArgsDefaultRoot(t *testing.T) {
+	t.Setenv("SOPHIA_WHO_ROOT", "")
+	cfg, err := parseServeArgs(nil, &bytes.Buffer{})
+	if err != nil {
+		t.Fatalf("parseServeArgs: %v", err)
+	}
+	if cfg.opts.Root != "." || cfg.opts.MaxScanResults != defaultMaxScanResults {
+		t.Errorf("Root %q, MaxScanResults %d", cfg.opts.Root, cfg.opts.MaxScanResults)
+	}
+}
+
+func TestParseServeArgsErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"unknown flag", []string{"--listen", "tcp://:7070", "--bogus"}, "flag provided but not defined: -bogus"},
+		{"missing value", []string{"--root", "holons", "--listen"}, "flag needs an argument: -listen"},
+		{"bad port", []string{"--port", "http"}, `invalid value "http" for flag -port`},
+		{"negative integer", []string{"--rate-burst", "-1"}, "want a non-negative integer"},
+		{"bad duration", []string{"--keepalive-time", "soon"}, "want a duration like 30s or off"},
+		{"bad perms", []string{"--unix-socket-perms", "1777"}, "want octal permissions"},
+		{"positional", []string{"--root", "holons", "extra"}, `unexpected argument "extra"`},
+		{"bad redact", []string{"--redact", "composer,uuid"}, `cannot redact "uuid"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			if _, err := parseServeArgs(tt.args, &out); err == nil {
+				t.Fatal("parseServeArgs succeeded, want an error")
+			}
+			if !strings.Contains(out.String(), tt.want) {
+				t.Errorf("output %q does not mention %q", out.String(), tt.want)
+			}
+			if !strings.Contains(out.String(), "usage: who serve") {
+				t.Errorf("output %q lacks the usage", out.String())
+			}
+		})
+	}
+}
+
+func TestParseServeArgsHelp(t *testing.T) {
+	var out bytes.Buffer
+	if _, err := parseServeArgs([]string{"-h"}, &out); err != flag.ErrHelp {
+		t.Fatalf("err = %v, want flag.ErrHelp", err)
+	}
+	if !strings.Contains(out.String(), "-keepalive-max-idle") {
+		t.Errorf("help does not list the flags:\n%s", out.String())
+	}
+}
+
+func TestServeWorkdirScansItsHolons(t *testing.T) {
+	t.Setenv("SOPHIA_WHO_ROOT", "")
+	workdir := t.TempDir()
+	holon := filepath.Join(workdir, "holons", "swift")
+	if err := os.MkdirAll(holon, 0755); err != nil {
+		t.Fatal(err)
+	}
+	id := identity.New()
+	id.GivenName, id.FamilyName, id.Motto, id.Composer = "Swift", "Prober", "Probe.", "Test"
+	if err := identity.WriteHolonMD(id, filepath.Join(holon, "HOLON.md")); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := parseServeArgs([]string{"--workdir", workdir}, &bytes.Buffer{})
+	if err != nil {
+		t.Fatalf("parseServeArgs: %v", err)
+	}
+	t.Chdir(t.TempDir()) // restored after the test, like the process cwd
+	if err := enterWorkdir(cfg.workdir); err != nil {
+		t.Fatalf("enterWorkdir: %v", err)
+	}
+
+	srv := &server.Server{Root: cfg.opts.Root}
+	resp, err := srv.ListIdentities(context.Background(), &pb.ListIdentitiesRequest{})
+	if err != nil {
+		t.Fatalf("ListIdentities: %v", err)
+	}
+	if len(resp.GetEntries()) != 1 || resp.GetEntries()[0].GetIdentity().GetUuid() != id.UUID {
+		t.Errorf("ListIdentities = %v, want the holon under --workdir", resp.GetEntries())
+	}
+}
+
+func TestEnterWorkdirErrors(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "file")
+	if err := os.WriteFile(file, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	for _, dir := range []string{filepath.Join(t.TempDir(), "missing"), file} {
+		if err := enterWorkdir(dir); err == nil || !strings.Contains(err.Error(), "--workdir "+dir) {
+			t.Errorf("enterWorkdir(%s) = %v, want an error naming it", dir, err)
+		}
+	}
+}
@@ -28,9 +28,16 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	SophiaWhoService_CreateIdentity_FullMethodName = "/sophia_who.v1.SophiaWhoService/CreateIdentity"
-	SophiaWhoService_ShowIdentity_FullMethodName   = "/sophia_who.v1.SophiaWhoService/ShowIdentity"
-	SophiaWhoService_ListIdentities_FullMethodName = "/sophia_who.v1.SophiaWhoService/ListIdentities"
+	SophiaWhoService_CreateIdentity_FullMethodName  = "/sophia_who.v1.SophiaWhoService/CreateIdentity"
+	SophiaWhoService_ShowIdentity_FullMethodName    = "/sophia_who.v1.SophiaWhoService/ShowIdentity"
+	SophiaWhoService_UpdateIdentity_FullMethodName  = "/sophia_who.v1.SophiaWhoService/UpdateIdentity"
+	SophiaWhoService_UpdateStatus_FullMethodName    = "/sophia_who.v1.SophiaWhoService/UpdateStatus"
+	SophiaWhoService_Reparent_FullMethodName        = "/sophia_who.v1.SophiaWhoService/Reparent"
+	SophiaWhoService_ListIdentities_FullMethodName  = "/sophia_who.v1.SophiaWhoService/ListIdentities"
+	SophiaWhoService_CountIdentities_FullMethodName = "/sophia_who.v1.SophiaWhoService/CountIdentities"
+	SophiaWhoService_ValidateContent_FullMethodName = "/sophia_who.v1.SophiaWhoService/ValidateContent"
+	SophiaWhoService_ListHolonFiles_FullMethodName  = "/sophia_who.v1.SophiaWhoService/ListHolonFiles"
+	SophiaWhoService_ValidateAll_FullMethodName     = "/sophia_who.v1.SophiaWhoService/ValidateAll"
 )
 
 // SophiaWhoServiceClient is the client API for SophiaWhoService service.
@@ -44,8 +51,30 @@ type SophiaWhoServiceClient interface {
 	CreateIdentity(ctx context.Context, in *CreateIdentityRequest, opts ...grpc.CallOption) (*CreateIdentityResponse, error)
 	// ShowIdentity retrieves a holon's identity by UUID.
 	ShowIdentity(ctx context.Context, in *ShowIdentityRequest, opts ...grpc.CallOption) (*ShowIdentityResponse, error)
+	// UpdateIdentity rewrites the fields named in update_mask, leaving the
+	// rest of the HOLON.md (other fields, comments, body) untouched.
+	UpdateIdentity(ctx context.Context, in *UpdateIdentityRequest, opts ...grpc.CallOption) (*UpdateIdentityResponse, error)
+	// UpdateStatus moves several holons to one lifecycle status, reporting
+	// the outcome of each separately. Moving to DEAD also records died.
+	UpdateStatus(ctx context.Context, in *UpdateStatusRequest, opts ...grpc.CallOption) (*UpdateStatusResponse, error)
+	// Reparent replaces a holon's parents, refusing unknown parents and any
+	// change that would make the lineage a cycle.
+	Reparent(ctx context.Context, in *ReparentRequest, opts ...grpc.CallOption) (*ReparentResponse, error)
 	// ListIdentities scans the project for all known holons.
 	ListIdentities(ctx context.Context, in *ListIdentitiesRequest, opts ...grpc.CallOption) (*ListIdentitiesResponse, error)
+	// CountIdentities returns how many holons a scan finds, broken down by
+	// clade and status, without transferring the identities themselves.
+	CountIdentities(ctx context.Context, in *CountIdentitiesRequest, opts ...grpc.CallOption) (*CountIdentitiesResponse, error)
+	// ValidateContent parses and validates raw HOLON.md content without
+	// writing anything (e.g. an editor buffer on save).
+	ValidateContent(ctx context.Context, in *ValidateContentRequest, opts ...grpc.CallOption) (*ValidateContentResponse, error)
+	// ListHolonFiles lists the files in a holon's directory (code, protos,
+	// ...), e.g. for a UI showing them next to the identity.
+	ListHolonFiles(ctx context.Context, in *ListHolonFilesRequest, opts ...grpc.CallOption) (*ListHolonFilesResponse, error)
+	// ValidateAll validates every HOLON.md under a root, streaming one
+	// result per file as the scan proceeds, with periodic progress. It is
+	// the RPC form of `who validate`.
+	ValidateAll(ctx context.Context, in *ValidateAllRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ValidationResult], error)
 }
 
 type sophiaWhoServiceClient struct {
@@ -76,6 +105,36 @@ func (c *sophiaWhoServiceClient) ShowIdentity(ctx context.Context, in *ShowIdent
 	return out, nil
 }
 
+func (c *sophiaWhoServiceClient) UpdateIdentity(ctx context.Context, in *UpdateIdentityRequest, opts ...grpc.CallOption) (*UpdateIdentityResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateIdentityResponse)
+	err := c.cc.Invoke(ctx, SophiaWhoService_UpdateIdentity_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sophiaWhoServiceClient) UpdateStatus(ctx context.Context, in *UpdateStatusRequest, opts ...grpc.CallOption) (*UpdateStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateStatusResponse)
+	err := c.cc.Invoke(ctx, SophiaWhoService_UpdateStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sophiaWhoServiceClient) Reparent(ctx context.Context, in *ReparentRequest, opts ...grpc.CallOption) (*ReparentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReparentResponse)
+	err := c.cc.Invoke(ctx, SophiaWhoService_Reparent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *sophiaWhoServiceClient) ListIdentities(ctx context.Context, in *ListIdentitiesRequest, opts ...grpc.CallOption) (*ListIdentitiesResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ListIdentitiesResponse)
@@ -86,6 +145,55 @@ func (c *sophiaWhoServiceClient) ListIdentities(ctx context.Context, in *ListIde
 	return out, nil
 }
 
+func (c *sophiaWhoServiceClient) CountIdentities(ctx context.Context, in *CountIdentitiesRequest, opts ...grpc.CallOption) (*CountIdentitiesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CountIdentitiesResponse)
+	err := c.cc.Invoke(ctx, SophiaWhoService_CountIdentities_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sophiaWhoServiceClient) ValidateContent(ctx context.Context, in *ValidateContentRequest, opts ...grpc.CallOption) (*ValidateContentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ValidateContentResponse)
+	err := c.cc.Invoke(ctx, SophiaWhoService_ValidateContent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sophiaWhoServiceClient) ListHolonFiles(ctx context.Context, in *ListHolonFilesRequest, opts ...grpc.CallOption) (*ListHolonFilesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListHolonFilesResponse)
+	err := c.cc.Invoke(ctx, SophiaWhoService_ListHolonFiles_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sophiaWhoServiceClient) ValidateAll(ctx context.Context, in *ValidateAllRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ValidationResult], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &SophiaWhoService_ServiceDesc.Streams[0], SophiaWhoService_ValidateAll_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ValidateAllRequest, ValidationResult]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SophiaWhoService_ValidateAllClient = grpc.ServerStreamingClient[ValidationResult]
+
 // SophiaWhoServiceServer is the server API for SophiaWhoService service.
 // All implementations must embed UnimplementedSophiaWhoServiceServer
 // for forward compatibility.
@@ -97,8 +205,30 @@ type SophiaWhoServiceServer interface {
 	CreateIdentity(context.Context, *CreateIdentityRequest) (*CreateIdentityResponse, error)
 	// ShowIdentity retrieves a holon's identity by UUID.
 	ShowIdentity(context.Context, *ShowIdentityRequest) (*ShowIdentityResponse, error)
+	// UpdateIdentity rewrites the fields named in update_mask, leaving the
+	// rest of the HOLON.md (other fields, comments, body) untouched.
+	UpdateIdentity(context.Context, *UpdateIdentityRequest) (*UpdateIdentityResponse, error)
+	// UpdateStatus moves several holons to one lifecycle status, reporting
+	// the outcome of each separately. Moving to DEAD also records died.
+	UpdateStatus(context.Context, *UpdateStatusRequest) (*UpdateStatusResponse, error)
+	// Reparent replaces a holon's parents, refusing unknown parents and any
+	// change that would make the lineage a cycle.
+	Reparent(context.Context, *ReparentRequest) (*ReparentResponse, error)
 	// ListIdentities scans the project for all known holons.
 	ListIdentities(context.Context, *ListIdentitiesRequest) (*ListIdentitiesResponse, error)
+	// CountIdentities returns how many holons a scan finds, broken down by
+	// clade and status, without transferring the identities themselves.
+	CountIdentities(context.Context, *CountIdentitiesRequest) (*CountIdentitiesResponse, error)
+	// ValidateContent parses and validates raw HOLON.md content without
+	// writing anything (e.g. an editor buffer on save).
+	ValidateContent(context.Context, *ValidateContentRequest) (*ValidateContentResponse, error)
+	// ListHolonFiles lists the files in a holon's directory (code, protos,
+	// ...), e.g. for a UI showing them next to the identity.
+	ListHolonFiles(context.Context, *ListHolonFilesRequest) (*ListHolonFilesResponse, error)
+	// ValidateAll validates every HOLON.md under a root, streaming one
+	// result per file as the scan proceeds, with periodic progress. It is
+	// the RPC form of `who validate`.
+	ValidateAll(*ValidateAllRequest, grpc.ServerStreamingServer[ValidationResult]) error
 	mustEmbedUnimplementedSophiaWhoServiceServer()
 }
 
@@ -115,9 +245,30 @@ func (UnimplementedSophiaWhoServiceServer) CreateIdentity(context.Context, *Crea
 func (UnimplementedSophiaWhoServiceServer) ShowIdentity(context.Context, *ShowIdentityRequest) (*ShowIdentityResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method ShowIdentity not implemented")
 }
+func (UnimplementedSophiaWhoServiceServer) UpdateIdentity(context.Context, *UpdateIdentityRequest) (*UpdateIdentityResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateIdentity not implemented")
+}
+func (UnimplementedSophiaWhoServiceServer) UpdateStatus(context.Context, *UpdateStatusRequest) (*UpdateStatusResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateStatus not implemented")
+}
+func (UnimplementedSophiaWhoServiceServer) Reparent(context.Context, *ReparentRequest) (*ReparentResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Reparent not implemented")
+}
 func (UnimplementedSophiaWhoServiceServer) ListIdentities(context.Context, *ListIdentitiesRequest) (*ListIdentitiesResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method ListIdentities not implemented")
 }
+func (UnimplementedSophiaWhoServiceServer) CountIdentities(context.Context, *CountIdentitiesRequest) (*CountIdentitiesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CountIdentities not implemented")
+}
+func (UnimplementedSophiaWhoServiceServer) ValidateContent(context.Context, *ValidateContentRequest) (*ValidateContentResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ValidateContent not implemented")
+}
+func (UnimplementedSophiaWhoServiceServer) ListHolonFiles(context.Context, *ListHolonFilesRequest) (*ListHolonFilesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListHolonFiles not implemented")
+}
+func (UnimplementedSophiaWhoServiceServer) ValidateAll(*ValidateAllRequest, grpc.ServerStreamingServer[ValidationResult]) error {
+	return status.Error(codes.Unimplemented, "method ValidateAll not implemented")
+}
 func (UnimplementedSophiaWhoServiceServer) mustEmbedUnimplementedSophiaWhoServiceServer() {}
 func (UnimplementedSophiaWhoServiceServer) testEmbeddedByValue()                          {}
 
@@ -175,6 +326,60 @@ func _SophiaWhoService_ShowIdentity_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _SophiaWhoService_UpdateIdentity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateIdentityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SophiaWhoServiceServer).UpdateIdentity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SophiaWhoService_UpdateIdentity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SophiaWhoServiceServer).UpdateIdentity(ctx, req.(*UpdateIdentityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SophiaWhoService_UpdateStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SophiaWhoServiceServer).UpdateStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SophiaWhoService_UpdateStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SophiaWhoServiceServer).UpdateStatus(ctx, req.(*UpdateStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SophiaWhoService_Reparent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReparentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SophiaWhoServiceServer).Reparent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SophiaWhoService_Reparent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SophiaWhoServiceServer).Reparent(ctx, req.(*ReparentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _SophiaWhoService_ListIdentities_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListIdentitiesRequest)
 	if err := dec(in); err != nil {
@@ -193,6 +398,71 @@ func _SophiaWhoService_ListIdentities_Handler(srv interface{}, ctx context.Conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _SophiaWhoService_CountIdentities_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CountIdentitiesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SophiaWhoServiceServer).CountIdentities(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SophiaWhoService_CountIdentities_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SophiaWhoServiceServer).CountIdentities(ctx, req.(*CountIdentitiesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SophiaWhoService_ValidateContent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidateContentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SophiaWhoServiceServer).ValidateContent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SophiaWhoService_ValidateContent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SophiaWhoServiceServer).ValidateContent(ctx, req.(*ValidateContentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SophiaWhoService_ListHolonFiles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListHolonFilesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SophiaWhoServiceServer).ListHolonFiles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SophiaWhoService_ListHolonFiles_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SophiaWhoServiceServer).ListHolonFiles(ctx, req.(*ListHolonFilesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SophiaWhoService_ValidateAll_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ValidateAllRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SophiaWhoServiceServer).ValidateAll(m, &grpc.GenericServerStream[ValidateAllRequest, ValidationResult]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SophiaWhoService_ValidateAllServer = grpc.ServerStreamingServer[ValidationResult]
+
 // SophiaWhoService_ServiceDesc is the grpc.ServiceDesc for SophiaWhoService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -208,11 +478,41 @@ var SophiaWhoService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ShowIdentity",
 			Handler:    _SophiaWhoService_ShowIdentity_Handler,
 		},
+		{
+			MethodName: "UpdateIdentity",
+			Handler:    _SophiaWhoService_UpdateIdentity_Handler,
+		},
+		{
+			MethodName: "UpdateStatus",
+			Handler:    _SophiaWhoService_UpdateStatus_Handler,
+		},
+		{
+			MethodName: "Reparent",
+			Handler:    _SophiaWhoService_Reparent_Handler,
+		},
 		{
 			MethodName: "ListIdentities",
 			Handler:    _SophiaWhoService_ListIdentities_Handler,
 		},
+		{
+			MethodName: "CountIdentities",
+			Handler:    _SophiaWhoService_CountIdentities_Handler,
+		},
+		{
+			MethodName: "ValidateContent",
+			Handler:    _SophiaWhoService_ValidateContent_Handler,
+		},
+		{
+			MethodName: "ListHolonFiles",
+			Handler:    _SophiaWhoService_ListHolonFiles_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ValidateAll",
+			Handler:       _SophiaWhoService_ValidateAll_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "protos/sophia_who/v1/sophia_who.proto",
 }
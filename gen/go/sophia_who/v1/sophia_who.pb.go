@@ -18,6 +18,7 @@ package sophiawhov1
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -160,6 +161,7 @@ const (
 	Status_STABLE             Status = 2
 	Status_DEPRECATED         Status = 3
 	Status_DEAD               Status = 4
+	Status_STATUS_CUSTOM      Status = 5 // A configured custom status; see custom_status.
 )
 
 // Enum value maps for Status.
@@ -170,6 +172,7 @@ var (
 		2: "STABLE",
 		3: "DEPRECATED",
 		4: "DEAD",
+		5: "STATUS_CUSTOM",
 	}
 	Status_value = map[string]int32{
 		"STATUS_UNSPECIFIED": 0,
@@ -177,6 +180,7 @@ var (
 		"STABLE":             2,
 		"DEPRECATED":         3,
 		"DEAD":               4,
+		"STATUS_CUSTOM":      5,
 	}
 )
 
@@ -218,27 +222,23 @@ type HolonIdentity struct {
 	Composer   string `protobuf:"bytes,5,opt,name=composer,proto3" json:"composer,omitempty"`
 	Clade      Clade  `protobuf:"varint,6,opt,name=clade,proto3,enum=sophia_who.v1.Clade" json:"clade,omitempty"`
 	Status     Status `protobuf:"varint,7,opt,name=status,proto3,enum=sophia_who.v1.Status" json:"status,omitempty"`
-	Born       string `protobuf:"bytes,8,opt,name=born,proto3" json:"born,omitempty"` // ISO 8601 date
+	Born       string `protobuf:"bytes,8,opt,name=born,proto3" json:"born,omitempty"`  // ISO 8601 date
+	Died       string `protobuf:"bytes,25,opt,name=died,proto3" json:"died,omitempty"` // ISO 8601 date, set when status becomes DEAD
 	// Lineage
 	Parents      []string         `protobuf:"bytes,9,rep,name=parents,proto3" json:"parents,omitempty"`
 	Reproduction ReproductionMode `protobuf:"varint,10,opt,name=reproduction,proto3,enum=sophia_who.v1.ReproductionMode" json:"reproduction,omitempty"`
-	// Pinning
-	BinaryPath    string   `protobuf:"bytes,11,opt,name=binary_path,json=binaryPath,proto3" json:"binary_path,omitempty"`
-	BinaryVersion string   `protobuf:"bytes,12,opt,name=binary_version,json=binaryVersion,proto3" json:"binary_version,omitempty"`
-	GitTag        string   `protobuf:"bytes,13,opt,name=git_tag,json=gitTag,proto3" json:"git_tag,omitempty"`
-	GitCommit     string   `protobuf:"bytes,14,opt,name=git_commit,json=gitCommit,proto3" json:"git_commit,omitempty"`
-	Os            string   `protobuf:"bytes,15,opt,name=os,proto3" json:"os,omitempty"`
-	Arch          string   `protobuf:"bytes,16,opt,name=arch,proto3" json:"arch,omitempty"`
-	Dependencies  []string `protobuf:"bytes,17,rep,name=dependencies,proto3" json:"dependencies,omitempty"`
 	// Optional
-	Aliases        []string `protobuf:"bytes,18,rep,name=aliases,proto3" json:"aliases,omitempty"`
-	WrappedLicense string   `protobuf:"bytes,19,opt,name=wrapped_license,json=wrappedLicense,proto3" json:"wrapped_license,omitempty"`
+	Aliases []string `protobuf:"bytes,18,rep,name=aliases,proto3" json:"aliases,omitempty"`
+	Tags    []string `protobuf:"bytes,26,rep,name=tags,proto3" json:"tags,omitempty"` // Lowercase, deduplicated.
 	// Metadata
-	GeneratedBy   string `protobuf:"bytes,20,opt,name=generated_by,json=generatedBy,proto3" json:"generated_by,omitempty"`
-	Lang          string `protobuf:"bytes,21,opt,name=lang,proto3" json:"lang,omitempty"`
-	ProtoStatus   Status `protobuf:"varint,22,opt,name=proto_status,json=protoStatus,proto3,enum=sophia_who.v1.Status" json:"proto_status,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	GeneratedBy string `protobuf:"bytes,20,opt,name=generated_by,json=generatedBy,proto3" json:"generated_by,omitempty"`
+	Lang        string `protobuf:"bytes,21,opt,name=lang,proto3" json:"lang,omitempty"`
+	ProtoStatus Status `protobuf:"varint,22,opt,name=proto_status,json=protoStatus,proto3,enum=sophia_who.v1.Status" json:"proto_status,omitempty"`
+	// Custom statuses (set when status/proto_status is STATUS_CUSTOM)
+	CustomStatus      string `protobuf:"bytes,23,opt,name=custom_status,json=customStatus,proto3" json:"custom_status,omitempty"`
+	CustomProtoStatus string `protobuf:"bytes,24,opt,name=custom_proto_status,json=customProtoStatus,proto3" json:"custom_proto_status,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
 func (x *HolonIdentity) Reset() {
@@ -327,6 +327,13 @@ func (x *HolonIdentity) GetBorn() string {
 	return ""
 }
 
+func (x *HolonIdentity) GetDied() string {
+	if x != nil {
+		return x.Died
+	}
+	return ""
+}
+
 func (x *HolonIdentity) GetParents() []string {
 	if x != nil {
 		return x.Parents
@@ -341,55 +348,6 @@ func (x *HolonIdentity) GetReproduction() ReproductionMode {
 	return ReproductionMode_REPRODUCTION_UNSPECIFIED
 }
 
-func (x *HolonIdentity) GetBinaryPath() string {
-	if x != nil {
-		return x.BinaryPath
-	}
-	return ""
-}
-
-func (x *HolonIdentity) GetBinaryVersion() string {
-	if x != nil {
-		return x.BinaryVersion
-	}
-	return ""
-}
-
-func (x *HolonIdentity) GetGitTag() string {
-	if x != nil {
-		return x.GitTag
-	}
-	return ""
-}
-
-func (x *HolonIdentity) GetGitCommit() string {
-	if x != nil {
-		return x.GitCommit
-	}
-	return ""
-}
-
-func (x *HolonIdentity) GetOs() string {
-	if x != nil {
-		return x.Os
-	}
-	return ""
-}
-
-func (x *HolonIdentity) GetArch() string {
-	if x != nil {
-		return x.Arch
-	}
-	return ""
-}
-
-func (x *HolonIdentity) GetDependencies() []string {
-	if x != nil {
-		return x.Dependencies
-	}
-	return nil
-}
-
 func (x *HolonIdentity) GetAliases() []string {
 	if x != nil {
 		return x.Aliases
@@ -397,11 +355,11 @@ func (x *HolonIdentity) GetAliases() []string {
 	return nil
 }
 
-func (x *HolonIdentity) GetWrappedLicense() string {
+func (x *HolonIdentity) GetTags() []string {
 	if x != nil {
-		return x.WrappedLicense
+		return x.Tags
 	}
-	return ""
+	return nil
 }
 
 func (x *HolonIdentity) GetGeneratedBy() string {
@@ -425,20 +383,38 @@ func (x *HolonIdentity) GetProtoStatus() Status {
 	return Status_STATUS_UNSPECIFIED
 }
 
+func (x *HolonIdentity) GetCustomStatus() string {
+	if x != nil {
+		return x.CustomStatus
+	}
+	return ""
+}
+
+func (x *HolonIdentity) GetCustomProtoStatus() string {
+	if x != nil {
+		return x.CustomProtoStatus
+	}
+	return ""
+}
+
 type CreateIdentityRequest struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	GivenName      string                 `protobuf:"bytes,1,opt,name=given_name,json=givenName,proto3" json:"given_name,omitempty"`    // Required.
-	FamilyName     string                 `protobuf:"bytes,2,opt,name=family_name,json=familyName,proto3" json:"family_name,omitempty"` // Required.
-	Motto          string                 `protobuf:"bytes,3,opt,name=motto,proto3" json:"motto,omitempty"`                             // Required.
-	Composer       string                 `protobuf:"bytes,4,opt,name=composer,proto3" json:"composer,omitempty"`                       // Required.
-	Clade          Clade                  `protobuf:"varint,5,opt,name=clade,proto3,enum=sophia_who.v1.Clade" json:"clade,omitempty"`   // Required.
-	Reproduction   ReproductionMode       `protobuf:"varint,6,opt,name=reproduction,proto3,enum=sophia_who.v1.ReproductionMode" json:"reproduction,omitempty"`
-	Lang           string                 `protobuf:"bytes,7,opt,name=lang,proto3" json:"lang,omitempty"`
-	Aliases        []string               `protobuf:"bytes,8,rep,name=aliases,proto3" json:"aliases,omitempty"`
-	WrappedLicense string                 `protobuf:"bytes,9,opt,name=wrapped_license,json=wrappedLicense,proto3" json:"wrapped_license,omitempty"`
-	OutputDir      string                 `protobuf:"bytes,10,opt,name=output_dir,json=outputDir,proto3" json:"output_dir,omitempty"` // Default: holons/<name>/
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GivenName     string                 `protobuf:"bytes,1,opt,name=given_name,json=givenName,proto3" json:"given_name,omitempty"`    // Required.
+	FamilyName    string                 `protobuf:"bytes,2,opt,name=family_name,json=familyName,proto3" json:"family_name,omitempty"` // Required.
+	Motto         string                 `protobuf:"bytes,3,opt,name=motto,proto3" json:"motto,omitempty"`                             // Required.
+	Composer      string                 `protobuf:"bytes,4,opt,name=composer,proto3" json:"composer,omitempty"`                       // Required.
+	Clade         Clade                  `protobuf:"varint,5,opt,name=clade,proto3,enum=sophia_who.v1.Clade" json:"clade,omitempty"`   // Required.
+	Reproduction  ReproductionMode       `protobuf:"varint,6,opt,name=reproduction,proto3,enum=sophia_who.v1.ReproductionMode" json:"reproduction,omitempty"`
+	Lang          string                 `protobuf:"bytes,7,opt,name=lang,proto3" json:"lang,omitempty"`
+	Aliases       []string               `protobuf:"bytes,8,rep,name=aliases,proto3" json:"aliases,omitempty"`
+	OutputDir     string                 `protobuf:"bytes,10,opt,name=output_dir,json=outputDir,proto3" json:"output_dir,omitempty"` // Default: holons/<name>/
+	Parents       []string               `protobuf:"bytes,11,rep,name=parents,proto3" json:"parents,omitempty"`                      // Parent UUIDs; distinct when reproduction is BRED.
+	Born          string                 `protobuf:"bytes,12,opt,name=born,proto3" json:"born,omitempty"`                            // YYYY-MM-DD, not in the future. Default: today.
+	OmitBody      bool                   `protobuf:"varint,13,opt,name=omit_body,json=omitBody,proto3" json:"omit_body,omitempty"`   // Write only the frontmatter and title line.
+	Tags          []string               `protobuf:"bytes,14,rep,name=tags,proto3" json:"tags,omitempty"`                            // Normalized to lowercase and deduplicated.
+	Strict        bool                   `protobuf:"varint,15,opt,name=strict,proto3" json:"strict,omitempty"`                       // Reject what would otherwise be a warning, e.g. given_name equal to family_name.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *CreateIdentityRequest) Reset() {
@@ -527,24 +503,54 @@ func (x *CreateIdentityRequest) GetAliases() []string {
 	return nil
 }
 
-func (x *CreateIdentityRequest) GetWrappedLicense() string {
+func (x *CreateIdentityRequest) GetOutputDir() string {
 	if x != nil {
-		return x.WrappedLicense
+		return x.OutputDir
 	}
 	return ""
 }
 
-func (x *CreateIdentityRequest) GetOutputDir() string {
+func (x *CreateIdentityRequest) GetParents() []string {
 	if x != nil {
-		return x.OutputDir
+		return x.Parents
+	}
+	return nil
+}
+
+func (x *CreateIdentityRequest) GetBorn() string {
+	if x != nil {
+		return x.Born
 	}
 	return ""
 }
 
+func (x *CreateIdentityRequest) GetOmitBody() bool {
+	if x != nil {
+		return x.OmitBody
+	}
+	return false
+}
+
+func (x *CreateIdentityRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *CreateIdentityRequest) GetStrict() bool {
+	if x != nil {
+		return x.Strict
+	}
+	return false
+}
+
 type CreateIdentityResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Identity      *HolonIdentity         `protobuf:"bytes,1,opt,name=identity,proto3" json:"identity,omitempty"`
-	FilePath      string                 `protobuf:"bytes,2,opt,name=file_path,json=filePath,proto3" json:"file_path,omitempty"` // Where HOLON.md was written.
+	FilePath      string                 `protobuf:"bytes,2,opt,name=file_path,json=filePath,proto3" json:"file_path,omitempty"`       // Where HOLON.md was written.
+	Warnings      []string               `protobuf:"bytes,3,rep,name=warnings,proto3" json:"warnings,omitempty"`                       // Non-fatal issues (e.g. directory/name mismatch).
+	RawContent    string                 `protobuf:"bytes,4,opt,name=raw_content,json=rawContent,proto3" json:"raw_content,omitempty"` // The HOLON.md content written to file_path.
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -593,9 +599,25 @@ func (x *CreateIdentityResponse) GetFilePath() string {
 	return ""
 }
 
+func (x *CreateIdentityResponse) GetWarnings() []string {
+	if x != nil {
+		return x.Warnings
+	}
+	return nil
+}
+
+func (x *CreateIdentityResponse) GetRawContent() string {
+	if x != nil {
+		return x.RawContent
+	}
+	return ""
+}
+
 type ShowIdentityRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Uuid          string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"` // Full UUID or prefix.
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Full UUID, unique prefix, "Given Family" name, alias, or the path of
+	// a holon directory under the server root.
+	Uuid          string `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -697,27 +719,31 @@ func (x *ShowIdentityResponse) GetRawContent() string {
 	return ""
 }
 
-type ListIdentitiesRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	RootDir       string                 `protobuf:"bytes,1,opt,name=root_dir,json=rootDir,proto3" json:"root_dir,omitempty"` // Directory to scan. Default: current dir.
+type UpdateIdentityRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Uuid     string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`         // Full UUID or prefix.
+	Identity *HolonIdentity         `protobuf:"bytes,2,opt,name=identity,proto3" json:"identity,omitempty"` // New values for the fields in update_mask.
+	// HolonIdentity field paths to update, e.g. "motto". A listed field left
+	// at its zero value is cleared. uuid cannot be updated.
+	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,3,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListIdentitiesRequest) Reset() {
-	*x = ListIdentitiesRequest{}
+func (x *UpdateIdentityRequest) Reset() {
+	*x = UpdateIdentityRequest{}
 	mi := &file_protos_sophia_who_v1_sophia_who_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListIdentitiesRequest) String() string {
+func (x *UpdateIdentityRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListIdentitiesRequest) ProtoMessage() {}
+func (*UpdateIdentityRequest) ProtoMessage() {}
 
-func (x *ListIdentitiesRequest) ProtoReflect() protoreflect.Message {
+func (x *UpdateIdentityRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_protos_sophia_who_v1_sophia_who_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -729,39 +755,54 @@ func (x *ListIdentitiesRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListIdentitiesRequest.ProtoReflect.Descriptor instead.
-func (*ListIdentitiesRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use UpdateIdentityRequest.ProtoReflect.Descriptor instead.
+func (*UpdateIdentityRequest) Descriptor() ([]byte, []int) {
 	return file_protos_sophia_who_v1_sophia_who_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *ListIdentitiesRequest) GetRootDir() string {
+func (x *UpdateIdentityRequest) GetUuid() string {
 	if x != nil {
-		return x.RootDir
+		return x.Uuid
 	}
 	return ""
 }
 
-type ListIdentitiesResponse struct {
+func (x *UpdateIdentityRequest) GetIdentity() *HolonIdentity {
+	if x != nil {
+		return x.Identity
+	}
+	return nil
+}
+
+func (x *UpdateIdentityRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+type UpdateIdentityResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Entries       []*HolonEntry          `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	Identity      *HolonIdentity         `protobuf:"bytes,1,opt,name=identity,proto3" json:"identity,omitempty"`
+	FilePath      string                 `protobuf:"bytes,2,opt,name=file_path,json=filePath,proto3" json:"file_path,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListIdentitiesResponse) Reset() {
-	*x = ListIdentitiesResponse{}
+func (x *UpdateIdentityResponse) Reset() {
+	*x = UpdateIdentityResponse{}
 	mi := &file_protos_sophia_who_v1_sophia_who_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListIdentitiesResponse) String() string {
+func (x *UpdateIdentityResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListIdentitiesResponse) ProtoMessage() {}
+func (*UpdateIdentityResponse) ProtoMessage() {}
 
-func (x *ListIdentitiesResponse) ProtoReflect() protoreflect.Message {
+func (x *UpdateIdentityResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_protos_sophia_who_v1_sophia_who_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -773,42 +814,47 @@ func (x *ListIdentitiesResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListIdentitiesResponse.ProtoReflect.Descriptor instead.
-func (*ListIdentitiesResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use UpdateIdentityResponse.ProtoReflect.Descriptor instead.
+func (*UpdateIdentityResponse) Descriptor() ([]byte, []int) {
 	return file_protos_sophia_who_v1_sophia_who_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *ListIdentitiesResponse) GetEntries() []*HolonEntry {
+func (x *UpdateIdentityResponse) GetIdentity() *HolonIdentity {
 	if x != nil {
-		return x.Entries
+		return x.Identity
 	}
 	return nil
 }
 
-// HolonEntry pairs an identity with its origin (local or cached).
-type HolonEntry struct {
+func (x *UpdateIdentityResponse) GetFilePath() string {
+	if x != nil {
+		return x.FilePath
+	}
+	return ""
+}
+
+type UpdateStatusRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Identity      *HolonIdentity         `protobuf:"bytes,1,opt,name=identity,proto3" json:"identity,omitempty"`
-	Origin        string                 `protobuf:"bytes,2,opt,name=origin,proto3" json:"origin,omitempty"`                                 // "local" or "cached"
-	RelativePath  string                 `protobuf:"bytes,3,opt,name=relative_path,json=relativePath,proto3" json:"relative_path,omitempty"` // Holon directory, relative to the scan root.
+	Uuids         []string               `protobuf:"bytes,1,rep,name=uuids,proto3" json:"uuids,omitempty"`                          // Full UUIDs, prefixes, names, or aliases.
+	NewStatus     string                 `protobuf:"bytes,2,opt,name=new_status,json=newStatus,proto3" json:"new_status,omitempty"` // HOLON.md status, e.g. "deprecated".
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *HolonEntry) Reset() {
-	*x = HolonEntry{}
+func (x *UpdateStatusRequest) Reset() {
+	*x = UpdateStatusRequest{}
 	mi := &file_protos_sophia_who_v1_sophia_who_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *HolonEntry) String() string {
+func (x *UpdateStatusRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*HolonEntry) ProtoMessage() {}
+func (*UpdateStatusRequest) ProtoMessage() {}
 
-func (x *HolonEntry) ProtoReflect() protoreflect.Message {
+func (x *UpdateStatusRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_protos_sophia_who_v1_sophia_who_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -820,59 +866,46 @@ func (x *HolonEntry) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use HolonEntry.ProtoReflect.Descriptor instead.
-func (*HolonEntry) Descriptor() ([]byte, []int) {
+// Deprecated: Use UpdateStatusRequest.ProtoReflect.Descriptor instead.
+func (*UpdateStatusRequest) Descriptor() ([]byte, []int) {
 	return file_protos_sophia_who_v1_sophia_who_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *HolonEntry) GetIdentity() *HolonIdentity {
+func (x *UpdateStatusRequest) GetUuids() []string {
 	if x != nil {
-		return x.Identity
+		return x.Uuids
 	}
 	return nil
 }
 
-func (x *HolonEntry) GetOrigin() string {
-	if x != nil {
-		return x.Origin
-	}
-	return ""
-}
-
-func (x *HolonEntry) GetRelativePath() string {
+func (x *UpdateStatusRequest) GetNewStatus() string {
 	if x != nil {
-		return x.RelativePath
+		return x.NewStatus
 	}
 	return ""
 }
 
-type PinVersionRequest struct {
+type UpdateStatusResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Uuid          string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"` // Target holon UUID.
-	BinaryPath    string                 `protobuf:"bytes,2,opt,name=binary_path,json=binaryPath,proto3" json:"binary_path,omitempty"`
-	BinaryVersion string                 `protobuf:"bytes,3,opt,name=binary_version,json=binaryVersion,proto3" json:"binary_version,omitempty"`
-	GitTag        string                 `protobuf:"bytes,4,opt,name=git_tag,json=gitTag,proto3" json:"git_tag,omitempty"`
-	GitCommit     string                 `protobuf:"bytes,5,opt,name=git_commit,json=gitCommit,proto3" json:"git_commit,omitempty"`
-	Os            string                 `protobuf:"bytes,6,opt,name=os,proto3" json:"os,omitempty"`
-	Arch          string                 `protobuf:"bytes,7,opt,name=arch,proto3" json:"arch,omitempty"`
+	Results       []*UpdateStatusResult  `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"` // One per requested uuid, in order.
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PinVersionRequest) Reset() {
-	*x = PinVersionRequest{}
+func (x *UpdateStatusResponse) Reset() {
+	*x = UpdateStatusResponse{}
 	mi := &file_protos_sophia_who_v1_sophia_who_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PinVersionRequest) String() string {
+func (x *UpdateStatusResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PinVersionRequest) ProtoMessage() {}
+func (*UpdateStatusResponse) ProtoMessage() {}
 
-func (x *PinVersionRequest) ProtoReflect() protoreflect.Message {
+func (x *UpdateStatusResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_protos_sophia_who_v1_sophia_who_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -884,82 +917,162 @@ func (x *PinVersionRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PinVersionRequest.ProtoReflect.Descriptor instead.
-func (*PinVersionRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use UpdateStatusResponse.ProtoReflect.Descriptor instead.
+func (*UpdateStatusResponse) Descriptor() ([]byte, []int) {
 	return file_protos_sophia_who_v1_sophia_who_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *PinVersionRequest) GetUuid() string {
+func (x *UpdateStatusResponse) GetResults() []*UpdateStatusResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type UpdateStatusResult struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Uuid           string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"` // As requested.
+	FilePath       string                 `protobuf:"bytes,2,opt,name=file_path,json=filePath,proto3" json:"file_path,omitempty"`
+	PreviousStatus string                 `protobuf:"bytes,3,opt,name=previous_status,json=previousStatus,proto3" json:"previous_status,omitempty"`
+	Error          string                 `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"` // Empty when the holon was updated.
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *UpdateStatusResult) Reset() {
+	*x = UpdateStatusResult{}
+	mi := &file_protos_sophia_who_v1_sophia_who_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateStatusResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateStatusResult) ProtoMessage() {}
+
+func (x *UpdateStatusResult) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_sophia_who_v1_sophia_who_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateStatusResult.ProtoReflect.Descriptor instead.
+func (*UpdateStatusResult) Descriptor() ([]byte, []int) {
+	return file_protos_sophia_who_v1_sophia_who_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *UpdateStatusResult) GetUuid() string {
 	if x != nil {
 		return x.Uuid
 	}
 	return ""
 }
 
-func (x *PinVersionRequest) GetBinaryPath() string {
+func (x *UpdateStatusResult) GetFilePath() string {
 	if x != nil {
-		return x.BinaryPath
+		return x.FilePath
 	}
 	return ""
 }
 
-func (x *PinVersionRequest) GetBinaryVersion() string {
+func (x *UpdateStatusResult) GetPreviousStatus() string {
 	if x != nil {
-		return x.BinaryVersion
+		return x.PreviousStatus
 	}
 	return ""
 }
 
-func (x *PinVersionRequest) GetGitTag() string {
+func (x *UpdateStatusResult) GetError() string {
 	if x != nil {
-		return x.GitTag
+		return x.Error
 	}
 	return ""
 }
 
-func (x *PinVersionRequest) GetGitCommit() string {
+type ReparentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uuid          string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`       // Full UUID, prefix, name, or alias.
+	Parents       []string               `protobuf:"bytes,2,rep,name=parents,proto3" json:"parents,omitempty"` // New parent UUIDs or prefixes. Empty clears them.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReparentRequest) Reset() {
+	*x = ReparentRequest{}
+	mi := &file_protos_sophia_who_v1_sophia_who_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReparentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReparentRequest) ProtoMessage() {}
+
+func (x *ReparentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_sophia_who_v1_sophia_who_proto_msgTypes[10]
 	if x != nil {
-		return x.GitCommit
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *PinVersionRequest) GetOs() string {
+// Deprecated: Use ReparentRequest.ProtoReflect.Descriptor instead.
+func (*ReparentRequest) Descriptor() ([]byte, []int) {
+	return file_protos_sophia_who_v1_sophia_who_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ReparentRequest) GetUuid() string {
 	if x != nil {
-		return x.Os
+		return x.Uuid
 	}
 	return ""
 }
 
-func (x *PinVersionRequest) GetArch() string {
+func (x *ReparentRequest) GetParents() []string {
 	if x != nil {
-		return x.Arch
+		return x.Parents
 	}
-	return ""
+	return nil
 }
 
-type PinVersionResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Identity      *HolonIdentity         `protobuf:"bytes,1,opt,name=identity,proto3" json:"identity,omitempty"` // Updated identity after pinning.
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+type ReparentResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Identity        *HolonIdentity         `protobuf:"bytes,1,opt,name=identity,proto3" json:"identity,omitempty"`
+	FilePath        string                 `protobuf:"bytes,2,opt,name=file_path,json=filePath,proto3" json:"file_path,omitempty"`
+	PreviousParents []string               `protobuf:"bytes,3,rep,name=previous_parents,json=previousParents,proto3" json:"previous_parents,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
-func (x *PinVersionResponse) Reset() {
-	*x = PinVersionResponse{}
-	mi := &file_protos_sophia_who_v1_sophia_who_proto_msgTypes[9]
+func (x *ReparentResponse) Reset() {
+	*x = ReparentResponse{}
+	mi := &file_protos_sophia_who_v1_sophia_who_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PinVersionResponse) String() string {
+func (x *ReparentResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PinVersionResponse) ProtoMessage() {}
+func (*ReparentResponse) ProtoMessage() {}
 
-func (x *PinVersionResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_protos_sophia_who_v1_sophia_who_proto_msgTypes[9]
+func (x *ReparentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_sophia_who_v1_sophia_who_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -970,97 +1083,1065 @@ func (x *PinVersionResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PinVersionResponse.ProtoReflect.Descriptor instead.
-func (*PinVersionResponse) Descriptor() ([]byte, []int) {
-	return file_protos_sophia_who_v1_sophia_who_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use ReparentResponse.ProtoReflect.Descriptor instead.
+func (*ReparentResponse) Descriptor() ([]byte, []int) {
+	return file_protos_sophia_who_v1_sophia_who_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *PinVersionResponse) GetIdentity() *HolonIdentity {
+func (x *ReparentResponse) GetIdentity() *HolonIdentity {
 	if x != nil {
 		return x.Identity
 	}
 	return nil
 }
 
-var File_protos_sophia_who_v1_sophia_who_proto protoreflect.FileDescriptor
+func (x *ReparentResponse) GetFilePath() string {
+	if x != nil {
+		return x.FilePath
+	}
+	return ""
+}
 
-const file_protos_sophia_who_v1_sophia_who_proto_rawDesc = "" +
-	"\n" +
-	"%protos/sophia_who/v1/sophia_who.proto\x12\rsophia_who.v1\"\xdf\x05\n" +
-	"\rHolonIdentity\x12\x12\n" +
-	"\x04uuid\x18\x01 \x01(\tR\x04uuid\x12\x1d\n" +
-	"\n" +
-	"given_name\x18\x02 \x01(\tR\tgivenName\x12\x1f\n" +
-	"\vfamily_name\x18\x03 \x01(\tR\n" +
-	"familyName\x12\x14\n" +
-	"\x05motto\x18\x04 \x01(\tR\x05motto\x12\x1a\n" +
-	"\bcomposer\x18\x05 \x01(\tR\bcomposer\x12*\n" +
-	"\x05clade\x18\x06 \x01(\x0e2\x14.sophia_who.v1.CladeR\x05clade\x12-\n" +
-	"\x06status\x18\a \x01(\x0e2\x15.sophia_who.v1.StatusR\x06status\x12\x12\n" +
-	"\x04born\x18\b \x01(\tR\x04born\x12\x18\n" +
-	"\aparents\x18\t \x03(\tR\aparents\x12C\n" +
-	"\freproduction\x18\n" +
-	" \x01(\x0e2\x1f.sophia_who.v1.ReproductionModeR\freproduction\x12\x1f\n" +
-	"\vbinary_path\x18\v \x01(\tR\n" +
-	"binaryPath\x12%\n" +
-	"\x0ebinary_version\x18\f \x01(\tR\rbinaryVersion\x12\x17\n" +
-	"\agit_tag\x18\r \x01(\tR\x06gitTag\x12\x1d\n" +
-	"\n" +
-	"git_commit\x18\x0e \x01(\tR\tgitCommit\x12\x0e\n" +
-	"\x02os\x18\x0f \x01(\tR\x02os\x12\x12\n" +
-	"\x04arch\x18\x10 \x01(\tR\x04arch\x12\"\n" +
-	"\fdependencies\x18\x11 \x03(\tR\fdependencies\x12\x18\n" +
-	"\aaliases\x18\x12 \x03(\tR\aaliases\x12'\n" +
-	"\x0fwrapped_license\x18\x13 \x01(\tR\x0ewrappedLicense\x12!\n" +
-	"\fgenerated_by\x18\x14 \x01(\tR\vgeneratedBy\x12\x12\n" +
-	"\x04lang\x18\x15 \x01(\tR\x04lang\x128\n" +
-	"\fproto_status\x18\x16 \x01(\x0e2\x15.sophia_who.v1.StatusR\vprotoStatus\"\xf0\x02\n" +
-	"\x15CreateIdentityRequest\x12\x1d\n" +
-	"\n" +
-	"given_name\x18\x01 \x01(\tR\tgivenName\x12\x1f\n" +
-	"\vfamily_name\x18\x02 \x01(\tR\n" +
-	"familyName\x12\x14\n" +
+func (x *ReparentResponse) GetPreviousParents() []string {
+	if x != nil {
+		return x.PreviousParents
+	}
+	return nil
+}
+
+type ListIdentitiesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RootDir       string                 `protobuf:"bytes,1,opt,name=root_dir,json=rootDir,proto3" json:"root_dir,omitempty"`                 // Directory to scan. Default: current dir.
+	IncludeStats  bool                   `protobuf:"varint,2,opt,name=include_stats,json=includeStats,proto3" json:"include_stats,omitempty"` // Fill dir_file_count and dir_size_bytes (slower).
+	Tags          []string               `protobuf:"bytes,3,rep,name=tags,proto3" json:"tags,omitempty"`                                      // Only holons carrying all of these tags.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListIdentitiesRequest) Reset() {
+	*x = ListIdentitiesRequest{}
+	mi := &file_protos_sophia_who_v1_sophia_who_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListIdentitiesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListIdentitiesRequest) ProtoMessage() {}
+
+func (x *ListIdentitiesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_sophia_who_v1_sophia_who_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListIdentitiesRequest.ProtoReflect.Descriptor instead.
+func (*ListIdentitiesRequest) Descriptor() ([]byte, []int) {
+	return file_protos_sophia_who_v1_sophia_who_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ListIdentitiesRequest) GetRootDir() string {
+	if x != nil {
+		return x.RootDir
+	}
+	return ""
+}
+
+func (x *ListIdentitiesRequest) GetIncludeStats() bool {
+	if x != nil {
+		return x.IncludeStats
+	}
+	return false
+}
+
+func (x *ListIdentitiesRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+type ListIdentitiesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entries       []*HolonEntry          `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	Truncated     bool                   `protobuf:"varint,2,opt,name=truncated,proto3" json:"truncated,omitempty"` // The scan stopped at the server's result cap.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListIdentitiesResponse) Reset() {
+	*x = ListIdentitiesResponse{}
+	mi := &file_protos_sophia_who_v1_sophia_who_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListIdentitiesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListIdentitiesResponse) ProtoMessage() {}
+
+func (x *ListIdentitiesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_sophia_who_v1_sophia_who_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListIdentitiesResponse.ProtoReflect.Descriptor instead.
+func (*ListIdentitiesResponse) Descriptor() ([]byte, []int) {
+	return file_protos_sophia_who_v1_sophia_who_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ListIdentitiesResponse) GetEntries() []*HolonEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+func (x *ListIdentitiesResponse) GetTruncated() bool {
+	if x != nil {
+		return x.Truncated
+	}
+	return false
+}
+
+// HolonEntry pairs an identity with its origin (local or cached).
+type HolonEntry struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Identity     *HolonIdentity         `protobuf:"bytes,1,opt,name=identity,proto3" json:"identity,omitempty"`
+	Origin       string                 `protobuf:"bytes,2,opt,name=origin,proto3" json:"origin,omitempty"`                                 // "local" or "cached"
+	RelativePath string                 `protobuf:"bytes,3,opt,name=relative_path,json=relativePath,proto3" json:"relative_path,omitempty"` // Holon directory, relative to the scan root (absolute if outside it).
+	// Set when include_stats is requested. Nested holon directories are
+	// not counted.
+	DirFileCount  int64 `protobuf:"varint,4,opt,name=dir_file_count,json=dirFileCount,proto3" json:"dir_file_count,omitempty"` // Regular files under the holon directory.
+	DirSizeBytes  int64 `protobuf:"varint,5,opt,name=dir_size_bytes,json=dirSizeBytes,proto3" json:"dir_size_bytes,omitempty"` // Their total size.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HolonEntry) Reset() {
+	*x = HolonEntry{}
+	mi := &file_protos_sophia_who_v1_sophia_who_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HolonEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HolonEntry) ProtoMessage() {}
+
+func (x *HolonEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_sophia_who_v1_sophia_who_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HolonEntry.ProtoReflect.Descriptor instead.
+func (*HolonEntry) Descriptor() ([]byte, []int) {
+	return file_protos_sophia_who_v1_sophia_who_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *HolonEntry) GetIdentity() *HolonIdentity {
+	if x != nil {
+		return x.Identity
+	}
+	return nil
+}
+
+func (x *HolonEntry) GetOrigin() string {
+	if x != nil {
+		return x.Origin
+	}
+	return ""
+}
+
+func (x *HolonEntry) GetRelativePath() string {
+	if x != nil {
+		return x.RelativePath
+	}
+	return ""
+}
+
+func (x *HolonEntry) GetDirFileCount() int64 {
+	if x != nil {
+		return x.DirFileCount
+	}
+	return 0
+}
+
+func (x *HolonEntry) GetDirSizeBytes() int64 {
+	if x != nil {
+		return x.DirSizeBytes
+	}
+	return 0
+}
+
+type CountIdentitiesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RootDir       string                 `protobuf:"bytes,1,opt,name=root_dir,json=rootDir,proto3" json:"root_dir,omitempty"` // Directory to scan. Default: current dir.
+	Clade         string                 `protobuf:"bytes,2,opt,name=clade,proto3" json:"clade,omitempty"`                    // Only count this clade. Default: all.
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`                  // Only count this status. Default: all.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CountIdentitiesRequest) Reset() {
+	*x = CountIdentitiesRequest{}
+	mi := &file_protos_sophia_who_v1_sophia_who_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CountIdentitiesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CountIdentitiesRequest) ProtoMessage() {}
+
+func (x *CountIdentitiesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_sophia_who_v1_sophia_who_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CountIdentitiesRequest.ProtoReflect.Descriptor instead.
+func (*CountIdentitiesRequest) Descriptor() ([]byte, []int) {
+	return file_protos_sophia_who_v1_sophia_who_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *CountIdentitiesRequest) GetRootDir() string {
+	if x != nil {
+		return x.RootDir
+	}
+	return ""
+}
+
+func (x *CountIdentitiesRequest) GetClade() string {
+	if x != nil {
+		return x.Clade
+	}
+	return ""
+}
+
+func (x *CountIdentitiesRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type CountIdentitiesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Total         int32                  `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"`
+	ByClade       map[string]int32       `protobuf:"bytes,2,rep,name=by_clade,json=byClade,proto3" json:"by_clade,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`    // Keyed by clade, e.g. "deterministic/pure".
+	ByStatus      map[string]int32       `protobuf:"bytes,3,rep,name=by_status,json=byStatus,proto3" json:"by_status,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"` // Keyed by status, e.g. "draft".
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CountIdentitiesResponse) Reset() {
+	*x = CountIdentitiesResponse{}
+	mi := &file_protos_sophia_who_v1_sophia_who_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CountIdentitiesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CountIdentitiesResponse) ProtoMessage() {}
+
+func (x *CountIdentitiesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_sophia_who_v1_sophia_who_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CountIdentitiesResponse.ProtoReflect.Descriptor instead.
+func (*CountIdentitiesResponse) Descriptor() ([]byte, []int) {
+	return file_protos_sophia_who_v1_sophia_who_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *CountIdentitiesResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *CountIdentitiesResponse) GetByClade() map[string]int32 {
+	if x != nil {
+		return x.ByClade
+	}
+	return nil
+}
+
+func (x *CountIdentitiesResponse) GetByStatus() map[string]int32 {
+	if x != nil {
+		return x.ByStatus
+	}
+	return nil
+}
+
+type ValidateContentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RawContent    string                 `protobuf:"bytes,1,opt,name=raw_content,json=rawContent,proto3" json:"raw_content,omitempty"` // The full HOLON.md content to check.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidateContentRequest) Reset() {
+	*x = ValidateContentRequest{}
+	mi := &file_protos_sophia_who_v1_sophia_who_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateContentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateContentRequest) ProtoMessage() {}
+
+func (x *ValidateContentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_sophia_who_v1_sophia_who_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateContentRequest.ProtoReflect.Descriptor instead.
+func (*ValidateContentRequest) Descriptor() ([]byte, []int) {
+	return file_protos_sophia_who_v1_sophia_who_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ValidateContentRequest) GetRawContent() string {
+	if x != nil {
+		return x.RawContent
+	}
+	return ""
+}
+
+type ValidateContentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Valid         bool                   `protobuf:"varint,1,opt,name=valid,proto3" json:"valid,omitempty"`
+	Errors        []*ValidationError     `protobuf:"bytes,2,rep,name=errors,proto3" json:"errors,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidateContentResponse) Reset() {
+	*x = ValidateContentResponse{}
+	mi := &file_protos_sophia_who_v1_sophia_who_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateContentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateContentResponse) ProtoMessage() {}
+
+func (x *ValidateContentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_sophia_who_v1_sophia_who_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateContentResponse.ProtoReflect.Descriptor instead.
+func (*ValidateContentResponse) Descriptor() ([]byte, []int) {
+	return file_protos_sophia_who_v1_sophia_who_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ValidateContentResponse) GetValid() bool {
+	if x != nil {
+		return x.Valid
+	}
+	return false
+}
+
+func (x *ValidateContentResponse) GetErrors() []*ValidationError {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+// ValidationError describes one problem found in HOLON.md content.
+type ValidationError struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Field         string                 `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"` // Frontmatter key, empty for parse errors.
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Line          int32                  `protobuf:"varint,3,opt,name=line,proto3" json:"line,omitempty"` // 1-based file line, 0 when unknown.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidationError) Reset() {
+	*x = ValidationError{}
+	mi := &file_protos_sophia_who_v1_sophia_who_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidationError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidationError) ProtoMessage() {}
+
+func (x *ValidationError) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_sophia_who_v1_sophia_who_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidationError.ProtoReflect.Descriptor instead.
+func (*ValidationError) Descriptor() ([]byte, []int) {
+	return file_protos_sophia_who_v1_sophia_who_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ValidationError) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *ValidationError) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ValidationError) GetLine() int32 {
+	if x != nil {
+		return x.Line
+	}
+	return 0
+}
+
+type ListHolonFilesRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Uuid           string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`                                              // Any target ShowIdentity accepts.
+	Recursive      bool                   `protobuf:"varint,2,opt,name=recursive,proto3" json:"recursive,omitempty"`                                   // Descend into subdirectories, except nested holons. Default: top level only.
+	ExcludeHolonMd bool                   `protobuf:"varint,3,opt,name=exclude_holon_md,json=excludeHolonMd,proto3" json:"exclude_holon_md,omitempty"` // Leave the holon's own HOLON.md out.
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListHolonFilesRequest) Reset() {
+	*x = ListHolonFilesRequest{}
+	mi := &file_protos_sophia_who_v1_sophia_who_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListHolonFilesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListHolonFilesRequest) ProtoMessage() {}
+
+func (x *ListHolonFilesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_sophia_who_v1_sophia_who_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListHolonFilesRequest.ProtoReflect.Descriptor instead.
+func (*ListHolonFilesRequest) Descriptor() ([]byte, []int) {
+	return file_protos_sophia_who_v1_sophia_who_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ListHolonFilesRequest) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *ListHolonFilesRequest) GetRecursive() bool {
+	if x != nil {
+		return x.Recursive
+	}
+	return false
+}
+
+func (x *ListHolonFilesRequest) GetExcludeHolonMd() bool {
+	if x != nil {
+		return x.ExcludeHolonMd
+	}
+	return false
+}
+
+type ListHolonFilesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Directory     string                 `protobuf:"bytes,1,opt,name=directory,proto3" json:"directory,omitempty"` // The holon directory.
+	Files         []*HolonFile           `protobuf:"bytes,2,rep,name=files,proto3" json:"files,omitempty"`         // Regular files, sorted by name.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListHolonFilesResponse) Reset() {
+	*x = ListHolonFilesResponse{}
+	mi := &file_protos_sophia_who_v1_sophia_who_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListHolonFilesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListHolonFilesResponse) ProtoMessage() {}
+
+func (x *ListHolonFilesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_sophia_who_v1_sophia_who_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListHolonFilesResponse.ProtoReflect.Descriptor instead.
+func (*ListHolonFilesResponse) Descriptor() ([]byte, []int) {
+	return file_protos_sophia_who_v1_sophia_who_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ListHolonFilesResponse) GetDirectory() string {
+	if x != nil {
+		return x.Directory
+	}
+	return ""
+}
+
+func (x *ListHolonFilesResponse) GetFiles() []*HolonFile {
+	if x != nil {
+		return x.Files
+	}
+	return nil
+}
+
+// HolonFile is one regular file in a holon directory.
+type HolonFile struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"` // Slash-separated, relative to the holon directory.
+	SizeBytes     int64                  `protobuf:"varint,2,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HolonFile) Reset() {
+	*x = HolonFile{}
+	mi := &file_protos_sophia_who_v1_sophia_who_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HolonFile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HolonFile) ProtoMessage() {}
+
+func (x *HolonFile) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_sophia_who_v1_sophia_who_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HolonFile.ProtoReflect.Descriptor instead.
+func (*HolonFile) Descriptor() ([]byte, []int) {
+	return file_protos_sophia_who_v1_sophia_who_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *HolonFile) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *HolonFile) GetSizeBytes() int64 {
+	if x != nil {
+		return x.SizeBytes
+	}
+	return 0
+}
+
+type ValidateAllRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RootDir       string                 `protobuf:"bytes,1,opt,name=root_dir,json=rootDir,proto3" json:"root_dir,omitempty"` // Directory to scan. Default: current dir.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidateAllRequest) Reset() {
+	*x = ValidateAllRequest{}
+	mi := &file_protos_sophia_who_v1_sophia_who_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateAllRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateAllRequest) ProtoMessage() {}
+
+func (x *ValidateAllRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_sophia_who_v1_sophia_who_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateAllRequest.ProtoReflect.Descriptor instead.
+func (*ValidateAllRequest) Descriptor() ([]byte, []int) {
+	return file_protos_sophia_who_v1_sophia_who_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ValidateAllRequest) GetRootDir() string {
+	if x != nil {
+		return x.RootDir
+	}
+	return ""
+}
+
+// ValidationResult is one message of the ValidateAll stream.
+type ValidationResult struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Result:
+	//
+	//	*ValidationResult_File
+	//	*ValidationResult_Progress
+	Result        isValidationResult_Result `protobuf_oneof:"result"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidationResult) Reset() {
+	*x = ValidationResult{}
+	mi := &file_protos_sophia_who_v1_sophia_who_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidationResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidationResult) ProtoMessage() {}
+
+func (x *ValidationResult) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_sophia_who_v1_sophia_who_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidationResult.ProtoReflect.Descriptor instead.
+func (*ValidationResult) Descriptor() ([]byte, []int) {
+	return file_protos_sophia_who_v1_sophia_who_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ValidationResult) GetResult() isValidationResult_Result {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *ValidationResult) GetFile() *FileValidation {
+	if x != nil {
+		if x, ok := x.Result.(*ValidationResult_File); ok {
+			return x.File
+		}
+	}
+	return nil
+}
+
+func (x *ValidationResult) GetProgress() *ValidationProgress {
+	if x != nil {
+		if x, ok := x.Result.(*ValidationResult_Progress); ok {
+			return x.Progress
+		}
+	}
+	return nil
+}
+
+type isValidationResult_Result interface {
+	isValidationResult_Result()
+}
+
+type ValidationResult_File struct {
+	File *FileValidation `protobuf:"bytes,1,opt,name=file,proto3,oneof"`
+}
+
+type ValidationResult_Progress struct {
+	Progress *ValidationProgress `protobuf:"bytes,2,opt,name=progress,proto3,oneof"`
+}
+
+func (*ValidationResult_File) isValidationResult_Result() {}
+
+func (*ValidationResult_Progress) isValidationResult_Result() {}
+
+// FileValidation is the outcome for one HOLON.md file.
+type FileValidation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Path          string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"` // Relative to the scan root (absolute if outside it).
+	Ok            bool                   `protobuf:"varint,2,opt,name=ok,proto3" json:"ok,omitempty"`
+	Errors        []*ValidationError     `protobuf:"bytes,3,rep,name=errors,proto3" json:"errors,omitempty"` // Empty when ok.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FileValidation) Reset() {
+	*x = FileValidation{}
+	mi := &file_protos_sophia_who_v1_sophia_who_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileValidation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileValidation) ProtoMessage() {}
+
+func (x *FileValidation) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_sophia_who_v1_sophia_who_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileValidation.ProtoReflect.Descriptor instead.
+func (*FileValidation) Descriptor() ([]byte, []int) {
+	return file_protos_sophia_who_v1_sophia_who_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *FileValidation) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *FileValidation) GetOk() bool {
+	if x != nil {
+		return x.Ok
+	}
+	return false
+}
+
+func (x *FileValidation) GetErrors() []*ValidationError {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+// ValidationProgress is sent periodically during the scan and once at
+// the end, with the final counts.
+type ValidationProgress struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ScannedFiles  int32                  `protobuf:"varint,1,opt,name=scanned_files,json=scannedFiles,proto3" json:"scanned_files,omitempty"` // Files visited so far.
+	CheckedFiles  int32                  `protobuf:"varint,2,opt,name=checked_files,json=checkedFiles,proto3" json:"checked_files,omitempty"` // HOLON.md files validated so far.
+	InvalidFiles  int32                  `protobuf:"varint,3,opt,name=invalid_files,json=invalidFiles,proto3" json:"invalid_files,omitempty"` // Of those, files with problems.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidationProgress) Reset() {
+	*x = ValidationProgress{}
+	mi := &file_protos_sophia_who_v1_sophia_who_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidationProgress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidationProgress) ProtoMessage() {}
+
+func (x *ValidationProgress) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_sophia_who_v1_sophia_who_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidationProgress.ProtoReflect.Descriptor instead.
+func (*ValidationProgress) Descriptor() ([]byte, []int) {
+	return file_protos_sophia_who_v1_sophia_who_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ValidationProgress) GetScannedFiles() int32 {
+	if x != nil {
+		return x.ScannedFiles
+	}
+	return 0
+}
+
+func (x *ValidationProgress) GetCheckedFiles() int32 {
+	if x != nil {
+		return x.CheckedFiles
+	}
+	return 0
+}
+
+func (x *ValidationProgress) GetInvalidFiles() int32 {
+	if x != nil {
+		return x.InvalidFiles
+	}
+	return 0
+}
+
+var File_protos_sophia_who_v1_sophia_who_proto protoreflect.FileDescriptor
+
+const file_protos_sophia_who_v1_sophia_who_proto_rawDesc = "" +
+	"\n" +
+	"%protos/sophia_who/v1/sophia_who.proto\x12\rsophia_who.v1\x1a google/protobuf/field_mask.proto\"\xd2\x05\n" +
+	"\rHolonIdentity\x12\x12\n" +
+	"\x04uuid\x18\x01 \x01(\tR\x04uuid\x12\x1d\n" +
+	"\n" +
+	"given_name\x18\x02 \x01(\tR\tgivenName\x12\x1f\n" +
+	"\vfamily_name\x18\x03 \x01(\tR\n" +
+	"familyName\x12\x14\n" +
+	"\x05motto\x18\x04 \x01(\tR\x05motto\x12\x1a\n" +
+	"\bcomposer\x18\x05 \x01(\tR\bcomposer\x12*\n" +
+	"\x05clade\x18\x06 \x01(\x0e2\x14.sophia_who.v1.CladeR\x05clade\x12-\n" +
+	"\x06status\x18\a \x01(\x0e2\x15.sophia_who.v1.StatusR\x06status\x12\x12\n" +
+	"\x04born\x18\b \x01(\tR\x04born\x12\x12\n" +
+	"\x04died\x18\x19 \x01(\tR\x04died\x12\x18\n" +
+	"\aparents\x18\t \x03(\tR\aparents\x12C\n" +
+	"\freproduction\x18\n" +
+	" \x01(\x0e2\x1f.sophia_who.v1.ReproductionModeR\freproduction\x12\x18\n" +
+	"\aaliases\x18\x12 \x03(\tR\aaliases\x12\x12\n" +
+	"\x04tags\x18\x1a \x03(\tR\x04tags\x12!\n" +
+	"\fgenerated_by\x18\x14 \x01(\tR\vgeneratedBy\x12\x12\n" +
+	"\x04lang\x18\x15 \x01(\tR\x04lang\x128\n" +
+	"\fproto_status\x18\x16 \x01(\x0e2\x15.sophia_who.v1.StatusR\vprotoStatus\x12#\n" +
+	"\rcustom_status\x18\x17 \x01(\tR\fcustomStatus\x12.\n" +
+	"\x13custom_proto_status\x18\x18 \x01(\tR\x11customProtoStatusJ\x04\b\v\x10\x12J\x04\b\x13\x10\x14R\vbinary_pathR\x0ebinary_versionR\agit_tagR\n" +
+	"git_commitR\x02osR\x04archR\fdependenciesR\x0fwrapped_license\"\xd5\x03\n" +
+	"\x15CreateIdentityRequest\x12\x1d\n" +
+	"\n" +
+	"given_name\x18\x01 \x01(\tR\tgivenName\x12\x1f\n" +
+	"\vfamily_name\x18\x02 \x01(\tR\n" +
+	"familyName\x12\x14\n" +
 	"\x05motto\x18\x03 \x01(\tR\x05motto\x12\x1a\n" +
 	"\bcomposer\x18\x04 \x01(\tR\bcomposer\x12*\n" +
 	"\x05clade\x18\x05 \x01(\x0e2\x14.sophia_who.v1.CladeR\x05clade\x12C\n" +
 	"\freproduction\x18\x06 \x01(\x0e2\x1f.sophia_who.v1.ReproductionModeR\freproduction\x12\x12\n" +
 	"\x04lang\x18\a \x01(\tR\x04lang\x12\x18\n" +
-	"\aaliases\x18\b \x03(\tR\aaliases\x12'\n" +
-	"\x0fwrapped_license\x18\t \x01(\tR\x0ewrappedLicense\x12\x1d\n" +
+	"\aaliases\x18\b \x03(\tR\aaliases\x12\x1d\n" +
 	"\n" +
 	"output_dir\x18\n" +
-	" \x01(\tR\toutputDir\"o\n" +
+	" \x01(\tR\toutputDir\x12\x18\n" +
+	"\aparents\x18\v \x03(\tR\aparents\x12\x12\n" +
+	"\x04born\x18\f \x01(\tR\x04born\x12\x1b\n" +
+	"\tomit_body\x18\r \x01(\bR\bomitBody\x12\x12\n" +
+	"\x04tags\x18\x0e \x03(\tR\x04tags\x12\x16\n" +
+	"\x06strict\x18\x0f \x01(\bR\x06strictJ\x04\b\t\x10\n" +
+	"R\x0fwrapped_license\"\xac\x01\n" +
 	"\x16CreateIdentityResponse\x128\n" +
 	"\bidentity\x18\x01 \x01(\v2\x1c.sophia_who.v1.HolonIdentityR\bidentity\x12\x1b\n" +
-	"\tfile_path\x18\x02 \x01(\tR\bfilePath\")\n" +
+	"\tfile_path\x18\x02 \x01(\tR\bfilePath\x12\x1a\n" +
+	"\bwarnings\x18\x03 \x03(\tR\bwarnings\x12\x1f\n" +
+	"\vraw_content\x18\x04 \x01(\tR\n" +
+	"rawContent\")\n" +
 	"\x13ShowIdentityRequest\x12\x12\n" +
 	"\x04uuid\x18\x01 \x01(\tR\x04uuid\"\x8e\x01\n" +
 	"\x14ShowIdentityResponse\x128\n" +
 	"\bidentity\x18\x01 \x01(\v2\x1c.sophia_who.v1.HolonIdentityR\bidentity\x12\x1b\n" +
 	"\tfile_path\x18\x02 \x01(\tR\bfilePath\x12\x1f\n" +
 	"\vraw_content\x18\x03 \x01(\tR\n" +
-	"rawContent\"2\n" +
+	"rawContent\"\xa2\x01\n" +
+	"\x15UpdateIdentityRequest\x12\x12\n" +
+	"\x04uuid\x18\x01 \x01(\tR\x04uuid\x128\n" +
+	"\bidentity\x18\x02 \x01(\v2\x1c.sophia_who.v1.HolonIdentityR\bidentity\x12;\n" +
+	"\vupdate_mask\x18\x03 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\"o\n" +
+	"\x16UpdateIdentityResponse\x128\n" +
+	"\bidentity\x18\x01 \x01(\v2\x1c.sophia_who.v1.HolonIdentityR\bidentity\x12\x1b\n" +
+	"\tfile_path\x18\x02 \x01(\tR\bfilePath\"J\n" +
+	"\x13UpdateStatusRequest\x12\x14\n" +
+	"\x05uuids\x18\x01 \x03(\tR\x05uuids\x12\x1d\n" +
+	"\n" +
+	"new_status\x18\x02 \x01(\tR\tnewStatus\"S\n" +
+	"\x14UpdateStatusResponse\x12;\n" +
+	"\aresults\x18\x01 \x03(\v2!.sophia_who.v1.UpdateStatusResultR\aresults\"\x84\x01\n" +
+	"\x12UpdateStatusResult\x12\x12\n" +
+	"\x04uuid\x18\x01 \x01(\tR\x04uuid\x12\x1b\n" +
+	"\tfile_path\x18\x02 \x01(\tR\bfilePath\x12'\n" +
+	"\x0fprevious_status\x18\x03 \x01(\tR\x0epreviousStatus\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\"?\n" +
+	"\x0fReparentRequest\x12\x12\n" +
+	"\x04uuid\x18\x01 \x01(\tR\x04uuid\x12\x18\n" +
+	"\aparents\x18\x02 \x03(\tR\aparents\"\x94\x01\n" +
+	"\x10ReparentResponse\x128\n" +
+	"\bidentity\x18\x01 \x01(\v2\x1c.sophia_who.v1.HolonIdentityR\bidentity\x12\x1b\n" +
+	"\tfile_path\x18\x02 \x01(\tR\bfilePath\x12)\n" +
+	"\x10previous_parents\x18\x03 \x03(\tR\x0fpreviousParents\"k\n" +
 	"\x15ListIdentitiesRequest\x12\x19\n" +
-	"\broot_dir\x18\x01 \x01(\tR\arootDir\"M\n" +
+	"\broot_dir\x18\x01 \x01(\tR\arootDir\x12#\n" +
+	"\rinclude_stats\x18\x02 \x01(\bR\fincludeStats\x12\x12\n" +
+	"\x04tags\x18\x03 \x03(\tR\x04tags\"k\n" +
 	"\x16ListIdentitiesResponse\x123\n" +
-	"\aentries\x18\x01 \x03(\v2\x19.sophia_who.v1.HolonEntryR\aentries\"\x83\x01\n" +
+	"\aentries\x18\x01 \x03(\v2\x19.sophia_who.v1.HolonEntryR\aentries\x12\x1c\n" +
+	"\ttruncated\x18\x02 \x01(\bR\ttruncated\"\xcf\x01\n" +
 	"\n" +
 	"HolonEntry\x128\n" +
 	"\bidentity\x18\x01 \x01(\v2\x1c.sophia_who.v1.HolonIdentityR\bidentity\x12\x16\n" +
 	"\x06origin\x18\x02 \x01(\tR\x06origin\x12#\n" +
-	"\rrelative_path\x18\x03 \x01(\tR\frelativePath\"\xcb\x01\n" +
-	"\x11PinVersionRequest\x12\x12\n" +
-	"\x04uuid\x18\x01 \x01(\tR\x04uuid\x12\x1f\n" +
-	"\vbinary_path\x18\x02 \x01(\tR\n" +
-	"binaryPath\x12%\n" +
-	"\x0ebinary_version\x18\x03 \x01(\tR\rbinaryVersion\x12\x17\n" +
-	"\agit_tag\x18\x04 \x01(\tR\x06gitTag\x12\x1d\n" +
+	"\rrelative_path\x18\x03 \x01(\tR\frelativePath\x12$\n" +
+	"\x0edir_file_count\x18\x04 \x01(\x03R\fdirFileCount\x12$\n" +
+	"\x0edir_size_bytes\x18\x05 \x01(\x03R\fdirSizeBytes\"a\n" +
+	"\x16CountIdentitiesRequest\x12\x19\n" +
+	"\broot_dir\x18\x01 \x01(\tR\arootDir\x12\x14\n" +
+	"\x05clade\x18\x02 \x01(\tR\x05clade\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\"\xcb\x02\n" +
+	"\x17CountIdentitiesResponse\x12\x14\n" +
+	"\x05total\x18\x01 \x01(\x05R\x05total\x12N\n" +
+	"\bby_clade\x18\x02 \x03(\v23.sophia_who.v1.CountIdentitiesResponse.ByCladeEntryR\abyClade\x12Q\n" +
+	"\tby_status\x18\x03 \x03(\v24.sophia_who.v1.CountIdentitiesResponse.ByStatusEntryR\bbyStatus\x1a:\n" +
+	"\fByCladeEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\x1a;\n" +
+	"\rByStatusEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\"9\n" +
+	"\x16ValidateContentRequest\x12\x1f\n" +
+	"\vraw_content\x18\x01 \x01(\tR\n" +
+	"rawContent\"g\n" +
+	"\x17ValidateContentResponse\x12\x14\n" +
+	"\x05valid\x18\x01 \x01(\bR\x05valid\x126\n" +
+	"\x06errors\x18\x02 \x03(\v2\x1e.sophia_who.v1.ValidationErrorR\x06errors\"U\n" +
+	"\x0fValidationError\x12\x14\n" +
+	"\x05field\x18\x01 \x01(\tR\x05field\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x12\n" +
+	"\x04line\x18\x03 \x01(\x05R\x04line\"s\n" +
+	"\x15ListHolonFilesRequest\x12\x12\n" +
+	"\x04uuid\x18\x01 \x01(\tR\x04uuid\x12\x1c\n" +
+	"\trecursive\x18\x02 \x01(\bR\trecursive\x12(\n" +
+	"\x10exclude_holon_md\x18\x03 \x01(\bR\x0eexcludeHolonMd\"f\n" +
+	"\x16ListHolonFilesResponse\x12\x1c\n" +
+	"\tdirectory\x18\x01 \x01(\tR\tdirectory\x12.\n" +
+	"\x05files\x18\x02 \x03(\v2\x18.sophia_who.v1.HolonFileR\x05files\">\n" +
+	"\tHolonFile\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1d\n" +
 	"\n" +
-	"git_commit\x18\x05 \x01(\tR\tgitCommit\x12\x0e\n" +
-	"\x02os\x18\x06 \x01(\tR\x02os\x12\x12\n" +
-	"\x04arch\x18\a \x01(\tR\x04arch\"N\n" +
-	"\x12PinVersionResponse\x128\n" +
-	"\bidentity\x18\x01 \x01(\v2\x1c.sophia_who.v1.HolonIdentityR\bidentity*\xc6\x01\n" +
+	"size_bytes\x18\x02 \x01(\x03R\tsizeBytes\"/\n" +
+	"\x12ValidateAllRequest\x12\x19\n" +
+	"\broot_dir\x18\x01 \x01(\tR\arootDir\"\x92\x01\n" +
+	"\x10ValidationResult\x123\n" +
+	"\x04file\x18\x01 \x01(\v2\x1d.sophia_who.v1.FileValidationH\x00R\x04file\x12?\n" +
+	"\bprogress\x18\x02 \x01(\v2!.sophia_who.v1.ValidationProgressH\x00R\bprogressB\b\n" +
+	"\x06result\"l\n" +
+	"\x0eFileValidation\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x12\x0e\n" +
+	"\x02ok\x18\x02 \x01(\bR\x02ok\x126\n" +
+	"\x06errors\x18\x03 \x03(\v2\x1e.sophia_who.v1.ValidationErrorR\x06errors\"\x83\x01\n" +
+	"\x12ValidationProgress\x12#\n" +
+	"\rscanned_files\x18\x01 \x01(\x05R\fscannedFiles\x12#\n" +
+	"\rchecked_files\x18\x02 \x01(\x05R\fcheckedFiles\x12#\n" +
+	"\rinvalid_files\x18\x03 \x01(\x05R\finvalidFiles*\xc6\x01\n" +
 	"\x05Clade\x12\x15\n" +
 	"\x11CLADE_UNSPECIFIED\x10\x00\x12\x16\n" +
 	"\x12DETERMINISTIC_PURE\x10\x01\x12\x1a\n" +
@@ -1076,7 +2157,7 @@ const file_protos_sophia_who_v1_sophia_who_proto_rawDesc = "" +
 	"\bASSISTED\x10\x02\x12\r\n" +
 	"\tAUTOMATIC\x10\x03\x12\x0f\n" +
 	"\vAUTOPOIETIC\x10\x04\x12\b\n" +
-	"\x04BRED\x10\x05*Q\n" +
+	"\x04BRED\x10\x05*d\n" +
 	"\x06Status\x12\x16\n" +
 	"\x12STATUS_UNSPECIFIED\x10\x00\x12\t\n" +
 	"\x05DRAFT\x10\x01\x12\n" +
@@ -1084,13 +2165,19 @@ const file_protos_sophia_who_v1_sophia_who_proto_rawDesc = "" +
 	"\x06STABLE\x10\x02\x12\x0e\n" +
 	"\n" +
 	"DEPRECATED\x10\x03\x12\b\n" +
-	"\x04DEAD\x10\x042\xfc\x02\n" +
+	"\x04DEAD\x10\x04\x12\x11\n" +
+	"\rSTATUS_CUSTOM\x10\x052\xa6\a\n" +
 	"\x10SophiaWhoService\x12]\n" +
 	"\x0eCreateIdentity\x12$.sophia_who.v1.CreateIdentityRequest\x1a%.sophia_who.v1.CreateIdentityResponse\x12W\n" +
 	"\fShowIdentity\x12\".sophia_who.v1.ShowIdentityRequest\x1a#.sophia_who.v1.ShowIdentityResponse\x12]\n" +
-	"\x0eListIdentities\x12$.sophia_who.v1.ListIdentitiesRequest\x1a%.sophia_who.v1.ListIdentitiesResponse\x12Q\n" +
-	"\n" +
-	"PinVersion\x12 .sophia_who.v1.PinVersionRequest\x1a!.sophia_who.v1.PinVersionResponseBLZJgithub.com/organic-programming/sophia-who/gen/go/sophia_who/v1;sophiawhov1b\x06proto3"
+	"\x0eUpdateIdentity\x12$.sophia_who.v1.UpdateIdentityRequest\x1a%.sophia_who.v1.UpdateIdentityResponse\x12W\n" +
+	"\fUpdateStatus\x12\".sophia_who.v1.UpdateStatusRequest\x1a#.sophia_who.v1.UpdateStatusResponse\x12K\n" +
+	"\bReparent\x12\x1e.sophia_who.v1.ReparentRequest\x1a\x1f.sophia_who.v1.ReparentResponse\x12]\n" +
+	"\x0eListIdentities\x12$.sophia_who.v1.ListIdentitiesRequest\x1a%.sophia_who.v1.ListIdentitiesResponse\x12`\n" +
+	"\x0fCountIdentities\x12%.sophia_who.v1.CountIdentitiesRequest\x1a&.sophia_who.v1.CountIdentitiesResponse\x12`\n" +
+	"\x0fValidateContent\x12%.sophia_who.v1.ValidateContentRequest\x1a&.sophia_who.v1.ValidateContentResponse\x12]\n" +
+	"\x0eListHolonFiles\x12$.sophia_who.v1.ListHolonFilesRequest\x1a%.sophia_who.v1.ListHolonFilesResponse\x12S\n" +
+	"\vValidateAll\x12!.sophia_who.v1.ValidateAllRequest\x1a\x1f.sophia_who.v1.ValidationResult0\x01BLZJgithub.com/organic-programming/sophia-who/gen/go/sophia_who/v1;sophiawhov1b\x06proto3"
 
 var (
 	file_protos_sophia_who_v1_sophia_who_proto_rawDescOnce sync.Once
@@ -1105,21 +2192,41 @@ func file_protos_sophia_who_v1_sophia_who_proto_rawDescGZIP() []byte {
 }
 
 var file_protos_sophia_who_v1_sophia_who_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
-var file_protos_sophia_who_v1_sophia_who_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_protos_sophia_who_v1_sophia_who_proto_msgTypes = make([]protoimpl.MessageInfo, 29)
 var file_protos_sophia_who_v1_sophia_who_proto_goTypes = []any{
-	(Clade)(0),                     // 0: sophia_who.v1.Clade
-	(ReproductionMode)(0),          // 1: sophia_who.v1.ReproductionMode
-	(Status)(0),                    // 2: sophia_who.v1.Status
-	(*HolonIdentity)(nil),          // 3: sophia_who.v1.HolonIdentity
-	(*CreateIdentityRequest)(nil),  // 4: sophia_who.v1.CreateIdentityRequest
-	(*CreateIdentityResponse)(nil), // 5: sophia_who.v1.CreateIdentityResponse
-	(*ShowIdentityRequest)(nil),    // 6: sophia_who.v1.ShowIdentityRequest
-	(*ShowIdentityResponse)(nil),   // 7: sophia_who.v1.ShowIdentityResponse
-	(*ListIdentitiesRequest)(nil),  // 8: sophia_who.v1.ListIdentitiesRequest
-	(*ListIdentitiesResponse)(nil), // 9: sophia_who.v1.ListIdentitiesResponse
-	(*HolonEntry)(nil),             // 10: sophia_who.v1.HolonEntry
-	(*PinVersionRequest)(nil),      // 11: sophia_who.v1.PinVersionRequest
-	(*PinVersionResponse)(nil),     // 12: sophia_who.v1.PinVersionResponse
+	(Clade)(0),                      // 0: sophia_who.v1.Clade
+	(ReproductionMode)(0),           // 1: sophia_who.v1.ReproductionMode
+	(Status)(0),                     // 2: sophia_who.v1.Status
+	(*HolonIdentity)(nil),           // 3: sophia_who.v1.HolonIdentity
+	(*CreateIdentityRequest)(nil),   // 4: sophia_who.v1.CreateIdentityRequest
+	(*CreateIdentityResponse)(nil),  // 5: sophia_who.v1.CreateIdentityResponse
+	(*ShowIdentityRequest)(nil),     // 6: sophia_who.v1.ShowIdentityRequest
+	(*ShowIdentityResponse)(nil),    // 7: sophia_who.v1.ShowIdentityResponse
+	(*UpdateIdentityRequest)(nil),   // 8: sophia_who.v1.UpdateIdentityRequest
+	(*UpdateIdentityResponse)(nil),  // 9: sophia_who.v1.UpdateIdentityResponse
+	(*UpdateStatusRequest)(nil),     // 10: sophia_who.v1.UpdateStatusRequest
+	(*UpdateStatusResponse)(nil),    // 11: sophia_who.v1.UpdateStatusResponse
+	(*UpdateStatusResult)(nil),      // 12: sophia_who.v1.UpdateStatusResult
+	(*ReparentRequest)(nil),         // 13: sophia_who.v1.ReparentRequest
+	(*ReparentResponse)(nil),        // 14: sophia_who.v1.ReparentResponse
+	(*ListIdentitiesRequest)(nil),   // 15: sophia_who.v1.ListIdentitiesRequest
+	(*ListIdentitiesResponse)(nil),  // 16: sophia_who.v1.ListIdentitiesResponse
+	(*HolonEntry)(nil),              // 17: sophia_who.v1.HolonEntry
+	(*CountIdentitiesRequest)(nil),  // 18: sophia_who.v1.CountIdentitiesRequest
+	(*CountIdentitiesResponse)(nil), // 19: sophia_who.v1.CountIdentitiesResponse
+	(*ValidateContentRequest)(nil),  // 20: sophia_who.v1.ValidateContentRequest
+	(*ValidateContentResponse)(nil), // 21: sophia_who.v1.ValidateContentResponse
+	(*ValidationError)(nil),         // 22: sophia_who.v1.ValidationError
+	(*ListHolonFilesRequest)(nil),   // 23: sophia_who.v1.ListHolonFilesRequest
+	(*ListHolonFilesResponse)(nil),  // 24: sophia_who.v1.ListHolonFilesResponse
+	(*HolonFile)(nil),               // 25: sophia_who.v1.HolonFile
+	(*ValidateAllRequest)(nil),      // 26: sophia_who.v1.ValidateAllRequest
+	(*ValidationResult)(nil),        // 27: sophia_who.v1.ValidationResult
+	(*FileValidation)(nil),          // 28: sophia_who.v1.FileValidation
+	(*ValidationProgress)(nil),      // 29: sophia_who.v1.ValidationProgress
+	nil,                             // 30: sophia_who.v1.CountIdentitiesResponse.ByCladeEntry
+	nil,                             // 31: sophia_who.v1.CountIdentitiesResponse.ByStatusEntry
+	(*fieldmaskpb.FieldMask)(nil),   // 32: google.protobuf.FieldMask
 }
 var file_protos_sophia_who_v1_sophia_who_proto_depIdxs = []int32{
 	0,  // 0: sophia_who.v1.HolonIdentity.clade:type_name -> sophia_who.v1.Clade
@@ -1130,22 +2237,45 @@ var file_protos_sophia_who_v1_sophia_who_proto_depIdxs = []int32{
 	1,  // 5: sophia_who.v1.CreateIdentityRequest.reproduction:type_name -> sophia_who.v1.ReproductionMode
 	3,  // 6: sophia_who.v1.CreateIdentityResponse.identity:type_name -> sophia_who.v1.HolonIdentity
 	3,  // 7: sophia_who.v1.ShowIdentityResponse.identity:type_name -> sophia_who.v1.HolonIdentity
-	10, // 8: sophia_who.v1.ListIdentitiesResponse.entries:type_name -> sophia_who.v1.HolonEntry
-	3,  // 9: sophia_who.v1.HolonEntry.identity:type_name -> sophia_who.v1.HolonIdentity
-	3,  // 10: sophia_who.v1.PinVersionResponse.identity:type_name -> sophia_who.v1.HolonIdentity
-	4,  // 11: sophia_who.v1.SophiaWhoService.CreateIdentity:input_type -> sophia_who.v1.CreateIdentityRequest
-	6,  // 12: sophia_who.v1.SophiaWhoService.ShowIdentity:input_type -> sophia_who.v1.ShowIdentityRequest
-	8,  // 13: sophia_who.v1.SophiaWhoService.ListIdentities:input_type -> sophia_who.v1.ListIdentitiesRequest
-	11, // 14: sophia_who.v1.SophiaWhoService.PinVersion:input_type -> sophia_who.v1.PinVersionRequest
-	5,  // 15: sophia_who.v1.SophiaWhoService.CreateIdentity:output_type -> sophia_who.v1.CreateIdentityResponse
-	7,  // 16: sophia_who.v1.SophiaWhoService.ShowIdentity:output_type -> sophia_who.v1.ShowIdentityResponse
-	9,  // 17: sophia_who.v1.SophiaWhoService.ListIdentities:output_type -> sophia_who.v1.ListIdentitiesResponse
-	12, // 18: sophia_who.v1.SophiaWhoService.PinVersion:output_type -> sophia_who.v1.PinVersionResponse
-	15, // [15:19] is the sub-list for method output_type
-	11, // [11:15] is the sub-list for method input_type
-	11, // [11:11] is the sub-list for extension type_name
-	11, // [11:11] is the sub-list for extension extendee
-	0,  // [0:11] is the sub-list for field type_name
+	3,  // 8: sophia_who.v1.UpdateIdentityRequest.identity:type_name -> sophia_who.v1.HolonIdentity
+	32, // 9: sophia_who.v1.UpdateIdentityRequest.update_mask:type_name -> google.protobuf.FieldMask
+	3,  // 10: sophia_who.v1.UpdateIdentityResponse.identity:type_name -> sophia_who.v1.HolonIdentity
+	12, // 11: sophia_who.v1.UpdateStatusResponse.results:type_name -> sophia_who.v1.UpdateStatusResult
+	3,  // 12: sophia_who.v1.ReparentResponse.identity:type_name -> sophia_who.v1.HolonIdentity
+	17, // 13: sophia_who.v1.ListIdentitiesResponse.entries:type_name -> sophia_who.v1.HolonEntry
+	3,  // 14: sophia_who.v1.HolonEntry.identity:type_name -> sophia_who.v1.HolonIdentity
+	30, // 15: sophia_who.v1.CountIdentitiesResponse.by_clade:type_name -> sophia_who.v1.CountIdentitiesResponse.ByCladeEntry
+	31, // 16: sophia_who.v1.CountIdentitiesResponse.by_status:type_name -> sophia_who.v1.CountIdentitiesResponse.ByStatusEntry
+	22, // 17: sophia_who.v1.ValidateContentResponse.errors:type_name -> sophia_who.v1.ValidationError
+	25, // 18: sophia_who.v1.ListHolonFilesResponse.files:type_name -> sophia_who.v1.HolonFile
+	28, // 19: sophia_who.v1.ValidationResult.file:type_name -> sophia_who.v1.FileValidation
+	29, // 20: sophia_who.v1.ValidationResult.progress:type_name -> sophia_who.v1.ValidationProgress
+	22, // 21: sophia_who.v1.FileValidation.errors:type_name -> sophia_who.v1.ValidationError
+	4,  // 22: sophia_who.v1.SophiaWhoService.CreateIdentity:input_type -> sophia_who.v1.CreateIdentityRequest
+	6,  // 23: sophia_who.v1.SophiaWhoService.ShowIdentity:input_type -> sophia_who.v1.ShowIdentityRequest
+	8,  // 24: sophia_who.v1.SophiaWhoService.UpdateIdentity:input_type -> sophia_who.v1.UpdateIdentityRequest
+	10, // 25: sophia_who.v1.SophiaWhoService.UpdateStatus:input_type -> sophia_who.v1.UpdateStatusRequest
+	13, // 26: sophia_who.v1.SophiaWhoService.Reparent:input_type -> sophia_who.v1.ReparentRequest
+	15, // 27: sophia_who.v1.SophiaWhoService.ListIdentities:input_type -> sophia_who.v1.ListIdentitiesRequest
+	18, // 28: sophia_who.v1.SophiaWhoService.CountIdentities:input_type -> sophia_who.v1.CountIdentitiesRequest
+	20, // 29: sophia_who.v1.SophiaWhoService.ValidateContent:input_type -> sophia_who.v1.ValidateContentRequest
+	23, // 30: sophia_who.v1.SophiaWhoService.ListHolonFiles:input_type -> sophia_who.v1.ListHolonFilesRequest
+	26, // 31: sophia_who.v1.SophiaWhoService.ValidateAll:input_type -> sophia_who.v1.ValidateAllRequest
+	5,  // 32: sophia_who.v1.SophiaWhoService.CreateIdentity:output_type -> sophia_who.v1.CreateIdentityResponse
+	7,  // 33: sophia_who.v1.SophiaWhoService.ShowIdentity:output_type -> sophia_who.v1.ShowIdentityResponse
+	9,  // 34: sophia_who.v1.SophiaWhoService.UpdateIdentity:output_type -> sophia_who.v1.UpdateIdentityResponse
+	11, // 35: sophia_who.v1.SophiaWhoService.UpdateStatus:output_type -> sophia_who.v1.UpdateStatusResponse
+	14, // 36: sophia_who.v1.SophiaWhoService.Reparent:output_type -> sophia_who.v1.ReparentResponse
+	16, // 37: sophia_who.v1.SophiaWhoService.ListIdentities:output_type -> sophia_who.v1.ListIdentitiesResponse
+	19, // 38: sophia_who.v1.SophiaWhoService.CountIdentities:output_type -> sophia_who.v1.CountIdentitiesResponse
+	21, // 39: sophia_who.v1.SophiaWhoService.ValidateContent:output_type -> sophia_who.v1.ValidateContentResponse
+	24, // 40: sophia_who.v1.SophiaWhoService.ListHolonFiles:output_type -> sophia_who.v1.ListHolonFilesResponse
+	27, // 41: sophia_who.v1.SophiaWhoService.ValidateAll:output_type -> sophia_who.v1.ValidationResult
+	32, // [32:42] is the sub-list for method output_type
+	22, // [22:32] is the sub-list for method input_type
+	22, // [22:22] is the sub-list for extension type_name
+	22, // [22:22] is the sub-list for extension extendee
+	0,  // [0:22] is the sub-list for field type_name
 }
 
 func init() { file_protos_sophia_who_v1_sophia_who_proto_init() }
@@ -1153,13 +2283,17 @@ func file_protos_sophia_who_v1_sophia_who_proto_init() {
 	if File_protos_sophia_who_v1_sophia_who_proto != nil {
 		return
 	}
+	file_protos_sophia_who_v1_sophia_who_proto_msgTypes[24].OneofWrappers = []any{
+		(*ValidationResult_File)(nil),
+		(*ValidationResult_Progress)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_protos_sophia_who_v1_sophia_who_proto_rawDesc), len(file_protos_sophia_who_v1_sophia_who_proto_rawDesc)),
 			NumEnums:      3,
-			NumMessages:   10,
+			NumMessages:   29,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -0,0 +1,66 @@
+// Package bulk runs the items of bulk operations, such as moving many
+// holons to a new status, in parallel but with bounded concurrency, so
+// that a large batch cannot flood the disk with simultaneous rewrites.
+package bulk
+
+import (
+	"runtime"
+	"sync"
+)
+
+// DefaultConcurrency is how many items Run processes at once when no
+// limit is given: one per usable CPU.
+func DefaultConcurrency() int {
+	return runtime.GOMAXPROCS(0)
+}
+
+// Run calls fn(i) for each i in [0, n), at most limit calls at a time
+// (limit <= 0: DefaultConcurrency()), and returns once all have
+// returned. Calls may run in any order; fn should record its result by
+// index to keep the output ordered.
+func Run(n, limit int, fn func(i int)) {
+	if limit <= 0 {
+		limit = DefaultConcurrency()
+	}
+	sem := make(chan struct{}, limit)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			fn(i)
+		}()
+	}
+	wg.Wait()
+}
+
+// RunUnique is Run over the distinct non-empty keys: fn(i) is called
+// only for the first index i of each key, so that items naming the same
+// target, such as one holon given by UUID and by alias, never run
+// concurrently against it. It returns, for every index, the index its
+// key was processed at, or -1 for an empty key, for copying results to
+// the duplicates.
+func RunUnique(keys []string, limit int, fn func(i int)) []int {
+	first := make([]int, len(keys))
+	seen := make(map[string]int, len(keys))
+	var unique []int
+	for i, key := range keys {
+		if key == "" {
+			first[i] = -1
+			continue
+		}
+		j, ok := seen[key]
+		if !ok {
+			j = i
+			seen[key] = i
+			unique = append(unique, i)
+		}
+		first[i] = j
+	}
+	Run(len(unique), limit, func(j int) { fn(unique[j]) })
+	return first
+}
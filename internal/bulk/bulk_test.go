@@ -0,0 +1,71 @@
+package bulk
+
+import (
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRunBoundsConcurrency(t *testing.T) {
+	const n, limit = 40, 3
+	var mu sync.Mutex
+	inFlight, peak := 0, 0
+	seen := make([]int, n)
+
+	Run(n, limit, func(i int) {
+		mu.Lock()
+		inFlight++
+		peak = max(peak, inFlight)
+		seen[i]++
+		mu.Unlock()
+		time.Sleep(time.Millisecond)
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+	})
+
+	for i, count := range seen {
+		if count != 1 {
+			t.Errorf("item %d ran %d times, want once", i, count)
+		}
+	}
+	if peak > limit || peak < 2 {
+		t.Errorf("peak concurrency = %d, want between 2 and %d", peak, limit)
+	}
+}
+
+func TestRunDefaultConcurrency(t *testing.T) {
+	ran := 0
+	var mu sync.Mutex
+	Run(5, 0, func(int) {
+		mu.Lock()
+		ran++
+		mu.Unlock()
+	})
+	if ran != 5 {
+		t.Errorf("ran %d items, want 5", ran)
+	}
+	if DefaultConcurrency() < 1 {
+		t.Errorf("DefaultConcurrency() = %d", DefaultConcurrency())
+	}
+}
+
+func TestRunUnique(t *testing.T) {
+	keys := []string{"a", "b", "a", "", "b", "c"}
+	var mu sync.Mutex
+	var ran []int
+	first := RunUnique(keys, 2, func(i int) {
+		mu.Lock()
+		ran = append(ran, i)
+		mu.Unlock()
+	})
+
+	slices.Sort(ran)
+	if want := []int{0, 1, 5}; !slices.Equal(ran, want) {
+		t.Errorf("ran %v, want the first index of each key %v", ran, want)
+	}
+	if want := []int{0, 1, 0, -1, 1, 5}; !slices.Equal(first, want) {
+		t.Errorf("first = %v, want %v", first, want)
+	}
+}
@@ -0,0 +1,50 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/organic-programming/sophia-who/pkg/identity"
+)
+
+// RunAudit prints the audit log under root, oldest entry first.
+func RunAudit(root string) error {
+	entries, err := identity.ReadAudit(root)
+	if err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		fmt.Println("No audit entries.")
+		return nil
+	}
+
+	for _, e := range entries {
+		fmt.Printf("%s %-7s %s", e.Time.Format("2006-01-02T15:04:05Z07:00"), e.Op, e.UUID)
+		if e.Composer != "" {
+			fmt.Printf(" composer=%q", e.Composer)
+		}
+		if e.Caller != "" {
+			fmt.Printf(" caller=%q", e.Caller)
+		}
+		if e.Path != "" {
+			fmt.Printf(" %s", e.Path)
+		}
+		fmt.Println()
+	}
+	return nil
+}
+
+// audit records a mutation made from the CLI. Logging is best-effort:
+// a failure is reported as a warning and never fails the command.
+func audit(root, op string, id identity.Identity, path string) {
+	err := identity.AppendAudit(root, identity.AuditEntry{
+		Op:       op,
+		UUID:     id.UUID,
+		Composer: id.Composer,
+		Caller:   os.Getenv("USER"),
+		Path:     path,
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "warning: %v\n", err)
+	}
+}
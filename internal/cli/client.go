@@ -0,0 +1,226 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	pb "github.com/organic-programming/sophia-who/gen/go/sophia_who/v1"
+	"github.com/organic-programming/sophia-who/pkg/identity"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"nhooyr.io/websocket"
+)
+
+// DefaultServer is the server the client subcommands dial when neither
+// --server nor $SOPHIA_WHO_SERVER is set: who serve's default address.
+const DefaultServer = "tcp://localhost:9090"
+
+// ClientOptions configures the who client subcommands, which issue
+// RPCs to a running who serve instead of reading the local tree.
+type ClientOptions struct {
+	// Server is the transport URI of the server: tcp://host:port,
+	// unix://path, or ws://host:port. Empty uses $SOPHIA_WHO_SERVER,
+	// then DefaultServer.
+	Server string
+
+	// Token, when set, is sent with every RPC as "authorization: Bearer
+	// <token>" metadata, for servers behind an auth interceptor.
+	Token string
+
+	// Context lists extra key=value pairs sent as RPC metadata.
+	Context []string
+
+	// Timeout bounds each RPC, dialing included. Zero: 30 seconds.
+	Timeout time.Duration
+}
+
+// defaultClientTimeout is the RPC deadline when ClientOptions.Timeout
+// is zero.
+const defaultClientTimeout = 30 * time.Second
+
+// dial connects to the server and returns the client, a context
+// carrying the metadata and deadline of the RPC, and a func releasing
+// both.
+func (o ClientOptions) dial() (pb.SophiaWhoServiceClient, context.Context, func(), error) {
+	server := o.Server
+	if server == "" {
+		server = os.Getenv("SOPHIA_WHO_SERVER")
+	}
+	if server == "" {
+		server = DefaultServer
+	}
+	target, dialOpts, err := grpcDialTarget(server)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	md := metadata.MD{}
+	if o.Token != "" {
+		md.Set("authorization", "Bearer "+o.Token)
+	}
+	for _, kv := range o.Context {
+		key, value, ok := strings.Cut(kv, "=")
+		key = strings.ToLower(strings.TrimSpace(key))
+		if !ok || key == "" {
+			return nil, nil, nil, fmt.Errorf("--context %q: want key=value", kv)
+		}
+		md.Append(key, value)
+	}
+
+	dialOpts = append(dialOpts, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	conn, err := grpc.NewClient(target, dialOpts...)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("dial %s: %w", server, err)
+	}
+	timeout := o.Timeout
+	if timeout == 0 {
+		timeout = defaultClientTimeout
+	}
+	ctx, cancel := context.WithTimeout(metadata.NewOutgoingContext(context.Background(), md), timeout)
+	return pb.NewSophiaWhoServiceClient(conn), ctx, func() {
+		cancel()
+		conn.Close()
+	}, nil
+}
+
+// grpcDialTarget converts a who serve transport URI into a gRPC dial
+// target, with the dial options it needs. ws:// connections carry gRPC
+// in binary WebSocket messages under the "grpc" subprotocol, as who
+// serve --listen ws:// expects.
+func grpcDialTarget(uri string) (string, []grpc.DialOption, error) {
+	switch {
+	case strings.HasPrefix(uri, "tcp://"):
+		return "passthrough:///" + strings.TrimPrefix(uri, "tcp://"), nil, nil
+	case strings.HasPrefix(uri, "unix://"):
+		return "unix:" + strings.TrimPrefix(uri, "unix://"), nil, nil
+	case strings.HasPrefix(uri, "ws://"):
+		dialer := func(ctx context.Context, _ string) (net.Conn, error) {
+			c, _, err := websocket.Dial(ctx, uri, &websocket.DialOptions{Subprotocols: []string{"grpc"}})
+			if err != nil {
+				return nil, err
+			}
+			// The connection outlives the dial: it must not be tied to ctx.
+			return websocket.NetConn(context.Background(), c, websocket.MessageBinary), nil
+		}
+		return "passthrough:///ws", []grpc.DialOption{grpc.WithContextDialer(dialer)}, nil
+	default:
+		return "", nil, fmt.Errorf("unsupported server URI %q (want tcp://host:port, unix://path, or ws://host:port)", uri)
+	}
+}
+
+// RunClientList lists the holons a server finds under rootDir (empty:
+// the server's root), like who list, through ListIdentities.
+func RunClientList(opts ClientOptions, rootDir string) error {
+	client, ctx, done, err := opts.dial()
+	if err != nil {
+		return err
+	}
+	defer done()
+
+	resp, err := client.ListIdentities(ctx, &pb.ListIdentitiesRequest{RootDir: rootDir})
+	if err != nil {
+		return fmt.Errorf("ListIdentities: %w", err)
+	}
+	if len(resp.GetEntries()) == 0 {
+		fmt.Println("No holons found.")
+		return nil
+	}
+
+	table := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "UUID\tNAME\tCLADE\tSTATUS\tPATH")
+	for _, e := range resp.GetEntries() {
+		id := e.GetIdentity()
+		name := strings.TrimSpace(id.GetGivenName() + " " + id.GetFamilyName())
+		status := identity.Status(id.GetStatus()).String()
+		if id.GetCustomStatus() != "" {
+			status = id.GetCustomStatus()
+		}
+		fmt.Fprintf(table, "%s\t%s\t%s\t%s\t%s\n", displayUUID(id.GetUuid(), false), name,
+			identity.Clade(id.GetClade()), status, e.GetRelativePath())
+	}
+	table.Flush()
+	if resp.GetTruncated() {
+		fmt.Fprintln(os.Stderr, "... truncated by the server's result cap")
+	}
+	return nil
+}
+
+// ClientNewOptions holds the identity fields of who client new. Unlike
+// who new, nothing is prompted for: fields left empty take the server's
+// defaults, and the server rejects the holon if a required one is
+// still missing.
+type ClientNewOptions struct {
+	GivenName  string
+	FamilyName string
+	Motto      string
+	Composer   string
+
+	// Clade and Reproduction are HOLON.md values such as
+	// "deterministic/pure" and "manual".
+	Clade        string
+	Reproduction string
+
+	Lang      string
+	Aliases   []string
+	Parents   []string
+	Tags      []string
+	Born      string
+	OutputDir string // relative to the server's root
+}
+
+// RunClientNew creates a holon on the server through CreateIdentity and
+// prints its UUID and the path the server wrote, with any warnings on
+// stderr.
+func RunClientNew(opts ClientOptions, fields ClientNewOptions) error {
+	req := &pb.CreateIdentityRequest{
+		GivenName:  fields.GivenName,
+		FamilyName: fields.FamilyName,
+		Motto:      fields.Motto,
+		Composer:   fields.Composer,
+		Lang:       fields.Lang,
+		Aliases:    fields.Aliases,
+		Parents:    fields.Parents,
+		Tags:       fields.Tags,
+		Born:       fields.Born,
+		OutputDir:  fields.OutputDir,
+	}
+	if fields.Clade != "" {
+		clade, err := identity.ParseClade(fields.Clade)
+		if err != nil {
+			return err
+		}
+		req.Clade = pb.Clade(clade)
+	}
+	if fields.Reproduction != "" {
+		reproduction, err := identity.ParseReproduction(fields.Reproduction)
+		if err != nil {
+			return err
+		}
+		req.Reproduction = pb.ReproductionMode(reproduction)
+	}
+
+	client, ctx, done, err := opts.dial()
+	if err != nil {
+		return err
+	}
+	defer done()
+
+	resp, err := client.CreateIdentity(ctx, req)
+	if err != nil {
+		return fmt.Errorf("CreateIdentity: %w", err)
+	}
+	for _, w := range resp.GetWarnings() {
+		fmt.Fprintf(os.Stderr, "warning: %s\n", w)
+	}
+	id := resp.GetIdentity()
+	fmt.Printf("✓ Born: %s %s\n", id.GetGivenName(), id.GetFamilyName())
+	fmt.Printf("  UUID: %s\n", id.GetUuid())
+	fmt.Printf("  File: %s\n", resp.GetFilePath())
+	return nil
+}
@@ -0,0 +1,171 @@
+package cli
+
+import (
+	"context"
+	"net"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/organic-programming/go-holons/pkg/transport"
+	pb "github.com/organic-programming/sophia-who/gen/go/sophia_who/v1"
+	"github.com/organic-programming/sophia-who/internal/server"
+	"github.com/organic-programming/sophia-who/pkg/identity"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// startClientTestServer serves root on a loopback port and returns its
+// URI and a func returning the metadata of the last RPC.
+func startClientTestServer(t *testing.T, root string) (string, func() metadata.MD) {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var mu sync.Mutex
+	var last metadata.MD
+	s := grpc.NewServer(grpc.UnaryInterceptor(func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		mu.Lock()
+		last = md
+		mu.Unlock()
+		return handler(ctx, req)
+	}))
+	pb.RegisterSophiaWhoServiceServer(s, &server.Server{Root: root})
+	go s.Serve(lis) //nolint:errcheck
+	t.Cleanup(s.Stop)
+
+	return "tcp://" + lis.Addr().String(), func() metadata.MD {
+		mu.Lock()
+		defer mu.Unlock()
+		return last
+	}
+}
+
+func TestRunClientList(t *testing.T) {
+	root := t.TempDir()
+	swift := renameFixture()
+	deep := renameFixture()
+	deep.UUID = "c3d4e5f6-0000-4000-8000-000000000005"
+	deep.GivenName = "Deep"
+	seedIdentity(t, root, swift)
+	seedIdentity(t, root, deep)
+
+	uri, lastMD := startClientTestServer(t, root)
+	opts := ClientOptions{Server: uri, Token: "s3cret", Context: []string{"X-Team=probes"}}
+	out := captureStdout(t, func() {
+		if err := RunClientList(opts, ""); err != nil {
+			t.Fatalf("RunClientList failed: %v", err)
+		}
+	})
+
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != 3 || !strings.HasPrefix(lines[0], "UUID") {
+		t.Fatalf("output:\n%s\nwant a header and two holons", out)
+	}
+	for _, id := range []string{swift.UUID, deep.UUID} {
+		if !strings.Contains(out, id[:8]+" ") {
+			t.Errorf("output does not list %s:\n%s", id, out)
+		}
+	}
+	if !strings.Contains(out, "deterministic/pure") || !strings.Contains(out, filepath.Join("holons", "deep-prober")) {
+		t.Errorf("output lacks clade or path:\n%s", out)
+	}
+
+	md := lastMD()
+	if got := md.Get("authorization"); len(got) != 1 || got[0] != "Bearer s3cret" {
+		t.Errorf("authorization metadata = %q, want the bearer token", got)
+	}
+	if got := md.Get("x-team"); len(got) != 1 || got[0] != "probes" {
+		t.Errorf("x-team metadata = %q, want probes", got)
+	}
+}
+
+func TestRunClientListErrors(t *testing.T) {
+	for _, opts := range []ClientOptions{
+		{Server: "http://localhost:9090"},
+		{Server: "tcp://127.0.0.1:1", Context: []string{"no-equals"}},
+	} {
+		if err := RunClientList(opts, ""); err == nil {
+			t.Errorf("RunClientList(%+v) succeeded, want an error", opts)
+		}
+	}
+}
+
+// printedUUID returns the UUID of RunClientNew's output.
+func printedUUID(t *testing.T, out string) string {
+	t.Helper()
+	for _, line := range strings.Split(out, "\n") {
+		if uuid, ok := strings.CutPrefix(strings.TrimSpace(line), "UUID: "); ok {
+			return uuid
+		}
+	}
+	t.Fatalf("output lacks a UUID line:\n%s", out)
+	return ""
+}
+
+func TestRunClientNew(t *testing.T) {
+	root := t.TempDir()
+	uri, _ := startClientTestServer(t, root)
+	fields := ClientNewOptions{
+		GivenName: "Remote", FamilyName: "Prober", Motto: "Probes from afar.",
+		Composer: "Test", Clade: "deterministic/pure", Tags: []string{"Remote"},
+	}
+	out := captureStdout(t, func() {
+		if err := RunClientNew(ClientOptions{Server: uri}, fields); err != nil {
+			t.Fatalf("RunClientNew failed: %v", err)
+		}
+	})
+
+	uuid := printedUUID(t, out)
+	if !identity.IsUUID(uuid) {
+		t.Fatalf("printed UUID %q is not a UUID:\n%s", uuid, out)
+	}
+	path := filepath.Join(root, "holons", "remote-prober", "HOLON.md")
+	if !strings.Contains(out, "File: ") {
+		t.Errorf("output lacks the file path:\n%s", out)
+	}
+	got := readStatus(t, path)
+	if got.UUID != uuid || got.Clade != "deterministic/pure" || !got.HasTags([]string{"remote"}) {
+		t.Errorf("written holon = %+v, want uuid %s, the clade and the tag", got, uuid)
+	}
+}
+
+func TestRunClientNewWebSocket(t *testing.T) {
+	root := t.TempDir()
+	lis, err := transport.Listen("ws://127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := grpc.NewServer()
+	pb.RegisterSophiaWhoServiceServer(s, &server.Server{Root: root})
+	go s.Serve(lis) //nolint:errcheck
+	t.Cleanup(s.Stop)
+
+	fields := ClientNewOptions{GivenName: "Web", FamilyName: "Prober", Motto: "Probes.", Composer: "Test", Clade: "deterministic/pure"}
+	out := captureStdout(t, func() {
+		if err := RunClientNew(ClientOptions{Server: lis.Addr().String()}, fields); err != nil {
+			t.Fatalf("RunClientNew over %s failed: %v", lis.Addr(), err)
+		}
+	})
+	if uuid := printedUUID(t, out); !identity.IsUUID(uuid) {
+		t.Errorf("printed UUID %q is not a UUID", uuid)
+	}
+}
+
+func TestRunClientNewErrors(t *testing.T) {
+	root := t.TempDir()
+	uri, _ := startClientTestServer(t, root)
+	for _, fields := range []ClientNewOptions{
+		{GivenName: "Bad", FamilyName: "Clade", Clade: "quantum"},
+		{GivenName: "Bad", FamilyName: "Mode", Reproduction: "budding"},
+		{FamilyName: "Nameless"}, // rejected by the server
+	} {
+		if err := RunClientNew(ClientOptions{Server: uri}, fields); err == nil {
+			t.Errorf("RunClientNew(%+v) succeeded, want an error", fields)
+		}
+	}
+}
@@ -3,182 +3,1212 @@ package cli
 
 import (
 	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"runtime"
+	"sort"
+	"strconv"
 	"strings"
+	"text/tabwriter"
+	"text/template"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/organic-programming/sophia-who/pkg/identity"
+	"gopkg.in/yaml.v3"
 )
 
+// NewOptions presets answers that RunNew would otherwise prompt for.
+type NewOptions struct {
+	// Clade and Reproduction accept the canonical name or the 1-based
+	// index shown in the interactive menu.
+	Clade        string
+	Reproduction string
+
+	// Template is the path of a custom HOLON.md template used instead of
+	// the built-in one (see identity.RenderTemplate).
+	Template string
+
+	// OutputDir skips the output directory prompt. It is validated like
+	// CreateIdentity's output_dir (see identity.ValidateOutputDir).
+	OutputDir string
+
+	// Parents are the UUIDs (or unique prefixes) of the holon's parents.
+	// Each must resolve to a holon under the working directory unless
+	// AllowUnknownParents is set. Parents without a Reproduction imply
+	// "bred".
+	Parents             []string
+	AllowUnknownParents bool
+
+	// PreWriteHook is a shell command given the rendered HOLON.md on
+	// stdin before anything is written. A non-zero exit refuses the
+	// holon, with the command's stderr as the reason.
+	PreWriteHook string
+
+	// NameTemplate, when set, generates the default given name from a
+	// text/template (see identity.ParseNameTemplate), so that answering
+	// the prompt with nothing picks the first name not already taken.
+	NameTemplate string
+
+	// Print writes the rendered HOLON.md to stdout instead of creating
+	// the holon: no directory or file is created and OutputDir is
+	// ignored. Prompts go to stderr.
+	Print bool
+
+	// Generator, when set, numbers the new holon instead of the
+	// id_scheme of .holonrc (see identity.NewSeededGenerator).
+	Generator identity.IDGenerator
+
+	// SortLists writes aliases and parents in sorted order (see
+	// identity.RenderOptions.SortLists). The sort_lists key of .holonrc
+	// turns it on too.
+	SortLists bool
+
+	// NoBody writes only the frontmatter and title line, without the
+	// Description and Introspection scaffold. It cannot be combined
+	// with Template.
+	NoBody bool
+
+	// TrackChildren records the new holon in the children list of each
+	// of its parents found under the working directory (see
+	// identity.AppendChild).
+	TrackChildren bool
+
+	// Strict refuses the holon on what would otherwise be a warning,
+	// such as a given name equal to the family name (see
+	// identity.Identity.Warnings).
+	Strict bool
+
+	// NoPrompts reads the answers from stdin, one per line, without
+	// printing the prompts, for scripts piping them in. Like an
+	// interactive session, it fails if stdin ends before every prompt
+	// is answered.
+	NoPrompts bool
+
+	// Tags categorize the holon beyond its clade. They are lowercased
+	// and deduplicated (see identity.NormalizeTags).
+	Tags []string
+}
+
+// hookRunner runs PreWriteHook commands; replaceable in tests.
+var hookRunner identity.HookRunner = identity.ShellHookRunner
+
 // RunNew interactively creates a new holon identity.
-func RunNew() error {
-	scanner := bufio.NewScanner(os.Stdin)
-	id := identity.New()
+// Defaults for composer, language, clade, reproduction, and the output
+// directory are read from .holonrc (see identity.LoadConfig). Without a
+// configured composer, the git user is offered (see
+// identity.GitComposer).
+//
+// Clade and reproduction mode set in opts skip their menus. Parents
+// are resolved before any prompt so a typo fails fast.
+func RunNew(opts NewOptions) error {
+	cfg, err := identity.LoadConfig(".")
+	if err != nil {
+		return err
+	}
+	if opts.Generator != nil {
+		cfg.Generator = opts.Generator
+	}
+	if strings.TrimSpace(cfg.Composer) == "" {
+		cfg.Composer = identity.GitComposer(".")
+	}
 
-	fmt.Println("─── Sophia Who? — New Holon Identity ───")
-	fmt.Printf("UUID: %s (generated)\n\n", id.UUID)
+	var nameTmpl *template.Template
+	if opts.NameTemplate != "" {
+		if nameTmpl, err = identity.ParseNameTemplate(opts.NameTemplate); err != nil {
+			return err
+		}
+	}
 
-	id.FamilyName = ask(scanner, "Family name (the function — e.g. Transcriber, Prober)")
-	id.GivenName = ask(scanner, "Given name (the character — e.g. Swift, Deep)")
-	id.Composer = ask(scanner, "Composer (who is making this decision?)")
-	id.Motto = ask(scanner, "Motto (the dessein in one sentence)")
+	if opts.NoBody && opts.Template != "" {
+		return fmt.Errorf("--no-body cannot be combined with --template")
+	}
 
-	fmt.Println("\nClade (computational nature):")
-	for i, c := range identity.Clades {
-		fmt.Printf("  %d. %s\n", i+1, c)
+	var tmpl string
+	if opts.Template != "" {
+		data, err := os.ReadFile(opts.Template)
+		if err != nil {
+			return fmt.Errorf("cannot read template: %w", err)
+		}
+		tmpl = string(data)
 	}
-	id.Clade = askChoice(scanner, "Choose clade", identity.Clades)
 
-	fmt.Println("\nReproduction mode:")
-	for i, r := range identity.ReproductionModes {
-		fmt.Printf("  %d. %s\n", i+1, r)
+	clade, err := resolveChoice("clade", opts.Clade, identity.Clades)
+	if err != nil {
+		return err
+	}
+	reproduction, err := resolveChoice("reproduction mode", opts.Reproduction, identity.ReproductionModes)
+	if err != nil {
+		return err
+	}
+	parents, err := resolveParents(".", opts.Parents, opts.AllowUnknownParents)
+	if err != nil {
+		return err
+	}
+	tags := identity.NormalizeTags(opts.Tags)
+	for _, tag := range tags {
+		if err := identity.ValidateTag(tag); err != nil {
+			return err
+		}
+	}
+	if len(parents) > 0 && reproduction == "" {
+		reproduction = "bred"
+	}
+
+	// With Print, stdout carries only the HOLON.md.
+	p := &prompter{in: bufio.NewScanner(os.Stdin), out: os.Stdout}
+	if opts.Print {
+		p.out = os.Stderr
+	}
+	if opts.NoPrompts {
+		p.out = io.Discard
+	}
+	id := cfg.New()
+	if len(parents) > 0 {
+		id.Parents = parents
+	}
+	if len(tags) > 0 {
+		id.Tags = tags
 	}
-	id.Reproduction = askChoice(scanner, "Choose reproduction mode", identity.ReproductionModes)
 
-	id.Lang = askDefault(scanner, "Implementation language", "go")
+	fmt.Fprintln(p.out, "─── Sophia Who? — New Holon Identity ───")
+	fmt.Fprintf(p.out, "UUID: %s (generated)\n\n", id.UUID)
 
-	aliases := askDefault(scanner, "Aliases (comma-separated, or empty)", "")
-	if aliases != "" {
-		for _, a := range strings.Split(aliases, ",") {
-			if trimmed := strings.TrimSpace(a); trimmed != "" {
-				id.Aliases = append(id.Aliases, trimmed)
+	id.FamilyName = p.ask("Family name (the function — e.g. Transcriber, Prober)")
+	if nameTmpl != nil {
+		given, err := generateGivenName(nameTmpl, id)
+		if err != nil {
+			return err
+		}
+		id.GivenName = p.askDefault("Given name (the character — e.g. Swift, Deep)", given)
+	} else {
+		id.GivenName = p.ask("Given name (the character — e.g. Swift, Deep)")
+	}
+	if cfg.Composer != "" {
+		id.Composer = p.askDefault("Composer (who is making this decision?)", cfg.Composer)
+	} else {
+		id.Composer = p.ask("Composer (who is making this decision?)")
+	}
+	id.Motto = p.ask("Motto (the dessein in one sentence)")
+
+	id.Clade = clade
+	if id.Clade == "" {
+		fmt.Fprintln(p.out, "\nClade (computational nature):")
+		for i, c := range identity.Clades {
+			fmt.Fprintf(p.out, "  %d. %s\n", i+1, c)
+		}
+		id.Clade = p.askChoice("Choose clade", identity.Clades, cfg.Clade)
+	}
+
+	id.Reproduction = reproduction
+	if id.Reproduction == "" {
+		fmt.Fprintln(p.out, "\nReproduction mode:")
+		for i, r := range identity.ReproductionModes {
+			fmt.Fprintf(p.out, "  %d. %s\n", i+1, r)
+		}
+		id.Reproduction = p.askChoice("Choose reproduction mode", identity.ReproductionModes, cfg.Reproduction)
+	}
+
+	lang := cfg.Lang
+	if lang == "" {
+		lang = "go"
+	}
+	id.Lang = p.askDefault("Implementation language", lang)
+
+	id.Aliases = p.askAliases("Aliases (comma-separated, or empty)", cfg.ValidateOptions())
+	if p.err != nil {
+		return p.err
+	}
+
+	for _, w := range id.Warnings() {
+		if opts.Strict {
+			return fmt.Errorf("%v (refused by --strict)", w)
+		}
+		fmt.Fprintf(os.Stderr, "warning: %v\n", w)
+	}
+
+	outputDir := opts.OutputDir
+	if !opts.Print {
+		if outputDir == "" {
+			outputDir = p.askDefault("Output directory", cfg.OutputDir(id))
+			if p.err != nil {
+				return p.err
 			}
 		}
+		if err := identity.ValidateOutputDir(".", outputDir); err != nil {
+			return err
+		}
+	}
+
+	data, err := renderHolonMD(id, identity.RenderOptions{
+		SortLists: opts.SortLists || cfg.SortLists,
+		Minimal:   opts.NoBody,
+		Template:  tmpl,
+	})
+	if err != nil {
+		return err
+	}
+	if opts.PreWriteHook != "" {
+		if err := identity.RunPreWriteHook(hookRunner, opts.PreWriteHook, data); err != nil {
+			return err
+		}
 	}
 
-	dirName := strings.ToLower(id.GivenName + "-" + strings.TrimSuffix(id.FamilyName, "?"))
-	dirName = strings.ReplaceAll(dirName, " ", "-")
-	outputDir := askDefault(scanner, "Output directory", filepath.Join("holons", dirName))
+	if opts.Print {
+		_, err := os.Stdout.Write(data)
+		return err
+	}
 
 	if err := os.MkdirAll(outputDir, 0755); err != nil {
 		return fmt.Errorf("cannot create directory %s: %w", outputDir, err)
 	}
 
 	outputPath := filepath.Join(outputDir, "HOLON.md")
-
-	if err := identity.WriteHolonMD(id, outputPath); err != nil {
+	if err := identity.CreateHolonMD(outputPath, data); err != nil {
 		return err
 	}
+	audit(".", identity.AuditCreate, id, outputPath)
 
 	fmt.Printf("\n✓ Born: %s %s\n", id.GivenName, id.FamilyName)
 	fmt.Printf("  UUID: %s\n", id.UUID)
 	fmt.Printf("  File: %s\n", outputPath)
 
+	if opts.TrackChildren {
+		trackChild(".", id)
+	}
 	return nil
 }
 
-// RunShow reads and displays a holon's identity by UUID.
-func RunShow(target string) error {
-	path, err := identity.FindByUUID(".", target)
+// trackChild appends id to the children list of each of its parents
+// under root. The holon already exists, so failures are warnings.
+func trackChild(root string, id identity.Identity) {
+	for _, parent := range id.Parents {
+		path, err := identity.FindByUUID(root, parent)
+		if err == nil {
+			err = identity.AppendChild(path, id.UUID)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "warning: cannot record child in parent %s: %v\n", parent, err)
+		}
+	}
+}
+
+// ShowOptions controls which part of a HOLON.md file RunShow prints.
+type ShowOptions struct {
+	RawBody        bool // print only the markdown body
+	RawFrontmatter bool // print only the YAML frontmatter block
+	YAML           bool // print the parsed identity re-marshaled as YAML
+	Open           bool // open the holon directory with the OS handler
+	NoHeader       bool // omit the resolved-path header line
+	FieldsOnly     bool // print key=value lines, one per frontmatter field
+
+	// IncludeSiblings lists, below the identity, the other holons
+	// sharing a parent with it. It cannot be combined with the raw,
+	// YAML, and fields-only modes, whose output is meant for tools.
+	IncludeSiblings bool
+
+	// Verify validates the holon first, as who validate does. Problems
+	// are printed on stderr instead of the content, and RunShow fails.
+	Verify bool
+}
+
+// showProgressEvery is how many scanned files separate the redraws of
+// who show's spinner.
+const showProgressEvery = 500
+
+// RunShow reads and displays a holon's identity by UUID on stdout.
+func RunShow(target string, opts ShowOptions) error {
+	return RunShowTo(os.Stdout, os.Stderr, target, opts)
+}
+
+// RunShowTo is RunShow writing the identity to stdout and the scan
+// progress to stderr, for callers capturing or embedding the output.
+func RunShowTo(stdout, stderr io.Writer, target string, opts ShowOptions) error {
+	if opts.Open && !canOpen() {
+		return fmt.Errorf("--open requires an interactive session with a display")
+	}
+
+	if opts.IncludeSiblings && (opts.RawBody || opts.RawFrontmatter || opts.YAML || opts.FieldsOnly) {
+		return fmt.Errorf("--include-siblings cannot be combined with --raw-body, --raw-frontmatter, --yaml, or --fields-only")
+	}
+
+	progress := newProgressLine(stderr, false)
+	h, err := identity.ResolveTargetWithProgress(".", target, showProgressEvery, func(p identity.ScanProgress) {
+		if p.ScannedFiles > 0 {
+			progress.show("resolving "+target, p.ScannedFiles)
+		}
+	})
+	progress.clear()
 	if err != nil {
 		return err
 	}
+	path := h.Path
 
-	data, err := os.ReadFile(path)
+	data, err := identity.ReadHolonFile(path, 0)
 	if err != nil {
 		return fmt.Errorf("cannot read %s: %w", path, err)
 	}
 
-	fmt.Println(string(data))
+	if opts.Verify {
+		errs := identity.ValidateContent(data)
+		for _, fe := range errs {
+			fmt.Fprintf(stderr, "%s: %s\n", path, fe.Error())
+		}
+		if len(errs) > 0 {
+			return fmt.Errorf("%s: %d problem(s) found", path, len(errs))
+		}
+	}
+
+	out, err := renderShow(data, showHeader(".", path), opts)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+
+	fmt.Fprintln(stdout, out)
+
+	if opts.IncludeSiblings {
+		if err := printSiblings(stdout, ".", h.Identity); err != nil {
+			return err
+		}
+	}
+
+	if opts.Open {
+		return openDir(filepath.Dir(path), runtime.GOOS, startCommand)
+	}
+	return nil
+}
+
+// printSiblings lists on w the holons under root sharing a parent with
+// id, one line each, after a "Siblings:" heading.
+func printSiblings(w io.Writer, root string, id identity.Identity) error {
+	var siblings []identity.LocatedIdentity
+	seen := map[string]bool{id.UUID: true}
+	for _, parent := range id.Parents {
+		children, err := identity.FindChildren(root, parent)
+		if err != nil {
+			return err
+		}
+		for _, c := range children {
+			if !seen[c.Identity.UUID] {
+				seen[c.Identity.UUID] = true
+				siblings = append(siblings, c)
+			}
+		}
+	}
+
+	if len(siblings) == 0 {
+		fmt.Fprintln(w, "\nSiblings: none")
+		return nil
+	}
+	fmt.Fprintln(w, "\nSiblings:")
+	for _, s := range siblings {
+		name := strings.TrimSpace(s.Identity.GivenName + " " + s.Identity.FamilyName)
+		fmt.Fprintf(w, "  %-*s %-33s %s\n", uuidColumnWidth(false), identity.ShortUUID(s.Identity.UUID), name, relHolonDir(root, s.Path))
+	}
 	return nil
 }
 
+// renderShow selects the slice of a HOLON.md file requested by opts.
+// The header is prepended to the full-file output unless opts.NoHeader is set;
+// raw and YAML modes never include it so their output can be piped as-is.
+func renderShow(data []byte, header string, opts ShowOptions) (string, error) {
+	modes := 0
+	for _, set := range []bool{opts.RawBody, opts.RawFrontmatter, opts.YAML, opts.FieldsOnly} {
+		if set {
+			modes++
+		}
+	}
+
+	switch {
+	case modes > 1:
+		return "", fmt.Errorf("--raw-body, --raw-frontmatter, --yaml, and --fields-only are mutually exclusive")
+	case opts.RawBody:
+		_, body, err := identity.ParseFrontmatter(data)
+		if err != nil {
+			return "", err
+		}
+		return body, nil
+	case opts.RawFrontmatter:
+		return identity.RawFrontmatter(data)
+	case opts.YAML:
+		id, _, err := identity.ParseFrontmatter(data)
+		if err != nil {
+			return "", err
+		}
+		out, err := yaml.Marshal(id)
+		if err != nil {
+			return "", fmt.Errorf("marshal YAML: %w", err)
+		}
+		return strings.TrimSuffix(string(out), "\n"), nil
+	case opts.FieldsOnly:
+		id, _, err := identity.ParseFrontmatter(data)
+		if err != nil {
+			return "", err
+		}
+		return fieldLines(id)
+	case opts.NoHeader || header == "":
+		return string(data), nil
+	default:
+		return header + "\n\n" + string(data), nil
+	}
+}
+
+// fieldLines renders id as key=value lines in frontmatter order, with
+// list values comma-joined. Values are quoted for the shell when needed,
+// so the output can be sourced as well as grepped.
+func fieldLines(id identity.Identity) (string, error) {
+	var doc yaml.Node
+	if err := doc.Encode(id); err != nil {
+		return "", fmt.Errorf("encode fields: %w", err)
+	}
+
+	var lines []string
+	for i := 0; i+1 < len(doc.Content); i += 2 {
+		key, value := doc.Content[i].Value, doc.Content[i+1]
+		v := value.Value
+		if value.Kind == yaml.SequenceNode {
+			items := make([]string, len(value.Content))
+			for j, item := range value.Content {
+				items[j] = item.Value
+			}
+			v = strings.Join(items, ",")
+		}
+		lines = append(lines, key+"="+shellQuote(v))
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// shellQuote returns s unchanged when it is safe as a bare shell word,
+// single-quoted otherwise, and ANSI-C quoted ($'...') when it holds
+// newlines or other control characters, which keeps every value on
+// one line.
+func shellQuote(s string) string {
+	safe := func(r rune) bool {
+		return r < utf8.RuneSelf && (r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || strings.ContainsRune("_-.,/:@%+=", r))
+	}
+	if !strings.ContainsFunc(s, func(r rune) bool { return !safe(r) }) {
+		return s
+	}
+	if !strings.ContainsFunc(s, unicode.IsControl) {
+		return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+	}
+
+	var b strings.Builder
+	b.WriteString("$'")
+	for _, r := range s {
+		switch r {
+		case '\\', '\'':
+			b.WriteRune('\\')
+			b.WriteRune(r)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\t':
+			b.WriteString(`\t`)
+		default:
+			if unicode.IsControl(r) {
+				fmt.Fprintf(&b, `\u%04x`, r)
+			} else {
+				b.WriteRune(r)
+			}
+		}
+	}
+	b.WriteString("'")
+	return b.String()
+}
+
+// showHeader returns the header line naming the HOLON.md path relative to root.
+func showHeader(root, path string) string {
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		rel = path
+	}
+	return "─── " + filepath.ToSlash(rel) + " ───"
+}
+
+// ListOptions controls how RunList renders the holons it finds.
+type ListOptions struct {
+	// JSONL prints one JSON object per holon, as soon as it is discovered,
+	// instead of a table. Progress still goes to stderr.
+	JSONL bool
+
+	// OnlyUUID prints just the full UUID of each holon, one per line,
+	// for shell loops: no header, no progress, and nothing when no holon
+	// is found. A "... N more" footer goes to stderr.
+	OnlyUUID bool
+
+	// IncludeIgnored lists HOLON.md files next to a .holonignore marker.
+	IncludeIgnored bool
+
+	// Symlinks says whether symlinked HOLON.md files are followed,
+	// skipped, or fail the listing. Followed links resolving outside the
+	// scanned directory are reported on stderr.
+	Symlinks identity.SymlinkPolicy
+
+	// Long adds a MOTTO column to the table, truncated to mottoWidth.
+	Long bool
+
+	// FullUUID shows complete UUIDs in the table instead of their first
+	// characters (see identity.ShortUUID). --fields and JSON Lines
+	// output always carry full UUIDs.
+	FullUUID bool
+
+	// Watch keeps the table on screen, redrawing it as HOLON.md files
+	// under root are created, modified, or removed, until Ctrl-C.
+	Watch bool
+
+	// Tree groups holons under their parent directories instead of
+	// printing a table. The tree is printed once the scan completes.
+	Tree bool
+
+	// GitRef lists the holons under root as committed at this git ref
+	// (branch, tag, or commit) instead of the work tree and cache.
+	// Their origin is "git".
+	GitRef string
+
+	// ChangedSince lists only the holons whose HOLON.md differs between
+	// this git ref and the work tree (see identity.ChangedHolonFiles).
+	// The cache is not scanned. With GitRef, the holons are listed as
+	// they are at that ref, e.g. the versions a branch started from.
+	ChangedSince string
+
+	// Fields selects and orders the table columns (see listFields).
+	// Empty keeps the default columns.
+	Fields []string
+
+	// Limit prints at most this many holons (0: no limit), after
+	// skipping the first Offset ones, in the order they are found.
+	// That order needs no sort step to be stable: the scan walks
+	// directories in lexical order, local holons before cached ones, so
+	// an unchanged tree pages the same way on every run. Holons left out
+	// past the limit are counted in a "... N more" footer, so the scan
+	// still runs to the end.
+	Limit  int
+	Offset int
+
+	// Invalid lists only the HOLON.md files under root that fail to
+	// parse or to validate, with the reasons, instead of the holons.
+	// The cache is not scanned.
+	Invalid bool
+
+	// Dedupe selects how duplicate holons are collapsed: "uuid" (the
+	// default) keeps the first local holon per UUID; "content" also
+	// collapses holons whose frontmatter is identical apart from the UUID,
+	// across local and cached origins, and reports the collapsed paths.
+	Dedupe string
+
+	// Tags lists only the holons carrying every one of these tags,
+	// compared case-insensitively.
+	Tags []string
+}
+
+// Dedupe modes for ListOptions.
+const (
+	DedupeUUID    = "uuid"
+	DedupeContent = "content"
+)
+
+// listFields maps the column names accepted by list --fields to their
+// values. Identity fields use their frontmatter key.
+var listFields = map[string]func(e listEntry) string{
+	"uuid":         func(e listEntry) string { return e.UUID },
+	"name":         func(e listEntry) string { return strings.TrimSpace(e.GivenName + " " + e.FamilyName) },
+	"given_name":   func(e listEntry) string { return e.GivenName },
+	"family_name":  func(e listEntry) string { return e.FamilyName },
+	"motto":        func(e listEntry) string { return truncate(e.Motto, mottoWidth) },
+	"composer":     func(e listEntry) string { return e.Composer },
+	"clade":        func(e listEntry) string { return e.Clade },
+	"status":       func(e listEntry) string { return e.Status },
+	"born":         func(e listEntry) string { return e.Born },
+	"parents":      func(e listEntry) string { return strings.Join(e.Parents, ",") },
+	"reproduction": func(e listEntry) string { return e.Reproduction },
+	"aliases":      func(e listEntry) string { return strings.Join(e.Aliases, ",") },
+	"tags":         func(e listEntry) string { return strings.Join(e.Tags, ",") },
+	"generated_by": func(e listEntry) string { return e.GeneratedBy },
+	"lang":         func(e listEntry) string { return e.Lang },
+	"proto_status": func(e listEntry) string { return e.ProtoStatus },
+	"origin":       func(e listEntry) string { return e.Origin },
+	"path":         func(e listEntry) string { return e.Path },
+}
+
+// ParseListFields parses a --fields value such as "uuid,name,clade",
+// rejecting names not in listFields.
+func ParseListFields(value string) ([]string, error) {
+	var fields []string
+	for _, f := range strings.Split(value, ",") {
+		f = strings.ToLower(strings.TrimSpace(f))
+		if f == "" {
+			continue
+		}
+		if _, ok := listFields[f]; !ok {
+			known := make([]string, 0, len(listFields))
+			for name := range listFields {
+				known = append(known, name)
+			}
+			sort.Strings(known)
+			return nil, fmt.Errorf("unknown field %q (known: %s)", f, strings.Join(known, ", "))
+		}
+		fields = append(fields, f)
+	}
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("no fields given")
+	}
+	return fields, nil
+}
+
+// mottoWidth is the number of runes of a motto shown by list --long.
+const mottoWidth = 60
+
+// listEntry is the JSON Lines representation of a listed holon.
+type listEntry struct {
+	identity.Identity
+	Origin string `json:"origin"`
+	Path   string `json:"path"`
+}
+
 // RunList scans both local holons and the global cache, labeling the origin
 // of each so the actant knows what is local and what is a dependency.
-func RunList(root string) error {
+// A root naming a HOLON.md file lists just that holon.
+func RunList(root string, opts ListOptions) error {
+	return RunListTo(os.Stdout, os.Stderr, root, opts)
+}
+
+// RunListTo is RunList writing the listing to stdout, and the scan
+// progress, skipped files, and collapsed duplicates to stderr.
+func RunListTo(stdout, stderr io.Writer, root string, opts ListOptions) error {
 	if root == "" {
 		root = "."
 	}
 	root = filepath.Clean(root)
+	fileRoot, err := identity.HolonFileRoot(root)
+	if err != nil {
+		return err
+	}
+	if fileRoot && (opts.Watch || opts.Invalid || opts.GitRef != "" || opts.ChangedSince != "") {
+		return fmt.Errorf("--watch, --invalid, --git-ref, and --changed-since need a directory, not %s", root)
+	}
 
-	localSeen := map[string]struct{}{}
+	switch opts.Dedupe {
+	case "", DedupeUUID, DedupeContent:
+	default:
+		return fmt.Errorf("unknown dedupe mode %q (want %s or %s)", opts.Dedupe, DedupeUUID, DedupeContent)
+	}
+	byContent := opts.Dedupe == DedupeContent
+	if opts.Limit < 0 || opts.Offset < 0 {
+		return fmt.Errorf("--limit and --offset must not be negative")
+	}
+
+	if opts.OnlyUUID && (opts.JSONL || opts.Tree || opts.Long || len(opts.Fields) > 0 || opts.Watch || opts.Invalid) {
+		return fmt.Errorf("--only-uuid cannot be combined with --jsonl, --tree, --long, --fields, --watch, or --invalid")
+	}
+
+	if opts.Watch {
+		if opts.JSONL || opts.Tree || len(opts.Fields) > 0 || opts.Limit > 0 || opts.Offset > 0 || opts.Invalid || len(opts.Tags) > 0 {
+			return fmt.Errorf("--watch cannot be combined with --jsonl, --tree, --fields, --limit, --offset, --invalid, or --tag")
+		}
+		if opts.GitRef != "" || opts.ChangedSince != "" {
+			return fmt.Errorf("--watch cannot be combined with --git-ref or --changed-since")
+		}
+		return runWatch(stdout, root, identity.ScanOptions{IncludeIgnored: opts.IncludeIgnored, SkipDirs: cacheSkipDirs(), Symlinks: opts.Symlinks}, opts.FullUUID)
+	}
+	if opts.Invalid {
+		if opts.Tree || opts.Long || len(opts.Fields) > 0 || opts.Limit > 0 || opts.Offset > 0 || opts.GitRef != "" || opts.ChangedSince != "" || len(opts.Tags) > 0 {
+			return fmt.Errorf("--invalid cannot be combined with --tree, --long, --fields, --limit, --offset, --git-ref, --changed-since, or --tag")
+		}
+		return listInvalid(stdout, root, opts)
+	}
+
+	// A HOLON.md root is listed alone, its directory shown as ".".
+	displayRoot := root
+	if fileRoot {
+		displayRoot = filepath.Dir(root)
+	}
+
+	var changed map[string]bool
+	if opts.ChangedSince != "" {
+		if changed, err = identity.ChangedHolonFiles(root, opts.ChangedSince); err != nil {
+			return err
+		}
+	}
+
+	localSeen := map[string]string{}
+	var contentSeen map[string]string
+	if byContent {
+		contentSeen = map[string]string{}
+	}
+	var collapsed []string
+	listed, remaining := 0, 0
 	printedHeader := false
 	printedEntries := 0
-	inlineProgress := isTerminal(os.Stderr)
-	progressVisible := false
+	progress := newProgressLine(stderr, true)
+
+	jsonOut := json.NewEncoder(stdout)
+	// Custom columns are sized to their content, so rows are aligned by
+	// a tabwriter flushed once the scan is over.
+	table := tabwriter.NewWriter(stdout, 0, 0, 2, ' ', 0)
+	tree := newTreeNode()
+	uuidWidth := uuidColumnWidth(opts.FullUUID)
+
+	printEntry := func(id identity.Identity, origin, path string) {
+		progress.clear()
 
-	clearProgressLine := func() {
-		if !inlineProgress || !progressVisible {
+		if opts.OnlyUUID {
+			fmt.Fprintln(stdout, id.UUID)
+			printedEntries++
 			return
 		}
-		fmt.Fprint(os.Stderr, "\r\033[2K")
-		progressVisible = false
-	}
 
-	printProgress := func(scanLabel string, scannedFiles int) {
-		if !inlineProgress {
-			fmt.Fprintf(os.Stderr, "[scan] %s: %d files scanned\n", scanLabel, scannedFiles)
+		if opts.JSONL {
+			if err := jsonOut.Encode(listEntry{Identity: id, Origin: origin, Path: path}); err == nil {
+				printedEntries++
+			}
 			return
 		}
-		fmt.Fprintf(os.Stderr, "\r\033[2K[scan] %s: %d files scanned", scanLabel, scannedFiles)
-		progressVisible = true
-	}
 
-	printEntry := func(id identity.Identity, origin, path string) {
-		clearProgressLine()
+		if opts.Tree {
+			tree.add(listEntry{Identity: id, Origin: origin, Path: path})
+			printedEntries++
+			return
+		}
+
+		if len(opts.Fields) > 0 {
+			if !printedHeader {
+				header := make([]string, len(opts.Fields))
+				for i, f := range opts.Fields {
+					header[i] = strings.ToUpper(f)
+				}
+				fmt.Fprintln(table, strings.Join(header, "\t"))
+				printedHeader = true
+			}
+			entry := listEntry{Identity: id, Origin: origin, Path: path}
+			row := make([]string, len(opts.Fields))
+			for i, f := range opts.Fields {
+				row[i] = listFields[f](entry)
+			}
+			fmt.Fprintln(table, strings.Join(row, "\t"))
+			printedEntries++
+			return
+		}
 
 		if !printedHeader {
-			fmt.Printf("%-38s %-33s %-8s %-25s %-8s %s\n", "UUID", "NAME", "ORIGIN", "CLADE", "STATUS", "PATH")
-			fmt.Println(strings.Repeat("─", 150))
+			header := fmt.Sprintf("%-*s %-33s %-8s %-25s %-8s %s", uuidWidth, "UUID", "NAME", "ORIGIN", "CLADE", "STATUS", "PATH")
+			width := 150 - fullUUIDWidth + uuidWidth
+			if opts.Long {
+				header = fmt.Sprintf("%-*s %-33s %-8s %-25s %-8s %-*s %s", uuidWidth, "UUID", "NAME", "ORIGIN", "CLADE", "STATUS", mottoWidth, "MOTTO", "PATH")
+				width += mottoWidth + 1
+			}
+			fmt.Fprintln(stdout, header)
+			fmt.Fprintln(stdout, strings.Repeat("─", width))
 			printedHeader = true
 		}
 
 		name := strings.TrimSpace(id.GivenName + " " + id.FamilyName)
-		fmt.Printf("%-38s %-33s %-8s %-25s %-8s %s\n", id.UUID, name, origin, id.Clade, id.Status, path)
+		uuid := displayUUID(id.UUID, opts.FullUUID)
+		if opts.Long {
+			motto := padRunes(truncate(id.Motto, mottoWidth), mottoWidth)
+			fmt.Fprintf(stdout, "%-*s %-33s %-8s %-25s %-8s %s %s\n", uuidWidth, uuid, name, origin, id.Clade, id.Status, motto, path)
+		} else {
+			fmt.Fprintf(stdout, "%-*s %-33s %-8s %-25s %-8s %s\n", uuidWidth, uuid, name, origin, id.Clade, id.Status, path)
+		}
 		printedEntries++
 	}
 
-	scanAndPrint := func(scanRoot, scanLabel, origin string, dedupe map[string]struct{}) {
-		lastReported := 0
-		err := identity.ScanAllWithPaths(scanRoot, 500, func(h identity.LocatedIdentity) {
-			key := h.Identity.UUID
-			if key == "" {
-				key = h.Path
-			}
-			if dedupe != nil {
-				if _, duplicate := dedupe[key]; duplicate {
-					return
-				}
-				dedupe[key] = struct{}{}
+	scanOpts := identity.ScanOptions{
+		ProgressEvery:  500,
+		IncludeIgnored: opts.IncludeIgnored,
+		SkipDirs:       cacheSkipDirs(),
+		Symlinks:       opts.Symlinks,
+		OnError: func(path string, err error) {
+			if errors.Is(err, identity.ErrFileTooLarge) {
+				progress.clear()
+				fmt.Fprintf(stderr, "skipped %v\n", err)
 			}
+		},
+		OnWarning: func(path string, err error) {
+			progress.clear()
+			fmt.Fprintf(stderr, "warning: %v\n", err)
+		},
+	}
 
-			printEntry(h.Identity, origin, relHolonDir(root, h.Path))
-		}, func(progress identity.ScanProgress) {
-			if progress.ScannedFiles == 0 || progress.ScannedFiles == lastReported {
+	handle := func(h identity.LocatedIdentity, origin string, dedupe map[string]string) {
+		if !h.Identity.HasTags(opts.Tags) {
+			return
+		}
+		if changed != nil && !changed[filepath.Clean(h.Path)] {
+			return
+		}
+		key := h.Identity.UUID
+		if key == "" {
+			key = h.Path
+		}
+		if dedupe != nil {
+			if _, duplicate := dedupe[key]; duplicate {
 				return
 			}
-			lastReported = progress.ScannedFiles
-			printProgress(scanLabel, progress.ScannedFiles)
+			dedupe[key] = h.Path
+		}
+
+		path := displayHolonDir(displayRoot, h.Path, origin)
+		if byContent {
+			hash := contentHash(h.Identity)
+			if first, duplicate := contentSeen[hash]; duplicate {
+				collapsed = append(collapsed, fmt.Sprintf("collapsed %s (same content as %s)", path, first))
+				return
+			}
+			contentSeen[hash] = path
+		}
+
+		listed++
+		if listed <= opts.Offset {
+			return
+		}
+		if opts.Limit > 0 && listed > opts.Offset+opts.Limit {
+			remaining++
+			return
+		}
+		printEntry(h.Identity, origin, path)
+	}
+
+	scanAndPrint := func(scanRoot, scanLabel, origin string, dedupe map[string]string) error {
+		lastReported := 0
+		return identity.ScanWithOptions(scanRoot, scanOpts, func(h identity.LocatedIdentity) {
+			handle(h, origin, dedupe)
+		}, func(p identity.ScanProgress) {
+			if opts.OnlyUUID || p.ScannedFiles == 0 || p.ScannedFiles == lastReported {
+				return
+			}
+			lastReported = p.ScannedFiles
+			progress.show(scanLabel, p.ScannedFiles)
+		})
+	}
+
+	if opts.GitRef != "" {
+		// Holons committed at the ref, under root; the work tree and the
+		// cache are not consulted.
+		err := identity.ScanGitRef(root, opts.GitRef, scanOpts, func(h identity.LocatedIdentity) {
+			handle(h, "git", localSeen)
 		})
 		if err != nil {
-			return
+			return err
+		}
+	} else if fileRoot {
+		if err := scanAndPrint(root, "root", "local", localSeen); err != nil {
+			return err
+		}
+	} else {
+		// Local holons: <root>/holons/
+		if err := scanAndPrint(filepath.Join(root, "holons"), "local", "local", localSeen); err != nil {
+			return err
+		}
+
+		// Also scan root itself for HOLON.md (standalone project)
+		if err := scanAndPrint(root, "root", "local", localSeen); err != nil {
+			return err
+		}
+
+		// Cached holons: $OPPATH/cache/, or the XDG cache (see holonCacheDir)
+		cacheDir := holonCacheDir()
+		if cacheDir != "" && changed == nil {
+			if err := scanAndPrint(cacheDir, "cache", "cached", nil); err != nil {
+				return err
+			}
+		}
+	}
+
+	progress.clear()
+	table.Flush()
+	if opts.Tree {
+		tree.render(stdout, 0)
+	}
+	if remaining > 0 {
+		// Keep stdout parseable as JSON Lines or a UUID list.
+		footer := stdout
+		if opts.JSONL || opts.OnlyUUID {
+			footer = stderr
+		}
+		fmt.Fprintf(footer, "... %d more\n", remaining)
+	}
+
+	for _, line := range collapsed {
+		fmt.Fprintln(stderr, line)
+	}
+
+	if printedEntries == 0 && !opts.JSONL && !opts.OnlyUUID {
+		fmt.Fprintln(stdout, "No holons found.")
+	}
+
+	return nil
+}
+
+// fullUUIDWidth is the width of the UUID column showing full UUIDs.
+const fullUUIDWidth = 38
+
+// uuidColumnWidth is the width of the UUID column of the list and watch
+// tables.
+func uuidColumnWidth(full bool) int {
+	if full {
+		return fullUUIDWidth
+	}
+	return identity.ShortIDLength + 2
+}
+
+// displayUUID returns uuid as the list and watch tables show it.
+func displayUUID(uuid string, full bool) string {
+	if full {
+		return uuid
+	}
+	return identity.ShortUUID(uuid)
+}
+
+// invalidEntry is the JSON Lines representation of a HOLON.md listed
+// by list --invalid.
+type invalidEntry struct {
+	Path   string   `json:"path"`
+	Errors []string `json:"errors"`
+}
+
+// listInvalid prints on w the HOLON.md files under root that fail to
+// parse or to validate, one per line with the reasons.
+func listInvalid(w io.Writer, root string, opts ListOptions) error {
+	scanOpts := identity.ScanOptions{IncludeIgnored: opts.IncludeIgnored, SkipDirs: cacheSkipDirs(), Symlinks: opts.Symlinks}
+	jsonOut := json.NewEncoder(w)
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	found := 0
+	err := identity.ValidateFiles(root, scanOpts, func(v identity.FileValidation) bool {
+		if len(v.Errors) == 0 {
+			return true
+		}
+		entry := invalidEntry{Path: relHolonDir(root, v.Path)}
+		for _, fe := range v.Errors {
+			entry.Errors = append(entry.Errors, fe.Error())
+		}
+		if opts.JSONL {
+			jsonOut.Encode(entry) //nolint:errcheck
+		} else {
+			if found == 0 {
+				fmt.Fprintln(table, "PATH\tREASON")
+			}
+			fmt.Fprintf(table, "%s\t%s\n", entry.Path, strings.Join(entry.Errors, "; "))
+		}
+		found++
+		return true
+	}, nil)
+	if err != nil {
+		return err
+	}
+	table.Flush()
+
+	if found == 0 && !opts.JSONL {
+		fmt.Fprintln(w, "No invalid holons found.")
+	}
+	return nil
+}
+
+// contentHash fingerprints the normalized frontmatter of id, ignoring
+// its UUID so that clones given a fresh UUID still compare equal.
+func contentHash(id identity.Identity) string {
+	id.UUID = ""
+	data, err := yaml.Marshal(id)
+	if err != nil {
+		return id.Slug()
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// DoctorOptions controls RunDoctor.
+type DoctorOptions struct {
+	Lint identity.LintOptions
+
+	// Fix rewrites HOLON.md files in place to repair what identity.Fix
+	// can repair, before linting.
+	Fix bool
+
+	// Format selects the report format: "text" (the default) prints one
+	// line per finding; "json" prints a JSON array of findings.
+	Format string
+}
+
+// Doctor report formats for DoctorOptions.
+const (
+	DoctorFormatText = "text"
+	DoctorFormatJSON = "json"
+)
+
+// RunDoctor scans root for HOLON.md files and reports lint findings.
+// The text report never fails the command. With the JSON format, meant
+// for CI, error-severity findings such as duplicate UUIDs make it return
+// an error once the report is printed; warnings never do.
+func RunDoctor(root string, opts DoctorOptions) error {
+	if root == "" {
+		root = "."
+	}
+	root = filepath.Clean(root)
+
+	switch opts.Format {
+	case "", DoctorFormatText, DoctorFormatJSON:
+	default:
+		return fmt.Errorf("unknown format %q (want %s or %s)", opts.Format, DoctorFormatText, DoctorFormatJSON)
+	}
+
+	if opts.Fix {
+		if err := fixAll(root); err != nil {
+			return err
+		}
+	}
+
+	var holons []identity.LocatedIdentity
+	err := identity.ScanAllWithPaths(root, 0, func(h identity.LocatedIdentity) {
+		holons = append(holons, h)
+	}, nil)
+	if err != nil {
+		return fmt.Errorf("scan %s: %w", root, err)
+	}
+
+	findings := identity.LintAll(holons, opts.Lint)
+	errorCount := 0
+	for i := range findings {
+		findings[i].Path = relHolonDir(root, findings[i].Path)
+		if findings[i].Severity == identity.SeverityError {
+			errorCount++
+		}
+	}
+
+	switch {
+	case opts.Format == DoctorFormatJSON:
+		if findings == nil {
+			findings = []identity.Finding{}
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(findings); err != nil {
+			return err
+		}
+	case len(findings) == 0:
+		fmt.Println("No issues found.")
+	default:
+		for _, f := range findings {
+			fmt.Printf("%s [%s] %s: %s\n", f.Severity, f.Rule, f.Path, f.Message)
 		}
 	}
 
-	// Local holons: <root>/holons/
-	scanAndPrint(filepath.Join(root, "holons"), "local", "local", localSeen)
+	if errorCount > 0 && opts.Format == DoctorFormatJSON {
+		return fmt.Errorf("doctor found %d error(s)", errorCount)
+	}
+	return nil
+}
 
-	// Also scan root itself for HOLON.md (standalone project)
-	scanAndPrint(root, "root", "local", localSeen)
+// RunFmt normalizes every HOLON.md under root with identity.Fix, as
+// who doctor --fix does: byte order marks, line endings, exactly one
+// trailing newline, duplicate aliases. With check, files are left as
+// they are and RunFmt fails if any of them would change.
+func RunFmt(root string, check bool) error {
+	if root == "" {
+		root = "."
+	}
+	if !check {
+		return fixAll(root)
+	}
 
-	// Cached holons: $OPPATH/cache/ (default: ~/.op/cache/)
-	cacheDir := holonCacheDir()
-	if cacheDir != "" {
-		scanAndPrint(cacheDir, "cache", "cached", nil)
+	paths, err := identity.HolonFiles(root, identity.ScanOptions{})
+	if err != nil {
+		return fmt.Errorf("scan %s: %w", root, err)
+	}
+	unformatted := 0
+	for _, path := range paths {
+		data, err := identity.ReadHolonFile(path, 0)
+		if err != nil {
+			return err
+		}
+		if _, changes := identity.Fix(data); len(changes) > 0 {
+			fmt.Printf("would fix %s: %s\n", relHolonDir(root, path), strings.Join(changes, ", "))
+			unformatted++
+		}
+	}
+	if unformatted > 0 {
+		return fmt.Errorf("%d file(s) need formatting; run who fmt", unformatted)
 	}
+	return nil
+}
 
-	clearProgressLine()
+// fixAll applies identity.Fix to every HOLON.md under root and prints
+// one line per file changed.
+func fixAll(root string) error {
+	paths, err := identity.HolonFiles(root, identity.ScanOptions{})
+	if err != nil {
+		return fmt.Errorf("scan %s: %w", root, err)
+	}
 
-	if printedEntries == 0 {
-		fmt.Println("No holons found.")
+	fixed := 0
+	for _, path := range paths {
+		var changes []string
+		err := identity.RewriteHolonFile(path, func(data []byte) ([]byte, error) {
+			var repaired []byte
+			if repaired, changes = identity.Fix(data); len(changes) == 0 {
+				return nil, nil
+			}
+			return repaired, nil
+		})
+		if err != nil {
+			return err
+		}
+		if len(changes) == 0 {
+			continue
+		}
+		fmt.Printf("fixed %s: %s\n", relHolonDir(root, path), strings.Join(changes, ", "))
+		fixed++
 	}
 
+	if fixed > 0 {
+		fmt.Printf("Fixed %d file(s).\n\n", fixed)
+	}
 	return nil
 }
 
-// holonCacheDir returns the global holon cache directory
-// ($OPPATH/cache/, default: ~/.op/cache/).
+// truncate shortens s to at most max runes, replacing the tail with an
+// ellipsis when it does not fit. Newlines are folded into spaces so the
+// result stays on one line.
+func truncate(s string, max int) string {
+	s = strings.Join(strings.Fields(s), " ")
+	if max <= 0 || utf8.RuneCountInString(s) <= max {
+		return s
+	}
+	runes := []rune(s)
+	return string(runes[:max-1]) + "…"
+}
+
+// padRunes right-pads s with spaces to width runes. Unlike %-*s, it counts
+// runes rather than bytes, so multibyte text lines up.
+func padRunes(s string, width int) string {
+	if n := utf8.RuneCountInString(s); n < width {
+		return s + strings.Repeat(" ", width-n)
+	}
+	return s
+}
+
+// cacheSkipDirs keeps local scans out of the holon cache when it lives
+// under the scanned root, so cached holons are only listed as cached.
+func cacheSkipDirs() []string {
+	if dir := holonCacheDir(); dir != "" {
+		return []string{dir}
+	}
+	return nil
+}
+
+// holonCacheDir returns the global holon cache directory. In order:
+//
+//   - $OPPATH/cache/ when OPPATH is set;
+//   - ~/.op/cache/ when it already exists, for compatibility;
+//   - $XDG_CACHE_HOME/op/ when XDG_CACHE_HOME is set;
+//   - ~/.cache/op/ on Linux, ~/.op/cache/ elsewhere.
+//
 // Returns an empty string if the home directory cannot be determined.
 func holonCacheDir() string {
 	if runtimeHome := strings.TrimSpace(os.Getenv("OPPATH")); runtimeHome != "" {
@@ -188,16 +1218,92 @@ func holonCacheDir() string {
 	if err != nil {
 		return ""
 	}
-	return filepath.Join(home, ".op", "cache")
+
+	legacy := filepath.Join(home, ".op", "cache")
+	if info, err := os.Stat(legacy); err == nil && info.IsDir() {
+		return legacy
+	}
+	// The XDG spec says relative paths must be ignored.
+	if xdg := os.Getenv("XDG_CACHE_HOME"); filepath.IsAbs(xdg) {
+		return filepath.Join(xdg, "op")
+	}
+	if runtime.GOOS == "linux" {
+		return filepath.Join(home, ".cache", "op")
+	}
+	return legacy
 }
 
+// relHolonDir returns the directory of holonPath relative to root, or
+// its absolute path when it lies outside root, so a display path never
+// climbs out through "..".
 func relHolonDir(root, holonPath string) string {
 	dir := filepath.Dir(holonPath)
-	rel, err := filepath.Rel(root, dir)
-	if err != nil {
-		return filepath.Clean(dir)
+	if rel, err := filepath.Rel(root, dir); err == nil && (rel == "." || filepath.IsLocal(rel)) {
+		return rel
+	}
+	return absDir(dir)
+}
+
+// displayHolonDir is how list shows where a holon lives: cached holons
+// are outside the project, so they get an absolute path; local holons
+// are shown relative to root (see relHolonDir).
+func displayHolonDir(root, holonPath, origin string) string {
+	if origin == "cached" {
+		return absDir(filepath.Dir(holonPath))
 	}
-	return filepath.Clean(rel)
+	return relHolonDir(root, holonPath)
+}
+
+func absDir(dir string) string {
+	if abs, err := filepath.Abs(dir); err == nil {
+		return abs
+	}
+	return filepath.Clean(dir)
+}
+
+// progressLine reports scan progress on w. On a terminal it is one line
+// with a spinner, redrawn in place and erased by clear. Elsewhere each
+// update is a line of its own when logged is set, and nothing is written
+// otherwise, so redirected output stays clean.
+type progressLine struct {
+	w       io.Writer
+	inline  bool
+	logged  bool
+	visible bool
+	frame   int
+}
+
+// newProgressLine returns a progressLine writing to w.
+func newProgressLine(w io.Writer, logged bool) *progressLine {
+	return &progressLine{w: w, inline: isTerminalWriter(w), logged: logged}
+}
+
+// show reports that scannedFiles files have been scanned for label.
+func (p *progressLine) show(label string, scannedFiles int) {
+	switch {
+	case p.inline:
+		frames := []rune(`|/-\`)
+		fmt.Fprintf(p.w, "\r\033[2K%c [scan] %s: %d files scanned", frames[p.frame%len(frames)], label, scannedFiles)
+		p.frame++
+		p.visible = true
+	case p.logged:
+		fmt.Fprintf(p.w, "[scan] %s: %d files scanned\n", label, scannedFiles)
+	}
+}
+
+// clear erases the progress line, if one is drawn, so that other output
+// can take its place.
+func (p *progressLine) clear() {
+	if p.visible {
+		fmt.Fprint(p.w, "\r\033[2K")
+		p.visible = false
+	}
+}
+
+// isTerminalWriter reports whether w is a file open on a terminal.
+func isTerminalWriter(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	return ok && isTerminal(f)
 }
 
 func isTerminal(f *os.File) bool {
@@ -211,42 +1317,193 @@ func isTerminal(f *os.File) bool {
 	return (info.Mode() & os.ModeCharDevice) != 0
 }
 
-func ask(scanner *bufio.Scanner, prompt string) string {
+// prompter asks for answers on in, writing prompts and hints to out.
+type prompter struct {
+	in  *bufio.Scanner
+	out io.Writer
+
+	// err records the first prompt left unanswered because the input
+	// ended or failed. Later prompts return at once with their default.
+	err error
+}
+
+// read reads the answer to prompt, trimmed. At the end of the input it
+// records p.err and reports false, so that callers stop asking instead
+// of prompting forever.
+func (p *prompter) read(prompt string) (string, bool) {
+	if p.err != nil {
+		return "", false
+	}
+	if !p.in.Scan() {
+		err := p.in.Err()
+		if err == nil {
+			err = io.ErrUnexpectedEOF
+		}
+		name, _, _ := strings.Cut(prompt, " (")
+		p.err = fmt.Errorf("no answer for %q: %w", name, err)
+		return "", false
+	}
+	return strings.TrimSpace(p.in.Text()), true
+}
+
+func (p *prompter) ask(prompt string) string {
 	for {
-		fmt.Printf("%s: ", prompt)
-		scanner.Scan()
-		answer := strings.TrimSpace(scanner.Text())
-		if answer != "" {
+		fmt.Fprintf(p.out, "%s: ", prompt)
+		answer, ok := p.read(prompt)
+		if !ok || answer != "" {
 			return answer
 		}
-		fmt.Println("  (required)")
+		fmt.Fprintln(p.out, "  (required)")
 	}
 }
 
-func askDefault(scanner *bufio.Scanner, prompt, defaultVal string) string {
+func (p *prompter) askDefault(prompt, defaultVal string) string {
 	if defaultVal != "" {
-		fmt.Printf("%s [%s]: ", prompt, defaultVal)
+		fmt.Fprintf(p.out, "%s [%s]: ", prompt, defaultVal)
 	} else {
-		fmt.Printf("%s: ", prompt)
+		fmt.Fprintf(p.out, "%s: ", prompt)
 	}
-	scanner.Scan()
-	answer := strings.TrimSpace(scanner.Text())
+	answer, _ := p.read(prompt)
 	if answer == "" {
 		return defaultVal
 	}
 	return answer
 }
 
-func askChoice(scanner *bufio.Scanner, prompt string, choices []string) string {
+func (p *prompter) askAliases(prompt string, opts identity.ValidateOptions) []string {
+	for {
+		answer := p.askDefault(prompt, "")
+		if answer == "" || p.err != nil {
+			return nil
+		}
+		aliases := identity.NormalizeAliases(strings.Split(answer, ","))
+		valid := true
+		for _, a := range aliases {
+			if err := identity.ValidateAliasWithOptions(a, opts); err != nil {
+				fmt.Fprintf(p.out, "  (%v)\n", err)
+				valid = false
+				break
+			}
+		}
+		if valid {
+			return aliases
+		}
+	}
+}
+
+func (p *prompter) askChoice(prompt string, choices []string, defaultVal string) string {
 	for {
-		fmt.Printf("%s (1-%d): ", prompt, len(choices))
-		scanner.Scan()
-		answer := strings.TrimSpace(scanner.Text())
-		for i, c := range choices {
-			if answer == fmt.Sprintf("%d", i+1) || answer == c {
-				return c
+		if defaultVal != "" {
+			fmt.Fprintf(p.out, "%s (1-%d) [%s]: ", prompt, len(choices), defaultVal)
+		} else {
+			fmt.Fprintf(p.out, "%s (1-%d): ", prompt, len(choices))
+		}
+		answer, ok := p.read(prompt)
+		if !ok || answer == "" && defaultVal != "" {
+			return defaultVal
+		}
+		if c, ok := matchChoice(answer, choices); ok {
+			return c
+		}
+		fmt.Fprintln(p.out, "  (invalid choice)")
+	}
+}
+
+// generateGivenName renders the first free given name for id from tmpl,
+// checked against the holons under the working directory.
+func generateGivenName(tmpl *template.Template, id identity.Identity) (string, error) {
+	holons, err := identity.FindAll(".")
+	if err != nil {
+		return "", err
+	}
+	data := identity.NameTemplateData{FamilyName: id.FamilyName}
+	for _, parent := range id.Parents {
+		for _, h := range holons {
+			if h.UUID == parent {
+				data.Parents = append(data.Parents, h.GivenName)
+				break
+			}
+		}
+	}
+	return identity.GenerateGivenName(tmpl, data, holons)
+}
+
+// renderHolonMD renders id as opts ask, refusing custom template output
+// whose frontmatter no longer parses.
+func renderHolonMD(id identity.Identity, opts identity.RenderOptions) ([]byte, error) {
+	data, err := identity.RenderHolonMDWithOptions(id, opts)
+	if err != nil {
+		return nil, err
+	}
+	if opts.Template == "" {
+		return data, nil
+	}
+	if _, _, err := identity.ParseFrontmatter(data); err != nil {
+		return nil, fmt.Errorf("template output is not a valid HOLON.md: %w", err)
+	}
+	return data, nil
+}
+
+// matchChoice resolves answer, a 1-based menu index or an exact name,
+// against choices.
+func matchChoice(answer string, choices []string) (string, bool) {
+	for i, c := range choices {
+		if answer == strconv.Itoa(i+1) || answer == c {
+			return c, true
+		}
+	}
+	return "", false
+}
+
+// resolveParents expands each parent UUID or prefix to the full UUID of
+// a holon under root. Unknown parents are an error unless allowUnknown
+// is set, in which case they are kept as given. A parent listed twice,
+// directly or through two prefixes, is always an error.
+func resolveParents(root string, parents []string, allowUnknown bool) ([]string, error) {
+	var resolved []string
+	seen := make(map[string]bool, len(parents))
+	for _, parent := range parents {
+		parent = strings.TrimSpace(parent)
+		if parent == "" {
+			continue
+		}
+
+		uuid := parent
+		path, err := identity.FindByUUID(root, parent)
+		switch {
+		case err == nil:
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return nil, fmt.Errorf("cannot read parent %s: %w", parent, err)
+			}
+			id, _, err := identity.ParseFrontmatter(data)
+			if err != nil {
+				return nil, fmt.Errorf("parent %s: %w", parent, err)
 			}
+			uuid = id.UUID
+		case !allowUnknown:
+			return nil, fmt.Errorf("unknown parent %q (use --allow-unknown-parents to keep it anyway)", parent)
 		}
-		fmt.Println("  (invalid choice)")
+
+		if seen[uuid] {
+			return nil, fmt.Errorf("parent %q is listed more than once", uuid)
+		}
+		seen[uuid] = true
+		resolved = append(resolved, uuid)
+	}
+	return resolved, nil
+}
+
+// resolveChoice is matchChoice for flag values: an empty value resolves
+// to "" and anything unrecognized is an error naming the valid range.
+func resolveChoice(what, value string, choices []string) (string, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "", nil
+	}
+	c, ok := matchChoice(value, choices)
+	if !ok {
+		return "", fmt.Errorf("unknown %s %q (use 1-%d or one of: %s)", what, value, len(choices), strings.Join(choices, ", "))
 	}
+	return c, nil
 }
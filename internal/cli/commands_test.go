@@ -0,0 +1,844 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/organic-programming/sophia-who/pkg/identity"
+	"gopkg.in/yaml.v3"
+)
+
+const showFixture = `---
+uuid: "show-uuid-1234"
+given_name: "Show"
+family_name: "Tester"
+status: draft
+---
+
+# Show Tester
+
+> *"Print what is asked."*
+`
+
+func TestRenderShowDefault(t *testing.T) {
+	out, err := renderShow([]byte(showFixture), "", ShowOptions{})
+	if err != nil {
+		t.Fatalf("renderShow failed: %v", err)
+	}
+	if out != showFixture {
+		t.Errorf("default output = %q, want full file", out)
+	}
+}
+
+func TestRenderShowRawFrontmatter(t *testing.T) {
+	out, err := renderShow([]byte(showFixture), "", ShowOptions{RawFrontmatter: true})
+	if err != nil {
+		t.Fatalf("renderShow failed: %v", err)
+	}
+	want := "uuid: \"show-uuid-1234\"\ngiven_name: \"Show\"\nfamily_name: \"Tester\"\nstatus: draft"
+	if out != want {
+		t.Errorf("raw frontmatter = %q, want %q", out, want)
+	}
+}
+
+func TestRenderShowRawBody(t *testing.T) {
+	out, err := renderShow([]byte(showFixture), "", ShowOptions{RawBody: true})
+	if err != nil {
+		t.Fatalf("renderShow failed: %v", err)
+	}
+	want := "\n\n# Show Tester\n\n> *\"Print what is asked.\"*\n"
+	if out != want {
+		t.Errorf("raw body = %q, want %q", out, want)
+	}
+	if strings.Contains(out, "uuid:") {
+		t.Error("raw body must not contain frontmatter")
+	}
+}
+
+func TestRenderShowExclusiveFlags(t *testing.T) {
+	_, err := renderShow([]byte(showFixture), "", ShowOptions{RawBody: true, RawFrontmatter: true})
+	if err == nil {
+		t.Fatal("expected error when both raw flags are set")
+	}
+}
+
+func TestRenderShowHeader(t *testing.T) {
+	header := showHeader(".", filepath.Join("holons", "show-tester", "HOLON.md"))
+	if !strings.Contains(header, "holons/show-tester/HOLON.md") {
+		t.Fatalf("header %q does not contain the relative path", header)
+	}
+
+	out, err := renderShow([]byte(showFixture), header, ShowOptions{})
+	if err != nil {
+		t.Fatalf("renderShow failed: %v", err)
+	}
+	if !strings.HasPrefix(out, header+"\n") {
+		t.Errorf("output should start with the header, got %q", out)
+	}
+	if !strings.HasSuffix(out, showFixture) {
+		t.Error("output should end with the full file content")
+	}
+
+	out, err = renderShow([]byte(showFixture), header, ShowOptions{NoHeader: true})
+	if err != nil {
+		t.Fatalf("renderShow failed: %v", err)
+	}
+	if out != showFixture {
+		t.Errorf("--no-header output = %q, want the file only", out)
+	}
+
+	out, err = renderShow([]byte(showFixture), header, ShowOptions{RawFrontmatter: true})
+	if err != nil {
+		t.Fatalf("renderShow failed: %v", err)
+	}
+	if strings.Contains(out, header) {
+		t.Error("raw modes must not include the header")
+	}
+}
+
+func TestRenderShowYAMLReparses(t *testing.T) {
+	id := renameFixture()
+	id.Aliases = []string{"swift"}
+	path := seedIdentity(t, t.TempDir(), id)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := renderShow(data, "", ShowOptions{YAML: true})
+	if err != nil {
+		t.Fatalf("renderShow failed: %v", err)
+	}
+	if strings.Contains(out, "---") || strings.Contains(out, "# Holon Identity") {
+		t.Errorf("--yaml output should be bare normalized YAML, got:\n%s", out)
+	}
+
+	var reparsed identity.Identity
+	if err := yaml.Unmarshal([]byte(out), &reparsed); err != nil {
+		t.Fatalf("--yaml output does not reparse: %v", err)
+	}
+	if !reflect.DeepEqual(reparsed, id) {
+		t.Errorf("reparsed identity = %+v, want %+v", reparsed, id)
+	}
+}
+
+func TestRenderShowFieldsOnly(t *testing.T) {
+	id := renameFixture()
+	id.Aliases = []string{"swift", "quick"}
+	id.Motto = "It's fast."
+	path := seedIdentity(t, t.TempDir(), id)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := renderShow(data, "header", ShowOptions{FieldsOnly: true})
+	if err != nil {
+		t.Fatalf("renderShow failed: %v", err)
+	}
+	lines := strings.Split(out, "\n")
+	for _, want := range []string{
+		"uuid=" + id.UUID,
+		"given_name=Swift",
+		"aliases=swift,quick",
+		`motto='It'\''s fast.'`,
+	} {
+		if !slices.Contains(lines, want) {
+			t.Errorf("--fields-only output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "header") {
+		t.Errorf("--fields-only output includes the header:\n%s", out)
+	}
+}
+
+func TestRunShowIncludeSiblings(t *testing.T) {
+	root := t.TempDir()
+	parent := renameFixture()
+	parent.GivenName = "Elder"
+	first := renameFixture()
+	first.GivenName = "First"
+	first.Parents = []string{parent.UUID}
+	second := renameFixture()
+	second.GivenName = "Second"
+	second.Parents = []string{parent.UUID}
+	seedIdentityAt(t, filepath.Join(root, "elder"), parent)
+	seedIdentityAt(t, filepath.Join(root, "first"), first)
+	seedIdentityAt(t, filepath.Join(root, "second"), second)
+	t.Chdir(root)
+
+	var buf bytes.Buffer
+	if err := RunShowTo(&buf, io.Discard, first.UUID, ShowOptions{IncludeSiblings: true}); err != nil {
+		t.Fatalf("RunShowTo failed: %v", err)
+	}
+	out := buf.String()
+	_, siblings, ok := strings.Cut(out, "\nSiblings:\n")
+	if !ok {
+		t.Fatalf("output lacks a Siblings section:\n%s", out)
+	}
+	if !strings.Contains(siblings, identity.ShortUUID(second.UUID)) || !strings.Contains(siblings, "Second Prober") {
+		t.Errorf("siblings do not list Second:\n%s", siblings)
+	}
+	if strings.Contains(siblings, "First") || strings.Contains(siblings, "Elder") {
+		t.Errorf("siblings list the holon itself or its parent:\n%s", siblings)
+	}
+
+	out = captureStdout(t, func() {
+		if err := RunShow(parent.UUID, ShowOptions{IncludeSiblings: true}); err != nil {
+			t.Fatalf("RunShow failed: %v", err)
+		}
+	})
+	if !strings.Contains(out, "\nSiblings: none") {
+		t.Errorf("a holon without parents should have no siblings:\n%s", out)
+	}
+
+	if err := RunShow(first.UUID, ShowOptions{IncludeSiblings: true, YAML: true}); err == nil {
+		t.Error("--include-siblings with --yaml succeeded, want an error")
+	}
+}
+
+func TestRunShowVerify(t *testing.T) {
+	root := t.TempDir()
+	valid := renameFixture()
+	invalid := renameFixture()
+	invalid.UUID = "c3d4e5f6-0000-4000-8000-000000000051"
+	invalid.Composer = ""
+	seedIdentityAt(t, filepath.Join(root, "valid"), valid)
+	seedIdentityAt(t, filepath.Join(root, "invalid"), invalid)
+	t.Chdir(root)
+
+	var stdout, stderr bytes.Buffer
+	err := RunShowTo(&stdout, &stderr, invalid.UUID, ShowOptions{Verify: true})
+	if err == nil || !strings.Contains(err.Error(), "1 problem(s)") {
+		t.Fatalf("RunShowTo(invalid) = %v, want a failure counting the problem", err)
+	}
+	if !strings.Contains(stderr.String(), filepath.Join("invalid", "HOLON.md")+": ") || !strings.Contains(stderr.String(), "composer") {
+		t.Errorf("stderr = %q, want the composer problem", stderr.String())
+	}
+	if stdout.Len() != 0 {
+		t.Errorf("invalid holon printed:\n%s", stdout.String())
+	}
+
+	stdout.Reset()
+	stderr.Reset()
+	if err := RunShowTo(&stdout, &stderr, valid.UUID, ShowOptions{Verify: true}); err != nil {
+		t.Fatalf("RunShowTo(valid) = %v", err)
+	}
+	if !strings.Contains(stdout.String(), valid.UUID) || stderr.Len() != 0 {
+		t.Errorf("valid holon: stdout %q, stderr %q", stdout.String(), stderr.String())
+	}
+}
+
+func TestShellQuote(t *testing.T) {
+	tests := map[string]string{
+		"Swift":       "Swift",
+		"":            "",
+		"two words":   "'two words'",
+		"line\nbreak": `$'line\nbreak'`,
+		"it's\n":      `$'it\'s\n'`,
+	}
+	for in, want := range tests {
+		if got := shellQuote(in); got != want {
+			t.Errorf("shellQuote(%q) = %s, want %s", in, got, want)
+		}
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		in   string
+		max  int
+		want string
+	}{
+		{"short", 10, "short"},
+		{"exactly10!", 10, "exactly10!"},
+		{"one sentence too many", 10, "one sente…"},
+		{"héhéhéhéhé-hé", 5, "héhé…"},
+		{"line one\nline two", 40, "line one line two"},
+	}
+	for _, tt := range tests {
+		if got := truncate(tt.in, tt.max); got != tt.want {
+			t.Errorf("truncate(%q, %d) = %q, want %q", tt.in, tt.max, got, tt.want)
+		}
+	}
+}
+
+func TestResolveChoiceByIndexOrName(t *testing.T) {
+	got, err := resolveChoice("clade", "4", identity.Clades)
+	if err != nil || got != "probabilistic/generative" {
+		t.Errorf("resolveChoice(4) = %q, %v; want probabilistic/generative", got, err)
+	}
+
+	got, err = resolveChoice("reproduction mode", "autopoietic", identity.ReproductionModes)
+	if err != nil || got != "autopoietic" {
+		t.Errorf("resolveChoice(autopoietic) = %q, %v; want autopoietic", got, err)
+	}
+
+	if got, err := resolveChoice("clade", "", identity.Clades); err != nil || got != "" {
+		t.Errorf("resolveChoice(\"\") = %q, %v; want empty, nil", got, err)
+	}
+}
+
+func TestResolveChoiceRejectsInvalidIndex(t *testing.T) {
+	for _, value := range []string{"0", "7", "-1", "deterministic"} {
+		if _, err := resolveChoice("clade", value, identity.Clades); err == nil {
+			t.Errorf("resolveChoice(%q) succeeded, want error", value)
+		}
+	}
+}
+
+func TestRunDoctorFixIsIdempotent(t *testing.T) {
+	root := t.TempDir()
+	id := renameFixture()
+	id.Aliases = []string{"swift", "swift"}
+	path := seedIdentity(t, root, id)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, append([]byte("\xEF\xBB\xBF"), data...), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	opts := DoctorOptions{Fix: true}
+	out := captureStdout(t, func() {
+		if err := RunDoctor(root, opts); err != nil {
+			t.Fatalf("RunDoctor failed: %v", err)
+		}
+	})
+	if !strings.Contains(out, "removed byte order mark, removed duplicate aliases") {
+		t.Errorf("first run did not report fixes:\n%s", out)
+	}
+
+	fixed, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, _, err := identity.ParseFrontmatter(fixed)
+	if err != nil {
+		t.Fatalf("fixed file does not parse: %v", err)
+	}
+	if !reflect.DeepEqual([]string(got.Aliases), []string{"swift"}) {
+		t.Errorf("aliases = %q, want [swift]", got.Aliases)
+	}
+
+	out = captureStdout(t, func() {
+		if err := RunDoctor(root, opts); err != nil {
+			t.Fatalf("RunDoctor failed: %v", err)
+		}
+	})
+	if strings.Contains(out, "fixed") {
+		t.Errorf("second run changed files:\n%s", out)
+	}
+}
+
+func TestRunFmt(t *testing.T) {
+	root := t.TempDir()
+	path := seedIdentity(t, root, renameFixture())
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	unformatted := bytes.TrimRight(data, "\n")
+	if err := os.WriteFile(path, unformatted, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	out := captureStdout(t, func() {
+		if err := RunFmt(root, true); err == nil {
+			t.Error("RunFmt --check succeeded on a file without a trailing newline")
+		}
+	})
+	if !strings.Contains(out, "normalized trailing newline") {
+		t.Errorf("--check did not report the file:\n%s", out)
+	}
+	if got, _ := os.ReadFile(path); !bytes.Equal(got, unformatted) {
+		t.Error("--check rewrote the file")
+	}
+
+	captureStdout(t, func() {
+		if err := RunFmt(root, false); err != nil {
+			t.Fatalf("RunFmt failed: %v", err)
+		}
+	})
+	if got, _ := os.ReadFile(path); !bytes.HasSuffix(got, []byte("\n")) || bytes.HasSuffix(got, []byte("\n\n")) {
+		t.Errorf("formatted file does not end with exactly one newline: %q", got[max(0, len(got)-10):])
+	}
+	captureStdout(t, func() {
+		if err := RunFmt(root, true); err != nil {
+			t.Errorf("RunFmt --check after formatting: %v", err)
+		}
+	})
+}
+
+// feedStdin replaces os.Stdin with the given answers, one per line.
+func feedStdin(t *testing.T, answers ...string) {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.WriteString(strings.Join(answers, "\n") + "\n"); err != nil {
+		t.Fatal(err)
+	}
+	w.Close()
+
+	orig := os.Stdin
+	os.Stdin = r
+	t.Cleanup(func() {
+		os.Stdin = orig
+		r.Close()
+	})
+}
+
+func TestRunNewTruncatedInput(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Chdir(t.TempDir())
+	// family and given name only: stdin ends at the composer prompt.
+	feedStdin(t, "Transcriber", "Swift")
+
+	done := make(chan error, 1)
+	captureStdout(t, func() {
+		go func() { done <- RunNew(NewOptions{Clade: "1", Reproduction: "manual"}) }()
+		select {
+		case err := <-done:
+			if !errors.Is(err, io.ErrUnexpectedEOF) || !strings.Contains(err.Error(), `"Composer"`) {
+				t.Errorf("RunNew = %v, want an unexpected EOF at the composer prompt", err)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("RunNew did not return once stdin ended")
+		}
+	})
+	if entries, _ := os.ReadDir("."); len(entries) != 0 {
+		t.Errorf("RunNew left %d entries behind", len(entries))
+	}
+}
+
+func TestRunNewNoPrompts(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Chdir(t.TempDir())
+	feedStdin(t, "Transcriber", "Swift", "B. Alter", "Listen first.", "", "")
+
+	out := captureStdout(t, func() {
+		if err := RunNew(NewOptions{Clade: "1", Reproduction: "manual", OutputDir: "out", NoPrompts: true}); err != nil {
+			t.Fatalf("RunNew failed: %v", err)
+		}
+	})
+	if strings.Contains(out, "Family name") || !strings.Contains(out, "Born: Swift Transcriber") {
+		t.Errorf("output shows prompts or lacks the result:\n%s", out)
+	}
+}
+
+func TestRunNewOutputDir(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Chdir(t.TempDir())
+	// family, given, composer, motto, lang, aliases
+	feedStdin(t, "Transcriber", "Swift", "B. Alter", "Listen first.", "", "")
+
+	opts := NewOptions{Clade: "1", Reproduction: "manual", OutputDir: filepath.Join("custom", "place")}
+	captureStdout(t, func() {
+		if err := RunNew(opts); err != nil {
+			t.Fatalf("RunNew failed: %v", err)
+		}
+	})
+
+	data, err := os.ReadFile(filepath.Join("custom", "place", "HOLON.md"))
+	if err != nil {
+		t.Fatalf("HOLON.md not written to --output-dir: %v", err)
+	}
+	id, _, err := identity.ParseFrontmatter(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id.GivenName != "Swift" || id.Clade != "deterministic/pure" {
+		t.Errorf("written identity = %+v", id)
+	}
+}
+
+func TestRunNewPrint(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	dir := t.TempDir()
+	t.Chdir(dir)
+	feedStdin(t, "Transcriber", "Swift", "B. Alter", "Listen first.", "", "swift")
+
+	stderr := os.Stderr
+	devNull, err := os.Open(os.DevNull)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer devNull.Close()
+	os.Stderr = devNull
+	defer func() { os.Stderr = stderr }()
+
+	opts := NewOptions{Clade: "1", Reproduction: "manual", OutputDir: "out", Print: true}
+	out := captureStdout(t, func() {
+		if err := RunNew(opts); err != nil {
+			t.Fatalf("RunNew failed: %v", err)
+		}
+	})
+
+	id, _, err := identity.ParseFrontmatter([]byte(out))
+	if err != nil {
+		t.Fatalf("printed output does not parse: %v\n%s", err, out)
+	}
+	if id.GivenName != "Swift" || id.FamilyName != "Transcriber" || id.Clade != "deterministic/pure" ||
+		id.Reproduction != "manual" || !reflect.DeepEqual([]string(id.Aliases), []string{"swift"}) {
+		t.Errorf("printed identity = %+v", id)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("--print created files: %v", entries)
+	}
+}
+
+func TestRunNewNoBody(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Chdir(t.TempDir())
+	feedStdin(t, "Transcriber", "Swift", "B. Alter", "Listen first.", "", "")
+
+	opts := NewOptions{Clade: "1", Reproduction: "manual", OutputDir: "out", NoBody: true}
+	captureStdout(t, func() {
+		if err := RunNew(opts); err != nil {
+			t.Fatalf("RunNew failed: %v", err)
+		}
+	})
+
+	data, err := os.ReadFile(filepath.Join("out", "HOLON.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "## Description") {
+		t.Errorf("--no-body wrote the body scaffold:\n%s", data)
+	}
+	id, _, err := identity.ParseFrontmatter(data)
+	if err != nil {
+		t.Fatalf("minimal HOLON.md does not parse: %v\n%s", err, data)
+	}
+	if id.GivenName != "Swift" || id.FamilyName != "Transcriber" {
+		t.Errorf("parsed identity = %+v", id)
+	}
+
+	opts.Template = "custom.tmpl"
+	if err := RunNew(opts); err == nil || !strings.Contains(err.Error(), "--template") {
+		t.Errorf("RunNew with --no-body and --template: err = %v, want a conflict", err)
+	}
+}
+
+func TestRunNewSortLists(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Chdir(t.TempDir())
+	if err := os.WriteFile(identity.ConfigFileName, []byte("sort_lists: true\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	feedStdin(t, "Transcriber", "Swift", "B. Alter", "Listen first.", "", "zeta, alpha")
+
+	opts := NewOptions{Clade: "1", Reproduction: "manual", OutputDir: "out", NoBody: true}
+	captureStdout(t, func() {
+		if err := RunNew(opts); err != nil {
+			t.Fatalf("RunNew failed: %v", err)
+		}
+	})
+
+	data, err := os.ReadFile(filepath.Join("out", "HOLON.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	id, _, err := identity.ParseFrontmatter(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"alpha", "zeta"}; !reflect.DeepEqual([]string(id.Aliases), want) {
+		t.Errorf("aliases = %q, want %q with sort_lists set in .holonrc", id.Aliases, want)
+	}
+}
+
+func TestRunNewGenerator(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Chdir(t.TempDir())
+	if err := os.WriteFile(identity.ConfigFileName, []byte("id_scheme: ulid\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	feedStdin(t, "Seeded", "Fixture", "B. Alter", "Same every time.", "", "")
+
+	opts := NewOptions{Clade: "1", Reproduction: "manual", OutputDir: "out", NoBody: true, Generator: identity.NewSeededGenerator(42)}
+	captureStdout(t, func() {
+		if err := RunNew(opts); err != nil {
+			t.Fatalf("RunNew failed: %v", err)
+		}
+	})
+
+	data, err := os.ReadFile(filepath.Join("out", "HOLON.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	id, _, err := identity.ParseFrontmatter(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := identity.NewSeededGenerator(42).NewID(); id.UUID != want {
+		t.Errorf("uuid = %q, want %q from the generator over id_scheme", id.UUID, want)
+	}
+}
+
+func TestRunNewStrictSameNames(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Chdir(t.TempDir())
+	feedStdin(t, "Echo", "Echo", "B. Alter", "Repeat.", "", "")
+
+	opts := NewOptions{Clade: "1", Reproduction: "manual", OutputDir: "echo", Strict: true}
+	captureStdout(t, func() {
+		if err := RunNew(opts); err == nil || !strings.Contains(err.Error(), "same as given_name") {
+			t.Errorf("RunNew --strict with identical names: err = %v, want a refusal", err)
+		}
+	})
+	if _, err := os.Stat("echo"); !os.IsNotExist(err) {
+		t.Errorf("refused holon was written: %v", err)
+	}
+}
+
+func TestRunNewNameTemplate(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Chdir(t.TempDir())
+
+	opts := NewOptions{Clade: "1", Reproduction: "manual", NameTemplate: `Holon-{{printf "%04d" .Counter}}`}
+	for range 3 {
+		// family, given (empty: generated), composer, motto, lang, aliases, output dir
+		feedStdin(t, "Worker", "", "B. Alter", "Work.", "", "", "")
+		captureStdout(t, func() {
+			if err := RunNew(opts); err != nil {
+				t.Fatalf("RunNew failed: %v", err)
+			}
+		})
+	}
+
+	holons, err := identity.FindAll(".")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, h := range holons {
+		names = append(names, h.GivenName)
+	}
+	sort.Strings(names)
+	if want := []string{"Holon-0001", "Holon-0002", "Holon-0003"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("given names = %v, want %v", names, want)
+	}
+}
+
+func TestRunNewRejectsEscapingOutputDir(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Chdir(t.TempDir())
+	feedStdin(t, "Transcriber", "Swift", "B. Alter", "Listen first.", "", "")
+
+	opts := NewOptions{Clade: "1", Reproduction: "1", OutputDir: filepath.Join("..", "outside")}
+	captureStdout(t, func() {
+		if err := RunNew(opts); err == nil {
+			t.Fatal("RunNew accepted an output dir outside the working directory")
+		}
+	})
+}
+
+func TestRunNewParents(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Chdir(t.TempDir())
+
+	mother := renameFixture()
+	father := renameFixture()
+	father.UUID = "c3d4e5f6-0000-4000-8000-000000000005"
+	seedIdentityAt(t, filepath.Join("holons", "mother"), mother)
+	seedIdentityAt(t, filepath.Join("holons", "father"), father)
+
+	feedStdin(t, "Transcriber", "Young", "B. Alter", "Listen first.", "", "")
+	opts := NewOptions{
+		Clade:     "1",
+		Parents:   []string{mother.UUID, father.UUID[:13]},
+		OutputDir: filepath.Join("holons", "child"),
+	}
+	captureStdout(t, func() {
+		if err := RunNew(opts); err != nil {
+			t.Fatalf("RunNew failed: %v", err)
+		}
+	})
+
+	data, err := os.ReadFile(filepath.Join("holons", "child", "HOLON.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	id, _, err := identity.ParseFrontmatter(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual([]string(id.Parents), []string{mother.UUID, father.UUID}) {
+		t.Errorf("parents = %q, want both full UUIDs", id.Parents)
+	}
+	if id.Reproduction != "bred" {
+		t.Errorf("reproduction = %q, want bred", id.Reproduction)
+	}
+}
+
+func TestRunNewTrackChildren(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Chdir(t.TempDir())
+
+	parent := renameFixture()
+	seedIdentityAt(t, filepath.Join("holons", "parent"), parent)
+
+	var children []string
+	for _, dir := range []string{"first", "second"} {
+		feedStdin(t, "Transcriber", "Young", "B. Alter", "Listen first.", "", "")
+		opts := NewOptions{Clade: "1", Parents: []string{parent.UUID}, OutputDir: filepath.Join("holons", dir), TrackChildren: true}
+		captureStdout(t, func() {
+			if err := RunNew(opts); err != nil {
+				t.Fatalf("RunNew failed: %v", err)
+			}
+		})
+		children = append(children, readStatus(t, filepath.Join("holons", dir, "HOLON.md")).UUID)
+	}
+
+	got := readStatus(t, filepath.Join("holons", "parent", "HOLON.md"))
+	if !reflect.DeepEqual([]string(got.Children), children) {
+		t.Errorf("parent children = %q, want %q", got.Children, children)
+	}
+}
+
+func TestRunNewUnknownParents(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Chdir(t.TempDir())
+
+	opts := NewOptions{Clade: "1", Parents: []string{"no-such-parent"}, OutputDir: "child"}
+	if err := RunNew(opts); err == nil || !strings.Contains(err.Error(), "--allow-unknown-parents") {
+		t.Fatalf("RunNew error = %v, want an unknown parent error", err)
+	}
+
+	feedStdin(t, "Transcriber", "Young", "B. Alter", "Listen first.", "", "")
+	opts.AllowUnknownParents = true
+	captureStdout(t, func() {
+		if err := RunNew(opts); err != nil {
+			t.Fatalf("RunNew with --allow-unknown-parents failed: %v", err)
+		}
+	})
+	data, err := os.ReadFile(filepath.Join("child", "HOLON.md"))
+	if err != nil || !strings.Contains(string(data), "no-such-parent") {
+		t.Errorf("unknown parent not written: %v\n%s", err, data)
+	}
+}
+
+func TestRunNewPreWriteHook(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Chdir(t.TempDir())
+
+	var seen []byte
+	orig := hookRunner
+	hookRunner = func(command string, stdin []byte) ([]byte, error) {
+		seen = stdin
+		if command == "deny" {
+			return []byte("family name is not allowed"), errors.New("exit status 1")
+		}
+		return nil, nil
+	}
+	t.Cleanup(func() { hookRunner = orig })
+
+	opts := NewOptions{Clade: "1", Reproduction: "manual", OutputDir: "denied", PreWriteHook: "deny"}
+	feedStdin(t, "Transcriber", "Swift", "B. Alter", "Listen first.", "", "")
+	captureStdout(t, func() {
+		err := RunNew(opts)
+		if err == nil || !strings.Contains(err.Error(), "family name is not allowed") {
+			t.Errorf("RunNew error = %v, want the hook's rejection", err)
+		}
+	})
+	if _, err := os.Stat("denied"); !os.IsNotExist(err) {
+		t.Errorf("rejected holon left its directory behind: %v", err)
+	}
+	if !strings.Contains(string(seen), `given_name: "Swift"`) {
+		t.Errorf("hook stdin is not the rendered HOLON.md:\n%s", seen)
+	}
+
+	opts.OutputDir, opts.PreWriteHook = "allowed", "allow"
+	feedStdin(t, "Transcriber", "Swift", "B. Alter", "Listen first.", "", "")
+	captureStdout(t, func() {
+		if err := RunNew(opts); err != nil {
+			t.Fatalf("RunNew with a passing hook failed: %v", err)
+		}
+	})
+	data, err := os.ReadFile(filepath.Join("allowed", "HOLON.md"))
+	if err != nil || !bytes.Equal(data, seen) {
+		t.Errorf("written file differs from what the hook approved (err %v)", err)
+	}
+}
+
+func TestRunDoctorJSON(t *testing.T) {
+	root := t.TempDir()
+	id := renameFixture()
+	seedIdentityAt(t, filepath.Join(root, "holons", "a"), id)
+	seedIdentityAt(t, filepath.Join(root, "holons", "b"), id)
+
+	var err error
+	out := captureStdout(t, func() {
+		err = RunDoctor(root, DoctorOptions{Lint: identity.DefaultLintOptions(), Format: DoctorFormatJSON})
+	})
+	if err == nil {
+		t.Error("RunDoctor succeeded despite a duplicate UUID")
+	}
+
+	var findings []identity.Finding
+	if err := json.Unmarshal([]byte(out), &findings); err != nil {
+		t.Fatalf("output is not a JSON array of findings: %v\n%s", err, out)
+	}
+	for _, f := range findings {
+		if f.Rule == "duplicate-uuid" && f.Severity == identity.SeverityError && f.UUID == id.UUID {
+			if want := filepath.Join("holons", "b"); f.Path != want {
+				t.Errorf("duplicate path = %q, want %q", f.Path, want)
+			}
+			return
+		}
+	}
+	t.Errorf("no duplicate-uuid finding in:\n%s", out)
+}
+
+func TestRunDoctorTextExitsZeroOnErrors(t *testing.T) {
+	root := t.TempDir()
+	id := renameFixture()
+	seedIdentityAt(t, filepath.Join(root, "holons", "a"), id)
+	seedIdentityAt(t, filepath.Join(root, "holons", "b"), id)
+
+	var err error
+	out := captureStdout(t, func() {
+		err = RunDoctor(root, DoctorOptions{Lint: identity.DefaultLintOptions()})
+	})
+	if err != nil {
+		t.Errorf("text report failed: %v", err)
+	}
+	if !strings.Contains(out, "error [duplicate-uuid]") {
+		t.Errorf("duplicate UUID not reported:\n%s", out)
+	}
+}
+
+func TestProgressLineOffTerminal(t *testing.T) {
+	var logged, quiet bytes.Buffer
+	for _, p := range []*progressLine{newProgressLine(&logged, true), newProgressLine(&quiet, false)} {
+		p.show("local", 500)
+		p.show("local", 1000)
+		p.clear()
+	}
+	if want := "[scan] local: 500 files scanned\n[scan] local: 1000 files scanned\n"; logged.String() != want {
+		t.Errorf("logged progress = %q, want %q", logged.String(), want)
+	}
+	if quiet.Len() != 0 {
+		t.Errorf("unlogged progress wrote %q off a terminal", quiet.String())
+	}
+}
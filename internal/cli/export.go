@@ -0,0 +1,26 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/organic-programming/sophia-who/pkg/identity"
+)
+
+// RunExportMarkdown writes the Markdown catalog of the holons under root
+// (see identity.CatalogMarkdown) to path, or to stdout when path is "-".
+func RunExportMarkdown(root, path string) error {
+	md, err := identity.CatalogMarkdown(root)
+	if err != nil {
+		return err
+	}
+	if path == "-" {
+		fmt.Print(md)
+		return nil
+	}
+	if err := os.WriteFile(path, []byte(md), 0644); err != nil {
+		return fmt.Errorf("write catalog: %w", err)
+	}
+	fmt.Printf("Wrote %s\n", path)
+	return nil
+}
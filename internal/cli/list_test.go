@@ -0,0 +1,714 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/organic-programming/sophia-who/pkg/identity"
+)
+
+// captureStdout runs fn and returns everything it wrote to os.Stdout.
+func captureStdout(t *testing.T, fn func()) string {
+	t.Helper()
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	original := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = original }()
+
+	done := make(chan string)
+	go func() {
+		data, _ := io.ReadAll(r)
+		done <- string(data)
+	}()
+
+	fn()
+	w.Close()
+	return <-done
+}
+
+func TestRunListJSONL(t *testing.T) {
+	t.Setenv("OPPATH", t.TempDir())
+	root := t.TempDir()
+
+	a := renameFixture()
+	b := renameFixture()
+	b.GivenName = "Deep"
+	b.Aliases = []string{"deep"}
+	seedIdentity(t, root, a)
+	seedIdentity(t, root, b)
+
+	out := captureStdout(t, func() {
+		if err := RunList(root, ListOptions{JSONL: true}); err != nil {
+			t.Fatalf("RunList failed: %v", err)
+		}
+	})
+
+	lines := strings.Split(strings.TrimSuffix(out, "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines, want 2:\n%s", len(lines), out)
+	}
+
+	uuids := map[string]bool{}
+	for _, line := range lines {
+		var entry struct {
+			identity.Identity
+			Origin string `json:"origin"`
+			Path   string `json:"path"`
+		}
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("line %q is not valid JSON: %v", line, err)
+		}
+		if entry.Origin != "local" {
+			t.Errorf("origin = %q, want local", entry.Origin)
+		}
+		if !strings.HasPrefix(entry.Path, "holons/") {
+			t.Errorf("path = %q, want a holons/ relative path", entry.Path)
+		}
+		uuids[entry.UUID] = true
+	}
+	if !uuids[a.UUID] || !uuids[b.UUID] {
+		t.Errorf("JSON Lines output missing seeded holons: %v", uuids)
+	}
+}
+
+func TestRunListJSONLEmpty(t *testing.T) {
+	t.Setenv("OPPATH", t.TempDir())
+
+	out := captureStdout(t, func() {
+		if err := RunList(t.TempDir(), ListOptions{JSONL: true}); err != nil {
+			t.Fatalf("RunList failed: %v", err)
+		}
+	})
+	if out != "" {
+		t.Errorf("stdout = %q, want nothing for an empty tree", out)
+	}
+}
+
+func TestRunListFileRoot(t *testing.T) {
+	t.Setenv("OPPATH", t.TempDir())
+	root := t.TempDir()
+	swift := renameFixture()
+	deep := renameFixture()
+	deep.UUID = "c3d4e5f6-0000-4000-8000-000000000005"
+	deep.GivenName = "Deep"
+	seedIdentityAt(t, filepath.Join(root, "holons", "swift"), swift)
+	seedIdentityAt(t, filepath.Join(root, "holons", "deep"), deep)
+
+	out := captureStdout(t, func() {
+		if err := RunList(filepath.Join(root, "holons", "swift", "HOLON.md"), ListOptions{JSONL: true}); err != nil {
+			t.Fatalf("RunList(HOLON.md) failed: %v", err)
+		}
+	})
+	var entry struct {
+		identity.Identity
+		Path string `json:"path"`
+	}
+	if err := json.Unmarshal([]byte(out), &entry); err != nil {
+		t.Fatalf("output %q is not one JSON line: %v", out, err)
+	}
+	if entry.UUID != swift.UUID || entry.Path != "." {
+		t.Errorf("listed %s at %q, want only %s at .", entry.UUID, entry.Path, swift.UUID)
+	}
+
+	notes := filepath.Join(root, "notes.txt")
+	if err := os.WriteFile(notes, []byte("not a holon\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	err := RunList(notes, ListOptions{})
+	if !errors.Is(err, identity.ErrNotHolonFile) {
+		t.Errorf("RunList(notes.txt) = %v, want ErrNotHolonFile", err)
+	}
+}
+
+func TestRunListTo(t *testing.T) {
+	t.Setenv("OPPATH", t.TempDir())
+	root := t.TempDir()
+	id := renameFixture()
+	seedIdentity(t, root, id)
+
+	var stdout, stderr bytes.Buffer
+	if err := RunListTo(&stdout, &stderr, root, ListOptions{}); err != nil {
+		t.Fatalf("RunListTo failed: %v", err)
+	}
+	lines := strings.Split(strings.TrimSuffix(stdout.String(), "\n"), "\n")
+	if len(lines) != 3 || !strings.HasPrefix(lines[0], "UUID") || !strings.HasPrefix(lines[1], "─") {
+		t.Fatalf("table:\n%s\nwant a header, a rule, and one holon", stdout.String())
+	}
+	for _, want := range []string{identity.ShortUUID(id.UUID), "Swift Prober", "local", "deterministic/pure", filepath.Join("holons", "swift-prober")} {
+		if !strings.Contains(lines[2], want) {
+			t.Errorf("row %q lacks %q", lines[2], want)
+		}
+	}
+	// Progress goes to stderr, and a buffer is not a terminal.
+	if strings.Contains(stdout.String(), "[scan]") || !strings.Contains(stderr.String(), "[scan] local") {
+		t.Errorf("stderr = %q, want the scan progress there only", stderr.String())
+	}
+
+	// JSON Lines keep the footer off stdout.
+	second := renameFixture()
+	second.UUID = "c3d4e5f6-0000-4000-8000-000000000021"
+	seedIdentityAt(t, filepath.Join(root, "holons", "second"), second)
+	stdout.Reset()
+	stderr.Reset()
+	if err := RunListTo(&stdout, &stderr, root, ListOptions{JSONL: true, Limit: 1}); err != nil {
+		t.Fatalf("RunListTo failed: %v", err)
+	}
+	if n := strings.Count(stdout.String(), "\n"); n != 1 || strings.Contains(stdout.String(), "more") {
+		t.Errorf("stdout = %q, want one JSON line", stdout.String())
+	}
+	if !strings.Contains(stderr.String(), "... 1 more") {
+		t.Errorf("stderr = %q, want the footer", stderr.String())
+	}
+}
+
+func TestRunListOnlyUUID(t *testing.T) {
+	t.Setenv("OPPATH", t.TempDir())
+	root := t.TempDir()
+	var want []string
+	for i, dir := range []string{"a", "b", "c"} {
+		id := renameFixture()
+		id.UUID = fmt.Sprintf("c3d4e5f6-0000-4000-8000-00000000003%d", i)
+		id.Tags = []string{"probe"}
+		if dir == "b" {
+			id.Tags = nil
+		} else {
+			want = append(want, id.UUID)
+		}
+		seedIdentityAt(t, filepath.Join(root, "holons", dir), id)
+	}
+
+	var stdout, stderr bytes.Buffer
+	if err := RunListTo(&stdout, &stderr, root, ListOptions{OnlyUUID: true, Tags: []string{"probe"}}); err != nil {
+		t.Fatalf("RunListTo failed: %v", err)
+	}
+	if got := stdout.String(); got != strings.Join(want, "\n")+"\n" {
+		t.Errorf("--only-uuid output:\n%s\nwant exactly:\n%s", got, strings.Join(want, "\n"))
+	}
+	if stderr.Len() != 0 {
+		t.Errorf("--only-uuid wrote to stderr: %q", stderr.String())
+	}
+
+	stdout.Reset()
+	if err := RunListTo(&stdout, &stderr, root, ListOptions{OnlyUUID: true, Tags: []string{"none"}}); err != nil {
+		t.Fatalf("RunListTo failed: %v", err)
+	}
+	if stdout.Len() != 0 {
+		t.Errorf("--only-uuid without matches printed %q, want nothing", stdout.String())
+	}
+
+	if err := RunList(root, ListOptions{OnlyUUID: true, JSONL: true}); err == nil {
+		t.Error("--only-uuid with --jsonl succeeded, want an error")
+	}
+}
+
+func TestRunListSymlinks(t *testing.T) {
+	t.Setenv("OPPATH", t.TempDir())
+	root := t.TempDir()
+	seedIdentity(t, root, renameFixture())
+	linked := renameFixture()
+	linked.UUID = "c3d4e5f6-0000-4000-8000-000000000041"
+	target := seedIdentityAt(t, t.TempDir(), linked)
+	link := filepath.Join(root, "holons", "linked", "HOLON.md")
+	if err := os.MkdirAll(filepath.Dir(link), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(target, link); err != nil {
+		t.Skipf("symlinks unsupported: %v", err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	if err := RunListTo(&stdout, &stderr, root, ListOptions{OnlyUUID: true}); err != nil {
+		t.Fatalf("RunListTo failed: %v", err)
+	}
+	if !strings.Contains(stdout.String(), linked.UUID) || !strings.Contains(stderr.String(), "warning: "+link) {
+		t.Errorf("follow: stdout %q, stderr %q, want the linked holon and a warning", stdout.String(), stderr.String())
+	}
+
+	err := RunListTo(io.Discard, io.Discard, root, ListOptions{Symlinks: identity.SymlinkReject})
+	if !errors.Is(err, identity.ErrSymlink) {
+		t.Errorf("reject: RunListTo = %v, want ErrSymlink", err)
+	}
+}
+
+func TestRunListLongTruncatesMotto(t *testing.T) {
+	t.Setenv("OPPATH", t.TempDir())
+	root := t.TempDir()
+	id := renameFixture()
+	id.Motto = strings.Repeat("ω", mottoWidth*2)
+	seedIdentity(t, root, id)
+
+	out := captureStdout(t, func() {
+		if err := RunList(root, ListOptions{Long: true}); err != nil {
+			t.Fatalf("RunList failed: %v", err)
+		}
+	})
+
+	want := strings.Repeat("ω", mottoWidth-1) + "…"
+	if !strings.Contains(out, want) {
+		t.Errorf("list --long output missing truncated motto:\n%s", out)
+	}
+	if strings.Contains(out, strings.Repeat("ω", mottoWidth)) {
+		t.Errorf("motto was not truncated:\n%s", out)
+	}
+}
+
+func TestRunListShortUUIDs(t *testing.T) {
+	t.Setenv("OPPATH", t.TempDir())
+	root := t.TempDir()
+	id := renameFixture()
+	seedIdentity(t, root, id)
+
+	row := func(opts ListOptions) string {
+		out := captureStdout(t, func() {
+			if err := RunList(root, opts); err != nil {
+				t.Fatalf("RunList failed: %v", err)
+			}
+		})
+		lines := strings.Split(strings.TrimSpace(out), "\n")
+		return lines[len(lines)-1]
+	}
+
+	short := strings.Fields(row(ListOptions{}))[0]
+	if len(short) != 8 || !strings.HasPrefix(id.UUID, short) {
+		t.Errorf("default list shows UUID %q, want the 8-character prefix of %s", short, id.UUID)
+	}
+	if full := strings.Fields(row(ListOptions{FullUUID: true}))[0]; full != id.UUID {
+		t.Errorf("list --full-uuid shows UUID %q, want %s", full, id.UUID)
+	}
+
+	// The short form is what users copy into who show.
+	t.Chdir(root)
+	if out := captureStdout(t, func() {
+		if err := RunShow(short, ShowOptions{FieldsOnly: true}); err != nil {
+			t.Fatalf("RunShow(%q) failed: %v", short, err)
+		}
+	}); !strings.Contains(out, id.UUID) {
+		t.Errorf("who show %s printed:\n%s", short, out)
+	}
+}
+
+func TestHolonCacheDirUsesXDGCacheHome(t *testing.T) {
+	home := t.TempDir()
+	xdg := t.TempDir()
+	t.Setenv("OPPATH", "")
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CACHE_HOME", xdg)
+
+	if got, want := holonCacheDir(), filepath.Join(xdg, "op"); got != want {
+		t.Errorf("holonCacheDir() = %q, want %q", got, want)
+	}
+
+	// An existing legacy cache keeps being used.
+	legacy := filepath.Join(home, ".op", "cache")
+	if err := os.MkdirAll(legacy, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if got := holonCacheDir(); got != legacy {
+		t.Errorf("holonCacheDir() = %q, want legacy %q", got, legacy)
+	}
+
+	t.Setenv("OPPATH", filepath.Join(home, "op"))
+	if got, want := holonCacheDir(), filepath.Join(home, "op", "cache"); got != want {
+		t.Errorf("holonCacheDir() = %q, want %q", got, want)
+	}
+}
+
+func TestRunListDedupeContent(t *testing.T) {
+	t.Setenv("OPPATH", t.TempDir())
+	root := t.TempDir()
+
+	original := renameFixture()
+	seedIdentity(t, root, original)
+
+	// A bad clone: same frontmatter under another directory and UUID.
+	clone := original
+	clone.UUID = "a1b2c3d4-0000-4000-8000-000000000002"
+	clonePath := filepath.Join(root, "holons", "copy", "HOLON.md")
+	if err := os.MkdirAll(filepath.Dir(clonePath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := identity.WriteHolonMD(clone, clonePath); err != nil {
+		t.Fatal(err)
+	}
+
+	list := func(opts ListOptions) []string {
+		opts.JSONL = true
+		out := captureStdout(t, func() {
+			if err := RunList(root, opts); err != nil {
+				t.Fatalf("RunList failed: %v", err)
+			}
+		})
+		return strings.Split(strings.TrimSuffix(out, "\n"), "\n")
+	}
+
+	if lines := list(ListOptions{}); len(lines) != 2 {
+		t.Fatalf("default dedupe listed %d holons, want 2", len(lines))
+	}
+	if lines := list(ListOptions{Dedupe: DedupeContent}); len(lines) != 1 {
+		t.Fatalf("content dedupe listed %d holons, want 1:\n%s", len(lines), strings.Join(lines, "\n"))
+	}
+}
+
+func TestRunListRejectsUnknownDedupe(t *testing.T) {
+	if err := RunList(t.TempDir(), ListOptions{Dedupe: "hash"}); err == nil {
+		t.Fatal("RunList accepted an unknown dedupe mode")
+	}
+}
+
+func TestRunListFields(t *testing.T) {
+	t.Setenv("OPPATH", t.TempDir())
+	root := t.TempDir()
+	id := renameFixture()
+	seedIdentity(t, root, id)
+
+	fields, err := ParseListFields("uuid, motto")
+	if err != nil {
+		t.Fatalf("ParseListFields failed: %v", err)
+	}
+	out := captureStdout(t, func() {
+		if err := RunList(root, ListOptions{Fields: fields}); err != nil {
+			t.Fatalf("RunList failed: %v", err)
+		}
+	})
+
+	lines := strings.Split(strings.TrimSuffix(out, "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines, want header and one row:\n%s", len(lines), out)
+	}
+	if got := strings.Fields(lines[0]); !reflect.DeepEqual(got, []string{"UUID", "MOTTO"}) {
+		t.Errorf("header = %q, want [UUID MOTTO]", got)
+	}
+	if want := id.UUID + "  " + id.Motto; lines[1] != want {
+		t.Errorf("row = %q, want %q", lines[1], want)
+	}
+}
+
+func TestParseListFieldsRejectsUnknown(t *testing.T) {
+	if _, err := ParseListFields("uuid,colour"); err == nil || !strings.Contains(err.Error(), "colour") {
+		t.Errorf("ParseListFields error = %v, want one naming the unknown field", err)
+	}
+}
+
+func TestRunListTree(t *testing.T) {
+	t.Setenv("OPPATH", t.TempDir())
+	root := t.TempDir()
+
+	a := renameFixture()
+	b := renameFixture()
+	b.UUID = "c3d4e5f6-0000-4000-8000-000000000004"
+	b.GivenName = "Deep"
+	seedIdentityAt(t, filepath.Join(root, "holons", "a"), a)
+	seedIdentityAt(t, filepath.Join(root, "holons", "b"), b)
+
+	out := captureStdout(t, func() {
+		if err := RunList(root, ListOptions{Tree: true}); err != nil {
+			t.Fatalf("RunList failed: %v", err)
+		}
+	})
+
+	want := "holons/\n" +
+		"  a — Swift Prober (" + a.UUID + ")\n" +
+		"  b — Deep Prober (" + b.UUID + ")\n"
+	if out != want {
+		t.Errorf("tree output:\n%s\nwant:\n%s", out, want)
+	}
+}
+
+func TestRunListPathsByOrigin(t *testing.T) {
+	opPath := t.TempDir()
+	t.Setenv("OPPATH", opPath)
+	root := t.TempDir()
+
+	local := renameFixture()
+	cached := renameFixture()
+	cached.UUID = "c3d4e5f6-0000-4000-8000-000000000007"
+	cached.GivenName = "Remote"
+	seedIdentityAt(t, filepath.Join(root, "holons", "swift"), local)
+	seedIdentityAt(t, filepath.Join(opPath, "cache", "remote"), cached)
+
+	out := captureStdout(t, func() {
+		if err := RunList(root, ListOptions{JSONL: true}); err != nil {
+			t.Fatalf("RunList failed: %v", err)
+		}
+	})
+
+	paths := map[string]string{}
+	for _, line := range strings.Split(strings.TrimSuffix(out, "\n"), "\n") {
+		var entry struct {
+			Origin string `json:"origin"`
+			Path   string `json:"path"`
+		}
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("line %q is not valid JSON: %v", line, err)
+		}
+		paths[entry.Origin] = entry.Path
+	}
+
+	if want := filepath.Join("holons", "swift"); paths["local"] != want {
+		t.Errorf("local path = %q, want %q", paths["local"], want)
+	}
+	if want := filepath.Join(opPath, "cache", "remote"); paths["cached"] != want {
+		t.Errorf("cached path = %q, want %q", paths["cached"], want)
+	}
+}
+
+func TestRelHolonDirOutsideRoot(t *testing.T) {
+	root := t.TempDir()
+	if got := relHolonDir(root, filepath.Join(root, "HOLON.md")); got != "." {
+		t.Errorf("holon at root = %q, want .", got)
+	}
+	outside := filepath.Join(filepath.Dir(root), "elsewhere", "HOLON.md")
+	if got := relHolonDir(root, outside); got != filepath.Dir(outside) {
+		t.Errorf("holon outside root = %q, want %q", got, filepath.Dir(outside))
+	}
+}
+
+// listGitRoot returns an empty root for a git repository, isolated from
+// the user's git configuration and holon cache, and a func running git
+// in it. The repository itself is left to the caller to init.
+func listGitRoot(t *testing.T) (string, func(args ...string)) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	t.Setenv("OPPATH", t.TempDir())
+	t.Setenv("GIT_CONFIG_GLOBAL", os.DevNull)
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+	root := t.TempDir()
+	return root, func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = root
+		cmd.Env = append(os.Environ(), "GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+			"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com")
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+}
+
+func TestRunListGitRef(t *testing.T) {
+	root, git := listGitRoot(t)
+
+	id := renameFixture()
+	seedIdentityAt(t, filepath.Join(root, "holons", "swift"), id)
+	git("init", "-q", "-b", "main")
+	git("add", ".")
+	git("commit", "-q", "-m", "add swift")
+	git("checkout", "-q", "-b", "feature")
+	if err := os.RemoveAll(filepath.Join(root, "holons", "swift")); err != nil {
+		t.Fatal(err)
+	}
+
+	out := captureStdout(t, func() {
+		if err := RunList(root, ListOptions{JSONL: true, GitRef: "main"}); err != nil {
+			t.Fatalf("RunList failed: %v", err)
+		}
+	})
+
+	var entry struct {
+		identity.Identity
+		Origin string `json:"origin"`
+		Path   string `json:"path"`
+	}
+	if err := json.Unmarshal([]byte(out), &entry); err != nil {
+		t.Fatalf("output %q is not one JSON line: %v", out, err)
+	}
+	if entry.UUID != id.UUID || entry.Origin != "git" {
+		t.Errorf("entry = %s (%s), want %s from git", entry.UUID, entry.Origin, id.UUID)
+	}
+	if want := filepath.Join("holons", "swift"); entry.Path != want {
+		t.Errorf("path = %q, want %q", entry.Path, want)
+	}
+}
+
+func TestRunListChangedSince(t *testing.T) {
+	root, git := listGitRoot(t)
+
+	swift := renameFixture()
+	deep := renameFixture()
+	deep.UUID = "c3d4e5f6-0000-4000-8000-000000000005"
+	deep.GivenName = "Deep"
+	seedIdentityAt(t, filepath.Join(root, "holons", "swift"), swift)
+	seedIdentityAt(t, filepath.Join(root, "holons", "deep"), deep)
+	git("init", "-q", "-b", "main")
+	git("add", ".")
+	git("commit", "-q", "-m", "add holons")
+
+	edited := deep
+	edited.Motto = "Edited since main."
+	seedIdentityAt(t, filepath.Join(root, "holons", "deep"), edited)
+
+	list := func(opts ListOptions) []identity.Identity {
+		t.Helper()
+		opts.JSONL = true
+		out := captureStdout(t, func() {
+			if err := RunList(root, opts); err != nil {
+				t.Fatalf("RunList(%+v) failed: %v", opts, err)
+			}
+		})
+		var ids []identity.Identity
+		for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+			var id identity.Identity
+			if err := json.Unmarshal([]byte(line), &id); err != nil {
+				t.Fatalf("output line %q is not JSON: %v", line, err)
+			}
+			ids = append(ids, id)
+		}
+		return ids
+	}
+
+	after := list(ListOptions{ChangedSince: "main"})
+	if len(after) != 1 || after[0].UUID != deep.UUID || after[0].Motto != edited.Motto {
+		t.Errorf("--changed-since main listed %+v, want only the edited holon", after)
+	}
+	before := list(ListOptions{ChangedSince: "main", GitRef: "main"})
+	if len(before) != 1 || before[0].UUID != deep.UUID || before[0].Motto != deep.Motto {
+		t.Errorf("--changed-since main --git-ref main listed %+v, want the holon as on main", before)
+	}
+
+	if err := RunList(root, ListOptions{ChangedSince: "no-such-ref"}); err == nil {
+		t.Error("RunList with an unknown ref succeeded, want an error")
+	}
+}
+
+func TestRunListLimitOffset(t *testing.T) {
+	t.Setenv("OPPATH", t.TempDir())
+	root := t.TempDir()
+	// Created, and numbered, against path order: pages follow the
+	// lexical order of the walk, whatever the creation order or UUIDs.
+	for i, dir := range []string{"c", "b", "a"} {
+		id := renameFixture()
+		id.UUID = fmt.Sprintf("c3d4e5f6-0000-4000-8000-00000000001%d", i)
+		seedIdentityAt(t, filepath.Join(root, "holons", dir), id)
+	}
+
+	out := captureStdout(t, func() {
+		if err := RunList(root, ListOptions{Fields: []string{"path"}, Limit: 1}); err != nil {
+			t.Fatalf("RunList failed: %v", err)
+		}
+	})
+	want := "PATH\n" + filepath.Join("holons", "a") + "\n... 2 more\n"
+	if out != want {
+		t.Errorf("--limit 1 output:\n%s\nwant:\n%s", out, want)
+	}
+
+	out = captureStdout(t, func() {
+		if err := RunList(root, ListOptions{Fields: []string{"path"}, Limit: 1, Offset: 2}); err != nil {
+			t.Fatalf("RunList failed: %v", err)
+		}
+	})
+	if want := "PATH\n" + filepath.Join("holons", "c") + "\n"; out != want {
+		t.Errorf("--limit 1 --offset 2 output:\n%s\nwant:\n%s", out, want)
+	}
+}
+
+func TestRunListSkipsCacheUnderRoot(t *testing.T) {
+	for _, opPath := range []string{".holon", "op"} {
+		t.Run(opPath, func(t *testing.T) {
+			root := t.TempDir()
+			t.Setenv("OPPATH", filepath.Join(root, opPath))
+			id := renameFixture()
+			seedIdentityAt(t, filepath.Join(root, opPath, "cache", "swift"), id)
+
+			out := captureStdout(t, func() {
+				if err := RunList(root, ListOptions{JSONL: true}); err != nil {
+					t.Fatalf("RunList failed: %v", err)
+				}
+			})
+
+			lines := strings.Split(strings.TrimSuffix(out, "\n"), "\n")
+			if len(lines) != 1 {
+				t.Fatalf("got %d entries, want the cached holon once:\n%s", len(lines), out)
+			}
+			var entry struct {
+				Origin string `json:"origin"`
+			}
+			if err := json.Unmarshal([]byte(lines[0]), &entry); err != nil {
+				t.Fatal(err)
+			}
+			if entry.Origin != "cached" {
+				t.Errorf("origin = %q, want cached", entry.Origin)
+			}
+		})
+	}
+}
+
+func TestRunListTag(t *testing.T) {
+	t.Setenv("OPPATH", t.TempDir())
+	root := t.TempDir()
+
+	audio := renameFixture()
+	audio.Tags = []string{"audio", "experimental"}
+	stable := renameFixture()
+	stable.GivenName = "Steady"
+	stable.Tags = []string{"audio"}
+	plain := renameFixture()
+	plain.GivenName = "Plain"
+	for _, id := range []identity.Identity{audio, stable, plain} {
+		seedIdentity(t, root, id)
+	}
+
+	list := func(tags ...string) []string {
+		out := captureStdout(t, func() {
+			if err := RunList(root, ListOptions{Fields: []string{"name", "tags"}, Tags: tags}); err != nil {
+				t.Fatalf("RunList failed: %v", err)
+			}
+		})
+		return strings.Split(strings.TrimSpace(out), "\n")[1:]
+	}
+
+	if rows := list("AUDIO"); len(rows) != 2 {
+		t.Errorf("--tag AUDIO listed %q, want the two audio holons", rows)
+	}
+	rows := list("audio", "experimental")
+	if len(rows) != 1 || !strings.HasPrefix(rows[0], audio.GivenName+" ") || !strings.HasSuffix(rows[0], "audio,experimental") {
+		t.Errorf("--tag audio --tag experimental listed %q, want only %s", rows, audio.GivenName)
+	}
+	if rows := list(); len(rows) != 3 {
+		t.Errorf("no --tag listed %q, want every holon", rows)
+	}
+}
+
+func TestRunListInvalid(t *testing.T) {
+	t.Setenv("OPPATH", t.TempDir())
+	root := t.TempDir()
+	seedIdentityAt(t, filepath.Join(root, "holons", "valid"), renameFixture())
+	broken := filepath.Join(root, "holons", "broken")
+	if err := os.MkdirAll(broken, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(broken, "HOLON.md"), []byte("---\nuuid: \"\"\n---\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	out := captureStdout(t, func() {
+		if err := RunList(root, ListOptions{Invalid: true}); err != nil {
+			t.Fatalf("RunList failed: %v", err)
+		}
+	})
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != 2 || !strings.HasPrefix(lines[0], "PATH") || !strings.Contains(lines[0], "REASON") {
+		t.Fatalf("--invalid output:\n%s\nwant a header and one row", out)
+	}
+	row := lines[1]
+	if !strings.HasPrefix(row, filepath.Join("holons", "broken")) || !strings.Contains(row, "uuid:") || !strings.Contains(row, "given_name: is required") {
+		t.Errorf("row = %q, want the broken holon with its reasons", row)
+	}
+	if strings.Contains(out, filepath.Join("holons", "valid")) {
+		t.Errorf("valid holon listed:\n%s", out)
+	}
+}
@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/organic-programming/sophia-who/pkg/identity"
+)
+
+// legacyHolonDir is where older releases created holons. Scans skip
+// hidden directories, so holons left there are never listed.
+const legacyHolonDir = ".holon"
+
+// RunMigrateLayout moves every holon directory directly under
+// <root>/.holon into the configured output root (default: holons), where
+// scans find it. Only directories holding a HOLON.md are moved, and
+// nothing inside them is changed. A holon whose target already exists
+// is skipped, so running the migration again is harmless. With dryRun,
+// the moves are only printed.
+func RunMigrateLayout(root string, dryRun bool) error {
+	cfg, err := identity.LoadConfig(root)
+	if err != nil {
+		return err
+	}
+	outputRoot := cfg.OutputRoot
+	if outputRoot == "" {
+		outputRoot = "holons"
+	}
+
+	legacy := filepath.Join(root, legacyHolonDir)
+	entries, err := os.ReadDir(legacy)
+	if os.IsNotExist(err) {
+		fmt.Printf("Nothing to migrate: %s does not exist.\n", legacy)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	verb := "moved"
+	if dryRun {
+		verb = "would move"
+	}
+	moved, skipped := 0, 0
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		from := filepath.Join(legacy, e.Name())
+		if _, err := os.Stat(filepath.Join(from, "HOLON.md")); err != nil {
+			continue
+		}
+		to := filepath.Join(root, outputRoot, e.Name())
+		if _, err := os.Lstat(to); err == nil {
+			fmt.Fprintf(os.Stderr, "skipped %s: %s already exists\n", from, to)
+			skipped++
+			continue
+		}
+		if !dryRun {
+			if err := os.MkdirAll(filepath.Dir(to), 0755); err != nil {
+				return err
+			}
+			if err := os.Rename(from, to); err != nil {
+				return fmt.Errorf("cannot move %s: %w", from, err)
+			}
+		}
+		fmt.Printf("%s %s → %s\n", verb, from, to)
+		moved++
+	}
+
+	if !dryRun && moved > 0 {
+		// Drop the legacy directory once empty; anything else left in it
+		// (e.g. a cache) keeps it.
+		os.Remove(legacy) //nolint:errcheck
+	}
+	fmt.Printf("%d holon(s) %s, %d skipped\n", moved, verb, skipped)
+	return nil
+}
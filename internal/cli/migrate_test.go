@@ -0,0 +1,72 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunMigrateLayout(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("OPPATH", t.TempDir())
+	root := t.TempDir()
+	id := renameFixture()
+	seedIdentityAt(t, filepath.Join(root, ".holon", "swift-prober"), id)
+	// Not a holon: left where it is.
+	if err := os.MkdirAll(filepath.Join(root, ".holon", "cache"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	list := func() string {
+		t.Helper()
+		return captureStdout(t, func() {
+			if err := RunList(root, ListOptions{JSONL: true}); err != nil {
+				t.Fatalf("RunList failed: %v", err)
+			}
+		})
+	}
+	if out := list(); strings.Contains(out, id.UUID) {
+		t.Fatalf("holon under .holon listed before migration:\n%s", out)
+	}
+
+	captureStdout(t, func() {
+		if err := RunMigrateLayout(root, true); err != nil {
+			t.Fatalf("dry run failed: %v", err)
+		}
+	})
+	if _, err := os.Stat(filepath.Join(root, ".holon", "swift-prober", "HOLON.md")); err != nil {
+		t.Fatalf("dry run moved the holon: %v", err)
+	}
+
+	captureStdout(t, func() {
+		if err := RunMigrateLayout(root, false); err != nil {
+			t.Fatalf("RunMigrateLayout failed: %v", err)
+		}
+	})
+	if _, err := os.Stat(filepath.Join(root, "holons", "swift-prober", "HOLON.md")); err != nil {
+		t.Fatalf("holon not moved to holons/: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(root, ".holon", "cache")); err != nil {
+		t.Errorf("non-holon directory was touched: %v", err)
+	}
+	if out := list(); !strings.Contains(out, id.UUID) || !strings.Contains(out, filepath.Join("holons", "swift-prober")) {
+		t.Errorf("migrated holon not listed:\n%s", out)
+	}
+}
+
+func TestRunMigrateLayoutSkipsExistingTarget(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	root := t.TempDir()
+	seedIdentityAt(t, filepath.Join(root, ".holon", "swift-prober"), renameFixture())
+	seedIdentityAt(t, filepath.Join(root, "holons", "swift-prober"), renameFixture())
+
+	captureStdout(t, func() {
+		if err := RunMigrateLayout(root, false); err != nil {
+			t.Fatalf("RunMigrateLayout failed: %v", err)
+		}
+	})
+	if _, err := os.Stat(filepath.Join(root, ".holon", "swift-prober", "HOLON.md")); err != nil {
+		t.Errorf("holon with an existing target was moved: %v", err)
+	}
+}
@@ -0,0 +1,52 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+)
+
+// commandRunner starts an external command without waiting for it.
+type commandRunner func(name string, args ...string) error
+
+// startCommand is the default commandRunner.
+func startCommand(name string, args ...string) error {
+	return exec.Command(name, args...).Start()
+}
+
+// openCommand returns the OS handler used to open dir on goos.
+func openCommand(goos, dir string) (string, []string) {
+	switch goos {
+	case "darwin":
+		return "open", []string{dir}
+	case "windows":
+		return "explorer", []string{dir}
+	default:
+		return "xdg-open", []string{dir}
+	}
+}
+
+// openDir opens dir with the OS file manager through run.
+func openDir(dir, goos string, run commandRunner) error {
+	name, args := openCommand(goos, dir)
+	if err := run(name, args...); err != nil {
+		return fmt.Errorf("cannot open %s with %s: %w", dir, name, err)
+	}
+	return nil
+}
+
+// canOpen reports whether an OS handler can reasonably be launched:
+// the session must be attached to a terminal and, on Linux and BSDs,
+// have a graphical display.
+func canOpen() bool {
+	if !isTerminal(os.Stdin) {
+		return false
+	}
+	switch runtime.GOOS {
+	case "darwin", "windows":
+		return true
+	default:
+		return os.Getenv("DISPLAY") != "" || os.Getenv("WAYLAND_DISPLAY") != ""
+	}
+}
@@ -0,0 +1,43 @@
+package cli
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestOpenDirPassesCommandAndPath(t *testing.T) {
+	for _, tc := range []struct {
+		goos, want string
+	}{
+		{"linux", "xdg-open"},
+		{"freebsd", "xdg-open"},
+		{"darwin", "open"},
+		{"windows", "explorer"},
+	} {
+		var gotName string
+		var gotArgs []string
+		run := func(name string, args ...string) error {
+			gotName = name
+			gotArgs = args
+			return nil
+		}
+
+		if err := openDir("holons/swift-prober", tc.goos, run); err != nil {
+			t.Fatalf("%s: openDir failed: %v", tc.goos, err)
+		}
+		if gotName != tc.want {
+			t.Errorf("%s: command = %q, want %q", tc.goos, gotName, tc.want)
+		}
+		if len(gotArgs) != 1 || gotArgs[0] != "holons/swift-prober" {
+			t.Errorf("%s: args = %q, want [holons/swift-prober]", tc.goos, gotArgs)
+		}
+	}
+}
+
+func TestOpenDirRunnerError(t *testing.T) {
+	run := func(string, ...string) error { return errors.New("not found") }
+
+	if err := openDir("holons/x", "linux", run); err == nil {
+		t.Fatal("expected error when the runner fails")
+	}
+}
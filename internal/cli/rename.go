@@ -0,0 +1,92 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/organic-programming/sophia-who/pkg/identity"
+)
+
+// RenameOptions describes the new name for RunRename.
+// Empty fields keep their current value.
+type RenameOptions struct {
+	GivenName  string
+	FamilyName string
+	MoveDir    bool // rename the holon directory to the new slug
+}
+
+// RunRename changes a holon's given and/or family name, rewriting its
+// HOLON.md in place. Nothing else is recomputed: the UUID, lineage, and
+// metadata are preserved, as is the markdown body apart from a title
+// heading that still names the holon.
+func RunRename(root, target string, opts RenameOptions) error {
+	opts.GivenName = strings.TrimSpace(opts.GivenName)
+	opts.FamilyName = strings.TrimSpace(opts.FamilyName)
+	if opts.GivenName == "" && opts.FamilyName == "" {
+		return fmt.Errorf("nothing to rename: pass --given and/or --family")
+	}
+
+	path, err := identity.FindByUUID(root, target)
+	if err != nil {
+		return err
+	}
+
+	var old, renamed identity.Identity
+	err = identity.RewriteHolonFile(path, func(data []byte) ([]byte, error) {
+		var err error
+		if old, _, err = identity.ParseFrontmatter(data); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+
+		renamed = old
+		set := map[string]any{}
+		if opts.GivenName != "" {
+			renamed.GivenName = opts.GivenName
+			set["given_name"] = opts.GivenName
+		}
+		if opts.FamilyName != "" {
+			renamed.FamilyName = opts.FamilyName
+			set["family_name"] = opts.FamilyName
+		}
+
+		updated, err := identity.UpdateFrontmatter(data, set)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		return retitle(updated, old, renamed), nil
+	})
+	if err != nil {
+		return err
+	}
+	audit(root, identity.AuditUpdate, renamed, path)
+
+	fmt.Printf("✓ Renamed: %s %s → %s %s\n", old.GivenName, old.FamilyName, renamed.GivenName, renamed.FamilyName)
+
+	if !opts.MoveDir {
+		return nil
+	}
+
+	dir := filepath.Dir(path)
+	newDir := filepath.Join(filepath.Dir(dir), renamed.Slug())
+	if newDir == dir {
+		return nil
+	}
+	if _, err := os.Stat(newDir); err == nil {
+		return fmt.Errorf("cannot move %s: %s already exists", dir, newDir)
+	}
+	if err := os.Rename(dir, newDir); err != nil {
+		return fmt.Errorf("cannot move %s: %w", dir, err)
+	}
+	fmt.Printf("  Dir:  %s\n", newDir)
+	return nil
+}
+
+// retitle replaces the body's "# <given> <family>" heading for old with
+// the heading for renamed. Custom headings are left alone.
+func retitle(data []byte, old, renamed identity.Identity) []byte {
+	oldTitle := "\n# " + identity.EscapeMarkdown(old.GivenName) + " " + identity.EscapeMarkdown(old.FamilyName) + "\n"
+	newTitle := "\n# " + identity.EscapeMarkdown(renamed.GivenName) + " " + identity.EscapeMarkdown(renamed.FamilyName) + "\n"
+	return []byte(strings.Replace(string(data), oldTitle, newTitle, 1))
+}
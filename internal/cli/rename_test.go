@@ -0,0 +1,102 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/organic-programming/sophia-who/pkg/identity"
+)
+
+// seedIdentity writes a HOLON.md for id under root/holons/<slug>/ and
+// returns its path.
+func seedIdentity(t *testing.T, root string, id identity.Identity) string {
+	t.Helper()
+	return seedIdentityAt(t, filepath.Join(root, "holons", id.Slug()), id)
+}
+
+// seedIdentityAt writes id to dir/HOLON.md and returns the file path.
+func seedIdentityAt(t *testing.T, dir string, id identity.Identity) string {
+	t.Helper()
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "HOLON.md")
+	if err := identity.WriteHolonMD(id, path); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func renameFixture() identity.Identity {
+	id := identity.New()
+	id.GivenName = "Swift"
+	id.FamilyName = "Prober"
+	id.Motto = "Probe quickly."
+	id.Composer = "Test Suite"
+	id.Clade = "deterministic/pure"
+	id.Reproduction = "manual"
+	id.Lang = "go"
+	return id
+}
+
+func TestRunRename(t *testing.T) {
+	root := t.TempDir()
+	id := renameFixture()
+	path := seedIdentity(t, root, id)
+
+	if err := RunRename(root, id.UUID, RenameOptions{GivenName: "Deep", FamilyName: "Scanner"}); err != nil {
+		t.Fatalf("RunRename failed: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	content := string(data)
+	if strings.Contains(content, "Swift") || strings.Contains(content, "Prober") {
+		t.Errorf("old name still present:\n%s", content)
+	}
+	if !strings.Contains(content, "# Deep Scanner") {
+		t.Errorf("title heading not updated:\n%s", content)
+	}
+
+	renamed, _, err := identity.ParseFrontmatter(data)
+	if err != nil {
+		t.Fatalf("ParseFrontmatter failed: %v", err)
+	}
+	if renamed.GivenName != "Deep" || renamed.FamilyName != "Scanner" {
+		t.Errorf("name = %q %q, want Deep Scanner", renamed.GivenName, renamed.FamilyName)
+	}
+	if renamed.UUID != id.UUID || renamed.Motto != id.Motto || renamed.Born != id.Born {
+		t.Errorf("other fields changed: %+v", renamed)
+	}
+}
+
+func TestRunRenameMoveDir(t *testing.T) {
+	root := t.TempDir()
+	id := renameFixture()
+	seedIdentity(t, root, id)
+
+	if err := RunRename(root, id.UUID, RenameOptions{GivenName: "Deep", MoveDir: true}); err != nil {
+		t.Fatalf("RunRename failed: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(root, "holons", "deep-prober", "HOLON.md")); err != nil {
+		t.Fatalf("holon not moved to the new slug: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(root, "holons", "swift-prober")); !os.IsNotExist(err) {
+		t.Error("old directory should be gone")
+	}
+}
+
+func TestRunRenameRequiresName(t *testing.T) {
+	root := t.TempDir()
+	id := renameFixture()
+	seedIdentity(t, root, id)
+
+	if err := RunRename(root, id.UUID, RenameOptions{}); err == nil {
+		t.Fatal("expected error when no new name is given")
+	}
+}
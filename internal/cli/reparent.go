@@ -0,0 +1,26 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/organic-programming/sophia-who/pkg/identity"
+)
+
+// RunReparent replaces the parents of the target holon under root with
+// parents, given as UUIDs or unique prefixes (see identity.Reparent).
+func RunReparent(root, target string, parents []string) error {
+	h, err := identity.ResolveTarget(root, target)
+	if err != nil {
+		return err
+	}
+	id, previous, err := identity.Reparent(root, h.Path, parents)
+	if err != nil {
+		return err
+	}
+	audit(root, identity.AuditUpdate, id, h.Path)
+
+	fmt.Printf("✓ %s %s: parents [%s] → [%s]\n", id.GivenName, id.FamilyName,
+		strings.Join(previous, ", "), strings.Join(id.Parents, ", "))
+	return nil
+}
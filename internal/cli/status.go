@@ -0,0 +1,76 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/organic-programming/sophia-who/internal/bulk"
+	"github.com/organic-programming/sophia-who/pkg/identity"
+)
+
+// StatusOptions tunes RunStatus.
+type StatusOptions struct {
+	// Concurrency bounds how many holons are rewritten at once.
+	// Zero: bulk.DefaultConcurrency().
+	Concurrency int
+}
+
+// setStatus rewrites the status of one holon for RunStatus; replaceable
+// in tests.
+var setStatus = identity.SetStatus
+
+// RunStatus moves every target holon under root to status, printing one
+// line per holon, in the order given. A holon that fails is reported
+// and the rest are still updated; the returned error counts the
+// failures.
+func RunStatus(root, status string, targets []string, opts StatusOptions) error {
+	cfg, err := identity.LoadConfig(root)
+	if err != nil {
+		return err
+	}
+
+	// Resolve every target first: a holon named twice, by UUID and by
+	// alias say, is then rewritten once and both report the outcome.
+	paths := make([]string, len(targets))
+	errs := make([]error, len(targets))
+	bulk.Run(len(targets), opts.Concurrency, func(i int) {
+		h, err := identity.ResolveTarget(root, targets[i])
+		if err != nil {
+			errs[i] = err
+			return
+		}
+		paths[i] = filepath.Clean(h.Path)
+	})
+
+	lines := make([]string, len(targets))
+	first := bulk.RunUnique(paths, opts.Concurrency, func(i int) {
+		id, previous, err := setStatus(paths[i], status, cfg.ValidateOptions())
+		if err != nil {
+			errs[i] = err
+			return
+		}
+		audit(root, identity.AuditUpdate, id, paths[i])
+		lines[i] = fmt.Sprintf("✓ %s %s: %s → %s", id.GivenName, id.FamilyName, previous, status)
+	})
+	for i, j := range first {
+		if j >= 0 {
+			lines[i], errs[i] = lines[j], errs[j]
+		}
+	}
+
+	failed := 0
+	for i, target := range targets {
+		if errs[i] != nil {
+			fmt.Fprintf(os.Stderr, "✗ %s: %v\n", target, errs[i])
+			failed++
+			continue
+		}
+		fmt.Println(lines[i])
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d holon(s) not updated", failed, len(targets))
+	}
+	return nil
+}
@@ -0,0 +1,160 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/organic-programming/sophia-who/pkg/identity"
+)
+
+func TestRunStatus(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	root := t.TempDir()
+
+	a := renameFixture()
+	b := renameFixture()
+	b.UUID = "c3d4e5f6-0000-4000-8000-000000000006"
+	b.GivenName = "Deep"
+	pathA := seedIdentityAt(t, filepath.Join(root, "holons", "a"), a)
+	pathB := seedIdentityAt(t, filepath.Join(root, "holons", "b"), b)
+
+	out := captureStdout(t, func() {
+		if err := RunStatus(root, "deprecated", []string{a.UUID, b.UUID}, StatusOptions{}); err != nil {
+			t.Fatalf("RunStatus failed: %v", err)
+		}
+	})
+	if strings.Count(out, "draft → deprecated") != 2 {
+		t.Errorf("output:\n%s", out)
+	}
+	for _, path := range []string{pathA, pathB} {
+		if got := readStatus(t, path); got.Status != "deprecated" || got.Died != "" {
+			t.Errorf("%s: status %q died %q, want deprecated and no died", path, got.Status, got.Died)
+		}
+	}
+
+	captureStdout(t, func() {
+		err := RunStatus(root, "dead", []string{a.UUID, "no-such-holon"}, StatusOptions{})
+		if err == nil || !strings.Contains(err.Error(), "1 of 2") {
+			t.Errorf("RunStatus error = %v, want one failure reported", err)
+		}
+	})
+	if got := readStatus(t, pathA); got.Status != "dead" || got.Died != time.Now().Format("2006-01-02") {
+		t.Errorf("dead holon: status %q died %q", got.Status, got.Died)
+	}
+
+	if err := RunStatus(root, "asleep", []string{b.UUID}, StatusOptions{}); err == nil {
+		t.Error("RunStatus accepted an unknown status")
+	}
+}
+
+func TestRunStatusDuplicateTargets(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	root := t.TempDir()
+	a := renameFixture()
+	a.Aliases = []string{"swifty"}
+	b := renameFixture()
+	b.UUID = "c3d4e5f6-0000-4000-8000-000000000007"
+	b.GivenName = "Deep"
+	pathA := seedIdentityAt(t, filepath.Join(root, "holons", "a"), a)
+	seedIdentityAt(t, filepath.Join(root, "holons", "b"), b)
+
+	var mu sync.Mutex
+	calls := map[string]int{}
+	original := setStatus
+	setStatus = func(path, status string, opts identity.ValidateOptions) (identity.Identity, string, error) {
+		mu.Lock()
+		calls[path]++
+		mu.Unlock()
+		return original(path, status, opts)
+	}
+	t.Cleanup(func() { setStatus = original })
+
+	targets := []string{a.UUID, "swifty", b.UUID, a.UUID[:8], a.UUID}
+	out := captureStdout(t, func() {
+		if err := RunStatus(root, "stable", targets, StatusOptions{Concurrency: 4}); err != nil {
+			t.Fatalf("RunStatus failed: %v", err)
+		}
+	})
+	for path, n := range calls {
+		if n != 1 {
+			t.Errorf("%s rewritten %d times, want once", path, n)
+		}
+	}
+	if len(calls) != 2 {
+		t.Errorf("rewrote %d holons, want 2", len(calls))
+	}
+	if lines := strings.Split(strings.TrimSpace(out), "\n"); len(lines) != len(targets) || strings.Count(out, "Swift Prober: draft → stable") != 4 {
+		t.Errorf("output is not one line per target:\n%s", out)
+	}
+	if got := readStatus(t, pathA); got.Status != "stable" {
+		t.Errorf("status %q, want stable", got.Status)
+	}
+}
+
+func TestRunStatusConcurrency(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	root := t.TempDir()
+	const n, limit = 24, 2
+	var targets, paths []string
+	for i := range n {
+		id := renameFixture()
+		id.UUID = fmt.Sprintf("c3d4e5f6-0000-4000-8000-%012d", i)
+		id.GivenName = fmt.Sprintf("Holon%02d", i)
+		paths = append(paths, seedIdentityAt(t, filepath.Join(root, "holons", id.GivenName), id))
+		targets = append(targets, id.UUID)
+	}
+
+	var mu sync.Mutex
+	inFlight, peak := 0, 0
+	original := setStatus
+	setStatus = func(path, status string, opts identity.ValidateOptions) (identity.Identity, string, error) {
+		mu.Lock()
+		inFlight++
+		peak = max(peak, inFlight)
+		mu.Unlock()
+		defer func() {
+			mu.Lock()
+			inFlight--
+			mu.Unlock()
+		}()
+		time.Sleep(2 * time.Millisecond)
+		return original(path, status, opts)
+	}
+	t.Cleanup(func() { setStatus = original })
+
+	out := captureStdout(t, func() {
+		if err := RunStatus(root, "stable", targets, StatusOptions{Concurrency: limit}); err != nil {
+			t.Fatalf("RunStatus failed: %v", err)
+		}
+	})
+	for _, path := range paths {
+		if got := readStatus(t, path); got.Status != "stable" {
+			t.Errorf("%s: status %q, want stable", path, got.Status)
+		}
+	}
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != n || !strings.Contains(lines[0], "Holon00") || !strings.Contains(lines[n-1], fmt.Sprintf("Holon%02d", n-1)) {
+		t.Errorf("output is not one line per holon in order:\n%s", out)
+	}
+	if peak > limit {
+		t.Errorf("peak concurrency = %d, want at most %d", peak, limit)
+	}
+}
+
+func readStatus(t *testing.T, path string) identity.Identity {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	id, _, err := identity.ParseFrontmatter(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return id
+}
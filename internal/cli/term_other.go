@@ -0,0 +1,10 @@
+//go:build !unix
+
+package cli
+
+import "os"
+
+// terminalWidth reports 0 (unknown) outside unix; lines are not cut.
+func terminalWidth(f *os.File) int {
+	return 0
+}
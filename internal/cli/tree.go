@@ -0,0 +1,59 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// treeNode is a directory in list --tree output. Holons are leaves of
+// the directory that contains their holon directory.
+type treeNode struct {
+	dirs   map[string]*treeNode
+	holons []listEntry
+}
+
+func newTreeNode() *treeNode {
+	return &treeNode{dirs: map[string]*treeNode{}}
+}
+
+// add files e under the directories of its relative path.
+func (n *treeNode) add(e listEntry) {
+	parent := path.Dir(filepath.ToSlash(e.Path))
+	if parent != "." {
+		for _, part := range strings.Split(parent, "/") {
+			child, ok := n.dirs[part]
+			if !ok {
+				child = newTreeNode()
+				n.dirs[part] = child
+			}
+			n = child
+		}
+	}
+	n.holons = append(n.holons, e)
+}
+
+// render writes the tree, two spaces of indentation per level:
+// directories end in "/", holons show their directory, name, and UUID.
+func (n *treeNode) render(w io.Writer, depth int) {
+	indent := strings.Repeat("  ", depth)
+
+	names := make([]string, 0, len(n.dirs))
+	for name := range n.dirs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(w, "%s%s/\n", indent, name)
+		n.dirs[name].render(w, depth+1)
+	}
+
+	sort.Slice(n.holons, func(i, j int) bool { return n.holons[i].Path < n.holons[j].Path })
+	for _, h := range n.holons {
+		name := strings.TrimSpace(h.GivenName + " " + h.FamilyName)
+		fmt.Fprintf(w, "%s%s — %s (%s)\n", indent, path.Base(filepath.ToSlash(h.Path)), name, h.UUID)
+	}
+}
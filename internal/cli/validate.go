@@ -0,0 +1,37 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/organic-programming/sophia-who/pkg/identity"
+)
+
+// RunValidate checks a HOLON.md file, or standard input when path is "-",
+// printing one line per problem. It returns an error if the content is invalid.
+func RunValidate(path string) error {
+	var data []byte
+	var err error
+	name := path
+	if path == "-" {
+		name = "<stdin>"
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return fmt.Errorf("cannot read %s: %w", name, err)
+	}
+
+	errs := identity.ValidateContent(data)
+	for _, fe := range errs {
+		fmt.Printf("%s: %s\n", name, fe.Error())
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%s: %d problem(s) found", name, len(errs))
+	}
+
+	fmt.Printf("✓ %s is valid\n", name)
+	return nil
+}
@@ -0,0 +1,23 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/organic-programming/sophia-who/pkg/identity"
+)
+
+// RunVerifyLineage checks that the target holon under root has each of
+// expected, UUIDs or unique prefixes, among its parents (see
+// identity.VerifyLineage). A mismatch is returned as an error, so that
+// CI steps fail on it.
+func RunVerifyLineage(root, target string, expected []string) error {
+	h, err := identity.ResolveTarget(root, target)
+	if err != nil {
+		return err
+	}
+	if err := identity.VerifyLineage(root, h.Identity, expected); err != nil {
+		return err
+	}
+	fmt.Printf("✓ %s %s: lineage as expected\n", h.Identity.GivenName, h.Identity.FamilyName)
+	return nil
+}
@@ -0,0 +1,183 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/organic-programming/sophia-who/pkg/identity"
+)
+
+// watchInterval is how often list --watch polls the tree for changes.
+var watchInterval = time.Second
+
+type watchOp int
+
+const (
+	watchCreate watchOp = iota
+	watchModify
+	watchRemove
+)
+
+// watchEvent reports a HOLON.md created, modified, or removed.
+type watchEvent struct {
+	Op   watchOp
+	Path string
+}
+
+// fileStamp identifies one version of a file for change detection.
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+// watchModel is the state behind list --watch: the holons currently on
+// screen, keyed by HOLON.md path.
+type watchModel struct {
+	root     string
+	opts     identity.ScanOptions
+	fullUUID bool // see ListOptions.FullUUID
+	rows     map[string]identity.LocatedIdentity
+	files    map[string]fileStamp
+}
+
+func newWatchModel(root string, opts identity.ScanOptions) *watchModel {
+	return &watchModel{
+		root:  root,
+		opts:  opts,
+		rows:  map[string]identity.LocatedIdentity{},
+		files: map[string]fileStamp{},
+	}
+}
+
+// poll compares the tree with the last poll and returns what changed.
+func (m *watchModel) poll() ([]watchEvent, error) {
+	paths, err := identity.HolonFiles(m.root, m.opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []watchEvent
+	current := make(map[string]fileStamp, len(paths))
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			continue
+		}
+		stamp := fileStamp{modTime: info.ModTime(), size: info.Size()}
+		current[path] = stamp
+		prev, known := m.files[path]
+		switch {
+		case !known:
+			events = append(events, watchEvent{Op: watchCreate, Path: path})
+		case prev != stamp:
+			events = append(events, watchEvent{Op: watchModify, Path: path})
+		}
+	}
+	for path := range m.files {
+		if _, ok := current[path]; !ok {
+			events = append(events, watchEvent{Op: watchRemove, Path: path})
+		}
+	}
+	m.files = current
+
+	sort.Slice(events, func(i, j int) bool { return events[i].Path < events[j].Path })
+	return events, nil
+}
+
+// handle applies ev to the rows. A file that no longer parses is taken
+// off screen until it is fixed.
+func (m *watchModel) handle(ev watchEvent) {
+	if ev.Op == watchRemove {
+		delete(m.rows, ev.Path)
+		return
+	}
+	data, err := identity.ReadHolonFile(ev.Path, m.opts.MaxFileSize)
+	if err != nil {
+		delete(m.rows, ev.Path)
+		return
+	}
+	id, _, err := identity.ParseFrontmatter(data)
+	if err != nil {
+		delete(m.rows, ev.Path)
+		return
+	}
+	m.rows[ev.Path] = identity.LocatedIdentity{Identity: id, Path: ev.Path}
+}
+
+// render clears the terminal and draws the table, cutting lines to
+// width columns when width is positive.
+func (m *watchModel) render(w io.Writer, width int) {
+	paths := make([]string, 0, len(m.rows))
+	for path := range m.rows {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	lines := []string{
+		fmt.Sprintf("Watching %s — %d holon(s), Ctrl-C to quit", m.root, len(paths)),
+		"",
+		fmt.Sprintf("%-*s %-33s %-25s %-8s %s", uuidColumnWidth(m.fullUUID), "UUID", "NAME", "CLADE", "STATUS", "PATH"),
+	}
+	for _, path := range paths {
+		id := m.rows[path].Identity
+		name := strings.TrimSpace(id.GivenName + " " + id.FamilyName)
+		uuid := displayUUID(id.UUID, m.fullUUID)
+		lines = append(lines, fmt.Sprintf("%-*s %-33s %-25s %-8s %s", uuidColumnWidth(m.fullUUID), uuid, name, id.Clade, id.Status, relHolonDir(m.root, path)))
+	}
+
+	fmt.Fprint(w, "\033[H\033[2J")
+	for _, line := range lines {
+		if runes := []rune(line); width > 0 && len(runes) > width {
+			line = string(runes[:width])
+		}
+		fmt.Fprintln(w, line)
+	}
+}
+
+// outputWidth is the width of the terminal out is open on, or 0 when it
+// is not a terminal.
+func outputWidth(out io.Writer) int {
+	if !isTerminalWriter(out) {
+		return 0
+	}
+	return terminalWidth(out.(*os.File))
+}
+
+// runWatch redraws the list on out whenever a HOLON.md under root
+// changes or, when out is a terminal, it is resized, until interrupted.
+func runWatch(out io.Writer, root string, opts identity.ScanOptions, fullUUID bool) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	m := newWatchModel(root, opts)
+	m.fullUUID = fullUUID
+	width := -1
+	ticker := time.NewTicker(watchInterval)
+	defer ticker.Stop()
+
+	for {
+		events, err := m.poll()
+		if err != nil {
+			return err
+		}
+		for _, ev := range events {
+			m.handle(ev)
+		}
+		if w := outputWidth(out); len(events) > 0 || w != width {
+			width = w
+			m.render(out, width)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
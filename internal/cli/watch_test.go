@@ -0,0 +1,52 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/organic-programming/sophia-who/pkg/identity"
+)
+
+func TestWatchModelTracksChanges(t *testing.T) {
+	root := t.TempDir()
+	m := newWatchModel(root, identity.ScanOptions{})
+
+	if events, err := m.poll(); err != nil || len(events) != 0 {
+		t.Fatalf("empty tree: events %v, err %v", events, err)
+	}
+
+	// A simulated create event for a file the poller has not seen yet.
+	id := renameFixture()
+	seedIdentityAt(t, filepath.Join(root, "holons", "swift"), id)
+	path := filepath.Join(root, "holons", "swift", "HOLON.md")
+	m.handle(watchEvent{Op: watchCreate, Path: path})
+	if got := m.rows[path].Identity.UUID; got != id.UUID {
+		t.Fatalf("row after create = %q, want %q", got, id.UUID)
+	}
+
+	var out bytes.Buffer
+	m.render(&out, 0)
+	if !strings.Contains(out.String(), identity.ShortUUID(id.UUID)+"   Swift") || !strings.Contains(out.String(), "1 holon(s)") {
+		t.Errorf("render after create:\n%s", out.String())
+	}
+
+	// The poller reports the same file, then its removal.
+	events, err := m.poll()
+	if err != nil || len(events) != 1 || events[0].Op != watchCreate || events[0].Path != path {
+		t.Fatalf("poll after create = %v, %v", events, err)
+	}
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	events, err = m.poll()
+	if err != nil || len(events) != 1 || events[0].Op != watchRemove {
+		t.Fatalf("poll after remove = %v, %v", events, err)
+	}
+	m.handle(events[0])
+	if len(m.rows) != 0 {
+		t.Errorf("rows after remove = %v, want none", m.rows)
+	}
+}
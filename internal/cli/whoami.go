@@ -0,0 +1,27 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/organic-programming/sophia-who/pkg/identity"
+)
+
+// RunWhoami reports the holon whose directory tree contains the current
+// working directory.
+func RunWhoami() error {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+
+	h, err := identity.FindEnclosing(cwd)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s %s\n", h.Identity.GivenName, h.Identity.FamilyName)
+	fmt.Printf("  UUID: %s\n", h.Identity.UUID)
+	fmt.Printf("  File: %s\n", h.Path)
+	return nil
+}
@@ -0,0 +1,43 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunWhoamiFromNestedDir(t *testing.T) {
+	root := t.TempDir()
+	outer := renameFixture()
+	seedIdentity(t, root, outer)
+
+	// A second holon nested inside the first must win for cwds below it.
+	inner := renameFixture()
+	inner.UUID = "b2c3d4e5-0000-4000-8000-000000000003"
+	inner.GivenName = "Inner"
+	innerDir := filepath.Join(root, "holons", outer.Slug(), "parts")
+	innerPath := seedIdentityAt(t, innerDir, inner)
+
+	cwd := filepath.Join(innerDir, "src", "deep")
+	if err := os.MkdirAll(cwd, 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(cwd)
+
+	out := captureStdout(t, func() {
+		if err := RunWhoami(); err != nil {
+			t.Fatalf("RunWhoami failed: %v", err)
+		}
+	})
+	if !strings.Contains(out, inner.UUID) || !strings.Contains(out, innerPath) {
+		t.Errorf("whoami reported the wrong holon:\n%s", out)
+	}
+}
+
+func TestRunWhoamiOutsideAnyHolon(t *testing.T) {
+	t.Chdir(t.TempDir())
+	if err := RunWhoami(); err == nil || !strings.Contains(err.Error(), "no HOLON.md found") {
+		t.Fatalf("RunWhoami error = %v, want a clear not-found error", err)
+	}
+}
@@ -2,8 +2,15 @@ package server
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"io"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -15,7 +22,10 @@ import (
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 )
 
 func startContractMemClient(t *testing.T, root string) (pb.SophiaWhoServiceClient, func()) {
@@ -113,6 +123,35 @@ func TestContractCreateIdentityNominal(t *testing.T) {
 	}
 }
 
+func TestContractCreateIdentityRawContent(t *testing.T) {
+	root := t.TempDir()
+	client, cleanup := startContractMemClient(t, root)
+	defer cleanup()
+
+	req := validCreateReq(filepath.Join("holons", "sophia-contract"))
+	req.Aliases = []string{"sophia"}
+	req.Tags = []string{"Contract"}
+	resp, err := client.CreateIdentity(context.Background(), req)
+	if err != nil {
+		t.Fatalf("CreateIdentity failed: %v", err)
+	}
+
+	parsed, _, err := identity.ParseFrontmatter([]byte(resp.GetRawContent()))
+	if err != nil {
+		t.Fatalf("parse raw_content: %v\n%s", err, resp.GetRawContent())
+	}
+	if got := toProto(parsed); !proto.Equal(got, resp.GetIdentity()) {
+		t.Errorf("raw_content reparses to %v, want the created identity %v", got, resp.GetIdentity())
+	}
+	data, err := os.ReadFile(resp.GetFilePath())
+	if err != nil {
+		t.Fatalf("read created file: %v", err)
+	}
+	if string(data) != resp.GetRawContent() {
+		t.Errorf("raw_content differs from %s:\n%s", resp.GetFilePath(), resp.GetRawContent())
+	}
+}
+
 func TestContractCreateIdentityMissingGivenName(t *testing.T) {
 	root := t.TempDir()
 	client, cleanup := startContractMemClient(t, root)
@@ -130,6 +169,124 @@ func TestContractCreateIdentityMissingGivenName(t *testing.T) {
 	}
 }
 
+func TestContractCreateIdentityQuotedMotto(t *testing.T) {
+	root := t.TempDir()
+	client, cleanup := startContractMemClient(t, root)
+	defer cleanup()
+
+	req := validCreateReq(filepath.Join("holons", "quoted"))
+	req.Motto = `Say "no" to *everything*.`
+	resp, err := client.CreateIdentity(context.Background(), req)
+	if err != nil {
+		t.Fatalf("CreateIdentity failed: %v", err)
+	}
+
+	data, err := os.ReadFile(resp.GetFilePath())
+	if err != nil {
+		t.Fatalf("read created file: %v", err)
+	}
+	parsed, body, err := identity.ParseFrontmatter(data)
+	if err != nil {
+		t.Fatalf("parse created HOLON.md: %v", err)
+	}
+	if parsed.Motto != req.Motto {
+		t.Errorf("motto = %q, want %q", parsed.Motto, req.Motto)
+	}
+	if want := `> *"Say \"no\" to \*everything\*."*`; !strings.Contains(body, want+"\n") {
+		t.Errorf("body missing escaped motto line %s:\n%s", want, body)
+	}
+
+	req = validCreateReq(filepath.Join("holons", "multiline"))
+	req.Motto = "First line.\n---\nNot frontmatter."
+	if _, err := client.CreateIdentity(context.Background(), req); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("multi-line motto: err = %v, want InvalidArgument", err)
+	}
+}
+
+func TestContractCreateIdentityBorn(t *testing.T) {
+	root := t.TempDir()
+	client, cleanup := startContractMemClient(t, root)
+	defer cleanup()
+
+	req := validCreateReq(filepath.Join("holons", "backfilled"))
+	req.Born = "2019-03-14"
+	resp, err := client.CreateIdentity(context.Background(), req)
+	if err != nil {
+		t.Fatalf("CreateIdentity failed: %v", err)
+	}
+	if got := resp.GetIdentity().GetBorn(); got != "2019-03-14" {
+		t.Errorf("response born = %q, want 2019-03-14", got)
+	}
+	data, err := os.ReadFile(resp.GetFilePath())
+	if err != nil {
+		t.Fatalf("read created file: %v", err)
+	}
+	if !strings.Contains(string(data), "\nborn: \"2019-03-14\"\n") {
+		t.Errorf("HOLON.md does not record the backfilled born date:\n%s", data)
+	}
+
+	for _, born := range []string{time.Now().AddDate(0, 0, 2).Format(identity.DateLayout), "14/03/2019"} {
+		req := validCreateReq(filepath.Join("holons", "bad-born"))
+		req.Born = born
+		if _, err := client.CreateIdentity(context.Background(), req); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("born %q: err = %v, want InvalidArgument", born, err)
+		}
+	}
+}
+
+func TestContractCreateIdentityOmitBody(t *testing.T) {
+	root := t.TempDir()
+	client, cleanup := startContractMemClient(t, root)
+	defer cleanup()
+
+	req := validCreateReq(filepath.Join("holons", "minimal"))
+	req.OmitBody = true
+	resp, err := client.CreateIdentity(context.Background(), req)
+	if err != nil {
+		t.Fatalf("CreateIdentity failed: %v", err)
+	}
+	data, err := os.ReadFile(resp.GetFilePath())
+	if err != nil {
+		t.Fatalf("read created file: %v", err)
+	}
+	if strings.Contains(string(data), "## Description") || !strings.HasSuffix(string(data), "\n# sophia Contract\n") {
+		t.Errorf("HOLON.md is not frontmatter and title only:\n%s", data)
+	}
+	if _, err := client.ShowIdentity(context.Background(), &pb.ShowIdentityRequest{Uuid: resp.GetIdentity().GetUuid()}); err != nil {
+		t.Errorf("ShowIdentity of the minimal holon failed: %v", err)
+	}
+}
+
+func TestContractCreateIdentitySameNames(t *testing.T) {
+	root := t.TempDir()
+	client, cleanup := startContractMemClient(t, root)
+	defer cleanup()
+
+	req := validCreateReq(filepath.Join("holons", "echo-echo"))
+	req.GivenName, req.FamilyName = "Echo", "Echo"
+	resp, err := client.CreateIdentity(context.Background(), req)
+	if err != nil {
+		t.Fatalf("CreateIdentity failed: %v", err)
+	}
+	if warnings := strings.Join(resp.GetWarnings(), "\n"); !strings.Contains(warnings, "family_name: is the same as given_name") {
+		t.Errorf("warnings = %q, want the identical names reported", warnings)
+	}
+
+	distinct, err := client.CreateIdentity(context.Background(), validCreateReq(filepath.Join("holons", "sophia-contract")))
+	if err != nil {
+		t.Fatalf("CreateIdentity failed: %v", err)
+	}
+	if len(distinct.GetWarnings()) != 0 {
+		t.Errorf("warnings = %q for distinct names, want none", distinct.GetWarnings())
+	}
+
+	req.OutputDir = filepath.Join("holons", "strict")
+	req.Strict = true
+	if _, err := client.CreateIdentity(context.Background(), req); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("strict CreateIdentity err = %v, want InvalidArgument", err)
+	}
+}
+
 func TestContractCreateIdentityMissingComposer(t *testing.T) {
 	root := t.TempDir()
 	client, cleanup := startContractMemClient(t, root)
@@ -147,6 +304,242 @@ func TestContractCreateIdentityMissingComposer(t *testing.T) {
 	}
 }
 
+func TestContractCreateIdentityMissingComposerIgnoresGitUser(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("GIT_CONFIG_GLOBAL", os.DevNull)
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+	root := t.TempDir()
+	for _, args := range [][]string{{"init", "-q"}, {"config", "user.name", "Server Host"}} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = root
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+
+	// The defaults who serve builds for root.
+	defaults, err := identity.LoadConfig(root)
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+	srv := &Server{Root: root, Defaults: defaults}
+	req := validCreateReq(filepath.Join("holons", "sophia-contract"))
+	req.Composer = ""
+	_, err = srv.CreateIdentity(context.Background(), req)
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("CreateIdentity without composer = %v, want InvalidArgument, not the git user", err)
+	}
+}
+
+func TestContractCreateIdentityRejectsInvalidAliases(t *testing.T) {
+	root := t.TempDir()
+	client, cleanup := startContractMemClient(t, root)
+	defer cleanup()
+
+	for _, alias := range []string{"--force", "all", "serve", "Doctor"} {
+		req := validCreateReq(filepath.Join("holons", "bad-alias"))
+		req.Aliases = []string{alias}
+
+		_, err := client.CreateIdentity(context.Background(), req)
+		if got := status.Code(err); got != codes.InvalidArgument {
+			t.Fatalf("alias %q: status code = %v, want %v", alias, got, codes.InvalidArgument)
+		}
+	}
+
+	if _, err := os.Stat(filepath.Join(root, "holons", "bad-alias", "HOLON.md")); !os.IsNotExist(err) {
+		t.Fatal("HOLON.md must not be written when aliases are invalid")
+	}
+}
+
+func TestContractCreateIdentityRejectsEscapingOutputDir(t *testing.T) {
+	root := t.TempDir()
+	client, cleanup := startContractMemClient(t, root)
+	defer cleanup()
+
+	_, err := client.CreateIdentity(context.Background(), validCreateReq(filepath.Join("..", "escape")))
+	if got := status.Code(err); got != codes.InvalidArgument {
+		t.Fatalf("status code = %v, want %v", got, codes.InvalidArgument)
+	}
+}
+
+func TestContractCreateIdentityRejectsAbsoluteOutputDirOutsideRoot(t *testing.T) {
+	root := t.TempDir()
+	client, cleanup := startContractMemClient(t, root)
+	defer cleanup()
+
+	outside := filepath.Join(t.TempDir(), "escape")
+	_, err := client.CreateIdentity(context.Background(), validCreateReq(outside))
+	if got := status.Code(err); got != codes.InvalidArgument {
+		t.Fatalf("status code = %v, want %v", got, codes.InvalidArgument)
+	}
+	if _, err := os.Stat(outside); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("%s was created: %v", outside, err)
+	}
+
+	inside := filepath.Join(root, "holons", "sophia-contract")
+	if _, err := client.CreateIdentity(context.Background(), validCreateReq(inside)); err != nil {
+		t.Errorf("CreateIdentity(absolute path inside root) = %v", err)
+	}
+}
+
+func TestContractCreateIdentityBredParents(t *testing.T) {
+	root := t.TempDir()
+	client, cleanup := startContractMemClient(t, root)
+	defer cleanup()
+
+	req := validCreateReq(filepath.Join("holons", "bred-dup"))
+	req.Reproduction = pb.ReproductionMode_BRED
+	req.Parents = []string{"parent-a", "parent-a"}
+	_, err := client.CreateIdentity(context.Background(), req)
+	if got := status.Code(err); got != codes.InvalidArgument {
+		t.Fatalf("status code = %v, want %v", got, codes.InvalidArgument)
+	}
+	if !strings.Contains(err.Error(), "parent-a") {
+		t.Errorf("error does not name the offending parent: %v", err)
+	}
+
+	req = validCreateReq(filepath.Join("holons", "bred-ok"))
+	req.Reproduction = pb.ReproductionMode_BRED
+	req.Parents = []string{"parent-a", "parent-b"}
+	resp, err := client.CreateIdentity(context.Background(), req)
+	if err != nil {
+		t.Fatalf("CreateIdentity with distinct parents failed: %v", err)
+	}
+	if got := resp.GetIdentity().GetParents(); len(got) != 2 {
+		t.Errorf("parents = %v, want both", got)
+	}
+}
+
+func TestContractCreateIdentityAlreadyExists(t *testing.T) {
+	root := t.TempDir()
+	client, cleanup := startContractMemClient(t, root)
+	defer cleanup()
+
+	outputDir := filepath.Join("holons", "sophia-contract")
+	if _, err := client.CreateIdentity(context.Background(), validCreateReq(outputDir)); err != nil {
+		t.Fatalf("first CreateIdentity failed: %v", err)
+	}
+
+	_, err := client.CreateIdentity(context.Background(), validCreateReq(outputDir))
+	if got := status.Code(err); got != codes.AlreadyExists {
+		t.Fatalf("status code = %v, want %v", got, codes.AlreadyExists)
+	}
+}
+
+func TestContractCreateIdentityWarnsOnDirectoryMismatch(t *testing.T) {
+	root := t.TempDir()
+	client, cleanup := startContractMemClient(t, root)
+	defer cleanup()
+
+	resp, err := client.CreateIdentity(context.Background(), validCreateReq(filepath.Join("holons", "elsewhere")))
+	if err != nil {
+		t.Fatalf("CreateIdentity failed: %v", err)
+	}
+	if len(resp.GetWarnings()) != 1 {
+		t.Fatalf("warnings = %q, want exactly one", resp.GetWarnings())
+	}
+	if !strings.Contains(resp.GetWarnings()[0], "sophia-contract") {
+		t.Errorf("warning %q should mention the expected slug", resp.GetWarnings()[0])
+	}
+
+	resp, err = client.CreateIdentity(context.Background(), validCreateReq(filepath.Join("holons", "sophia-contract")))
+	if err != nil {
+		t.Fatalf("CreateIdentity failed: %v", err)
+	}
+	if len(resp.GetWarnings()) != 0 {
+		t.Errorf("warnings = %q, want none for a matching directory", resp.GetWarnings())
+	}
+}
+
+func TestContractCreateIdentityPreWriteHook(t *testing.T) {
+	orig := hookRunner
+	hookRunner = func(command string, stdin []byte) ([]byte, error) {
+		if command == "deny" {
+			return []byte("composer must be a team"), errors.New("exit status 1")
+		}
+		return nil, nil
+	}
+	t.Cleanup(func() { hookRunner = orig })
+
+	root := t.TempDir()
+	srv := &Server{Root: root, PreWriteHook: "deny"}
+	_, err := srv.CreateIdentity(context.Background(), validCreateReq(filepath.Join("holons", "denied")))
+	if status.Code(err) != codes.FailedPrecondition || !strings.Contains(err.Error(), "composer must be a team") {
+		t.Fatalf("CreateIdentity error = %v, want FailedPrecondition with the hook's reason", err)
+	}
+	if _, err := os.Stat(filepath.Join(root, "holons", "denied")); !os.IsNotExist(err) {
+		t.Errorf("rejected holon left its directory behind: %v", err)
+	}
+
+	srv.PreWriteHook = "allow"
+	resp, err := srv.CreateIdentity(context.Background(), validCreateReq(filepath.Join("holons", "allowed")))
+	if err != nil {
+		t.Fatalf("CreateIdentity with a passing hook failed: %v", err)
+	}
+	if _, err := os.Stat(resp.GetFilePath()); err != nil {
+		t.Errorf("HOLON.md not written: %v", err)
+	}
+}
+
+func TestContractCreateIdentityTrackChildren(t *testing.T) {
+	root := t.TempDir()
+	srv := &Server{Root: root, TrackChildren: true}
+	parent, err := srv.CreateIdentity(context.Background(), validCreateReq(filepath.Join("holons", "parent")))
+	if err != nil {
+		t.Fatalf("CreateIdentity(parent) failed: %v", err)
+	}
+	parentUUID := parent.GetIdentity().GetUuid()
+
+	children := make([]string, 2)
+	errs := make([]error, len(children))
+	var wg sync.WaitGroup
+	for i := range children {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			req := validCreateReq(filepath.Join("holons", fmt.Sprintf("child-%d", i)))
+			req.Parents = []string{parentUUID}
+			resp, err := srv.CreateIdentity(context.Background(), req)
+			if err == nil && len(resp.GetWarnings()) > 1 {
+				err = fmt.Errorf("warnings: %q", resp.GetWarnings())
+			}
+			children[i], errs[i] = resp.GetIdentity().GetUuid(), err
+		}()
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			t.Fatalf("CreateIdentity(child) failed: %v", err)
+		}
+	}
+
+	data, err := os.ReadFile(parent.GetFilePath())
+	if err != nil {
+		t.Fatal(err)
+	}
+	id, _, err := identity.ParseFrontmatter(data)
+	if err != nil {
+		t.Fatalf("parent no longer parses: %v", err)
+	}
+	if len(id.Children) != 2 || !slices.Contains(id.Children, children[0]) || !slices.Contains(id.Children, children[1]) {
+		t.Errorf("parent children = %v, want both %v", id.Children, children)
+	}
+
+	// Unknown parents are reported, not fatal.
+	req := validCreateReq(filepath.Join("holons", "orphan"))
+	req.Parents = []string{identity.NewID()}
+	resp, err := srv.CreateIdentity(context.Background(), req)
+	if err != nil {
+		t.Fatalf("CreateIdentity(orphan) failed: %v", err)
+	}
+	if warnings := strings.Join(resp.GetWarnings(), "\n"); !strings.Contains(warnings, "cannot record child") {
+		t.Errorf("warnings = %q, want the unknown parent reported", warnings)
+	}
+}
+
 func TestContractShowIdentityNominal(t *testing.T) {
 	root := t.TempDir()
 	client, cleanup := startContractMemClient(t, root)
@@ -230,6 +623,55 @@ func TestContractListIdentitiesNominal(t *testing.T) {
 	}
 }
 
+func TestContractListIdentitiesIncludeStats(t *testing.T) {
+	root := t.TempDir()
+	client, cleanup := startContractMemClient(t, root)
+	defer cleanup()
+
+	created, err := client.CreateIdentity(context.Background(), validCreateReq(filepath.Join("holons", "stats")))
+	if err != nil {
+		t.Fatalf("CreateIdentity: %v", err)
+	}
+	holonMD, err := os.Stat(created.GetFilePath())
+	if err != nil {
+		t.Fatal(err)
+	}
+	extra := map[string]string{
+		filepath.Join("holons", "stats", "main.go"):           "package main\n",
+		filepath.Join("holons", "stats", "docs", "README.md"): "# Stats\n",
+	}
+	wantSize := holonMD.Size()
+	for path, content := range extra {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		wantSize += int64(len(content))
+	}
+
+	resp, err := client.ListIdentities(context.Background(), &pb.ListIdentitiesRequest{IncludeStats: true})
+	if err != nil {
+		t.Fatalf("ListIdentities failed: %v", err)
+	}
+	if len(resp.GetEntries()) != 1 {
+		t.Fatalf("entries = %d, want 1", len(resp.GetEntries()))
+	}
+	entry := resp.GetEntries()[0]
+	if entry.GetDirFileCount() != 3 || entry.GetDirSizeBytes() != wantSize {
+		t.Errorf("stats = %d files, %d bytes; want 3 files, %d bytes", entry.GetDirFileCount(), entry.GetDirSizeBytes(), wantSize)
+	}
+
+	plain, err := client.ListIdentities(context.Background(), &pb.ListIdentitiesRequest{})
+	if err != nil {
+		t.Fatalf("ListIdentities failed: %v", err)
+	}
+	if e := plain.GetEntries()[0]; e.GetDirFileCount() != 0 || e.GetDirSizeBytes() != 0 {
+		t.Errorf("stats filled without include_stats: %+v", e)
+	}
+}
+
 func TestContractListIdentitiesEmptyDirectory(t *testing.T) {
 	root := t.TempDir()
 	if err := os.MkdirAll(filepath.Join(root, "empty"), 0755); err != nil {
@@ -247,3 +689,377 @@ func TestContractListIdentitiesEmptyDirectory(t *testing.T) {
 		t.Fatalf("entries = %d, want 0", len(resp.GetEntries()))
 	}
 }
+
+func TestContractCountIdentities(t *testing.T) {
+	root := t.TempDir()
+	seed := []struct{ name, clade, status string }{
+		{"alpha", "deterministic/pure", "draft"},
+		{"beta", "deterministic/pure", "stable"},
+		{"gamma", "probabilistic/generative", "stable"},
+		{"delta", "probabilistic/generative", "dead"},
+	}
+	for _, h := range seed {
+		id := identity.New()
+		id.GivenName = h.name
+		id.FamilyName = "Count"
+		id.Clade = h.clade
+		id.Status = h.status
+		path := filepath.Join(root, "holons", h.name, "HOLON.md")
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := identity.WriteHolonMD(id, path); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	client, cleanup := startContractMemClient(t, root)
+	defer cleanup()
+
+	resp, err := client.CountIdentities(context.Background(), &pb.CountIdentitiesRequest{RootDir: "holons"})
+	if err != nil {
+		t.Fatalf("CountIdentities failed: %v", err)
+	}
+	if resp.GetTotal() != 4 {
+		t.Fatalf("total = %d, want 4", resp.GetTotal())
+	}
+	wantStatus := map[string]int32{"draft": 1, "stable": 2, "dead": 1}
+	for st, n := range wantStatus {
+		if got := resp.GetByStatus()[st]; got != n {
+			t.Errorf("by_status[%q] = %d, want %d", st, got, n)
+		}
+	}
+	if got := resp.GetByClade()["probabilistic/generative"]; got != 2 {
+		t.Errorf("by_clade[probabilistic/generative] = %d, want 2", got)
+	}
+
+	filtered, err := client.CountIdentities(context.Background(), &pb.CountIdentitiesRequest{RootDir: "holons", Status: "stable"})
+	if err != nil {
+		t.Fatalf("CountIdentities(status=stable) failed: %v", err)
+	}
+	if filtered.GetTotal() != 2 || len(filtered.GetByStatus()) != 1 {
+		t.Fatalf("filtered = %+v, want 2 stable holons only", filtered)
+	}
+}
+
+func TestContractValidateContent(t *testing.T) {
+	root := t.TempDir()
+	client, cleanup := startContractMemClient(t, root)
+	defer cleanup()
+
+	valid := "---\nuuid: \"01J9ZQ3V5X8K2M4N6P7R9S0T1V\"\ngiven_name: \"Valid\"\nfamily_name: \"Content\"\nmotto: \"Ok.\"\ncomposer: \"Test\"\nstatus: draft\n---\n"
+	resp, err := client.ValidateContent(context.Background(), &pb.ValidateContentRequest{RawContent: valid})
+	if err != nil {
+		t.Fatalf("ValidateContent failed: %v", err)
+	}
+	if !resp.GetValid() || len(resp.GetErrors()) != 0 {
+		t.Fatalf("valid content reported invalid: %+v", resp.GetErrors())
+	}
+
+	invalid := "---\nuuid: \"vc-2\"\ngiven_name: \"\"\nfamily_name: \"Content\"\nmotto: \"Ok.\"\ncomposer: \"Test\"\nstatus: zombie\n---\n"
+	resp, err = client.ValidateContent(context.Background(), &pb.ValidateContentRequest{RawContent: invalid})
+	if err != nil {
+		t.Fatalf("ValidateContent failed: %v", err)
+	}
+	if resp.GetValid() {
+		t.Fatal("invalid content reported valid")
+	}
+
+	byField := map[string]*pb.ValidationError{}
+	for _, e := range resp.GetErrors() {
+		byField[e.GetField()] = e
+	}
+	if e := byField["given_name"]; e == nil || e.GetLine() != 3 {
+		t.Errorf("given_name error = %v, want one on line 3", e)
+	}
+	if e := byField["status"]; e == nil || e.GetLine() != 7 {
+		t.Errorf("status error = %v, want one on line 7", e)
+	}
+
+	if _, err := os.Stat(filepath.Join(root, "holons")); !os.IsNotExist(err) {
+		t.Error("ValidateContent must not write anything")
+	}
+}
+
+func TestContractCreateIdentityAppendsAuditEntry(t *testing.T) {
+	root := t.TempDir()
+	client, cleanup := startContractMemClient(t, root)
+	defer cleanup()
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), callerMetadataKey, "ci-bot")
+	resp, err := client.CreateIdentity(ctx, validCreateReq(filepath.Join("holons", "sophia-contract")))
+	if err != nil {
+		t.Fatalf("CreateIdentity failed: %v", err)
+	}
+
+	entries, err := identity.ReadAudit(root)
+	if err != nil {
+		t.Fatalf("ReadAudit failed: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("audit entries = %d, want 1", len(entries))
+	}
+	e := entries[0]
+	if e.Op != identity.AuditCreate || e.UUID != resp.GetIdentity().GetUuid() {
+		t.Errorf("entry = %+v, want a create for %s", e, resp.GetIdentity().GetUuid())
+	}
+	if e.Caller != "ci-bot" || e.Composer != resp.GetIdentity().GetComposer() {
+		t.Errorf("entry caller/composer = %q/%q", e.Caller, e.Composer)
+	}
+}
+
+func TestContractCreateIdentitySucceedsWhenAuditFails(t *testing.T) {
+	root := t.TempDir()
+	// A file where the log directory should be makes every append fail.
+	if err := os.WriteFile(filepath.Join(root, ".holon"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	client, cleanup := startContractMemClient(t, root)
+	defer cleanup()
+
+	if _, err := client.CreateIdentity(context.Background(), validCreateReq(filepath.Join("holons", "sophia-contract"))); err != nil {
+		t.Fatalf("CreateIdentity failed because of the audit log: %v", err)
+	}
+}
+
+func TestContractListHolonFiles(t *testing.T) {
+	root := t.TempDir()
+	client, cleanup := startContractMemClient(t, root)
+	defer cleanup()
+
+	created, err := client.CreateIdentity(context.Background(), validCreateReq(filepath.Join("holons", "sophia-contract")))
+	if err != nil {
+		t.Fatalf("CreateIdentity failed: %v", err)
+	}
+	dir := filepath.Dir(created.GetFilePath())
+	for name, content := range map[string]string{
+		"main.go":                    "package main\n",
+		"protos/v1/service.proto":    "syntax = \"proto3\";\n",
+		"child/HOLON.md":             "---\nuuid: \"nested\"\n---\n",
+		"child/belongs-to-child.txt": "not ours\n",
+	} {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	list := func(req *pb.ListHolonFilesRequest) map[string]int64 {
+		t.Helper()
+		req.Uuid = created.GetIdentity().GetUuid()
+		resp, err := client.ListHolonFiles(context.Background(), req)
+		if err != nil {
+			t.Fatalf("ListHolonFiles failed: %v", err)
+		}
+		if resp.GetDirectory() != dir {
+			t.Errorf("directory = %q, want %q", resp.GetDirectory(), dir)
+		}
+		files := map[string]int64{}
+		for _, f := range resp.GetFiles() {
+			files[f.GetName()] = f.GetSizeBytes()
+		}
+		return files
+	}
+
+	top := list(&pb.ListHolonFilesRequest{})
+	if len(top) != 2 || top["main.go"] != int64(len("package main\n")) || top["HOLON.md"] == 0 {
+		t.Errorf("top-level files = %v, want HOLON.md and main.go with their sizes", top)
+	}
+
+	all := list(&pb.ListHolonFilesRequest{Recursive: true, ExcludeHolonMd: true})
+	want := map[string]bool{"main.go": true, "protos/v1/service.proto": true}
+	if len(all) != len(want) {
+		t.Errorf("recursive files = %v, want %v without the nested holon", all, want)
+	}
+	for name := range want {
+		if _, ok := all[name]; !ok {
+			t.Errorf("recursive listing is missing %s: %v", name, all)
+		}
+	}
+
+	if _, err := client.ListHolonFiles(context.Background(), &pb.ListHolonFilesRequest{Uuid: "no-such-holon"}); status.Code(err) != codes.NotFound {
+		t.Errorf("unknown holon: err = %v, want NotFound", err)
+	}
+}
+
+func TestContractValidateAll(t *testing.T) {
+	root := t.TempDir()
+	client, cleanup := startContractMemClient(t, root)
+	defer cleanup()
+
+	if _, err := client.CreateIdentity(context.Background(), validCreateReq(filepath.Join("holons", "valid"))); err != nil {
+		t.Fatalf("CreateIdentity failed: %v", err)
+	}
+	invalid := filepath.Join(root, "holons", "invalid", "HOLON.md")
+	if err := os.MkdirAll(filepath.Dir(invalid), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(invalid, []byte("---\nuuid: \"\"\ngiven_name: \"Broken\"\n---\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	stream, err := client.ValidateAll(context.Background(), &pb.ValidateAllRequest{})
+	if err != nil {
+		t.Fatalf("ValidateAll failed: %v", err)
+	}
+	files := map[string]*pb.FileValidation{}
+	var last *pb.ValidationProgress
+	for {
+		msg, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Recv failed: %v", err)
+		}
+		switch r := msg.GetResult().(type) {
+		case *pb.ValidationResult_File:
+			if last != nil {
+				t.Errorf("file result %s after the final progress", r.File.GetPath())
+			}
+			files[filepath.ToSlash(r.File.GetPath())] = r.File
+		case *pb.ValidationResult_Progress:
+			last = r.Progress
+		}
+	}
+
+	if v := files["holons/valid/HOLON.md"]; v == nil || !v.GetOk() || len(v.GetErrors()) != 0 {
+		t.Errorf("valid holon result = %v, want ok", v)
+	}
+	v := files["holons/invalid/HOLON.md"]
+	if v == nil || v.GetOk() {
+		t.Fatalf("invalid holon result = %v, want not ok", v)
+	}
+	fields := map[string]bool{}
+	for _, fe := range v.GetErrors() {
+		fields[fe.GetField()] = true
+	}
+	if !fields["uuid"] || !fields["family_name"] {
+		t.Errorf("invalid holon errors = %v, want uuid and family_name among them", v.GetErrors())
+	}
+	if len(files) != 2 {
+		t.Errorf("got results for %d files, want 2: %v", len(files), files)
+	}
+	if last.GetCheckedFiles() != 2 || last.GetInvalidFiles() != 1 || last.GetScannedFiles() < 2 {
+		t.Errorf("final progress = %v, want 2 checked, 1 invalid", last)
+	}
+}
+
+func TestContractUpdateIdentityProtoStatusTransitions(t *testing.T) {
+	root := t.TempDir()
+	client, cleanup := startContractMemClient(t, root)
+	defer cleanup()
+
+	created, err := client.CreateIdentity(context.Background(), validCreateReq(filepath.Join("holons", "contract")))
+	if err != nil {
+		t.Fatalf("CreateIdentity failed: %v", err)
+	}
+	uuid := created.GetIdentity().GetUuid()
+	setProtoStatus := func(st pb.Status) (*pb.UpdateIdentityResponse, error) {
+		return client.UpdateIdentity(context.Background(), &pb.UpdateIdentityRequest{
+			Uuid:       uuid,
+			Identity:   &pb.HolonIdentity{ProtoStatus: st},
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"proto_status"}},
+		})
+	}
+
+	resp, err := setProtoStatus(pb.Status_STABLE)
+	if err != nil {
+		t.Fatalf("draft → stable: %v", err)
+	}
+	if got := resp.GetIdentity().GetProtoStatus(); got != pb.Status_STABLE {
+		t.Errorf("proto_status = %v, want STABLE", got)
+	}
+
+	_, err = setProtoStatus(pb.Status_DRAFT)
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("stable → draft: err = %v, want FailedPrecondition", err)
+	}
+	// Nor backward in two steps, through an unset proto_status.
+	_, err = setProtoStatus(pb.Status_STATUS_UNSPECIFIED)
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("stable → unset: err = %v, want FailedPrecondition", err)
+	}
+	data, err := os.ReadFile(created.GetFilePath())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `proto_status: "stable"`) {
+		t.Errorf("a rejected transition rewrote the file:\n%s", data)
+	}
+}
+
+func TestContractUpdateIdentityErrorCodes(t *testing.T) {
+	root := t.TempDir()
+	client, cleanup := startContractMemClient(t, root)
+	defer cleanup()
+
+	update := func(uuid string) error {
+		_, err := client.UpdateIdentity(context.Background(), &pb.UpdateIdentityRequest{
+			Uuid:       uuid,
+			Identity:   &pb.HolonIdentity{Motto: "Renamed."},
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"motto"}},
+		})
+		return err
+	}
+
+	if err := update("no-such-holon"); status.Code(err) != codes.NotFound {
+		t.Errorf("unknown uuid: err = %v, want NotFound", err)
+	}
+
+	if os.Geteuid() == 0 {
+		t.Skip("file permissions are not enforced for root")
+	}
+	created, err := client.CreateIdentity(context.Background(), validCreateReq(filepath.Join("holons", "locked")))
+	if err != nil {
+		t.Fatalf("CreateIdentity failed: %v", err)
+	}
+	dir := filepath.Join(root, filepath.Dir(created.GetFilePath()))
+	if err := os.Chmod(dir, 0555); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chmod(dir, 0755) })
+	if err := update(created.GetIdentity().GetUuid()); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("read-only holon directory: err = %v, want PermissionDenied", err)
+	}
+}
+
+func TestContractReparent(t *testing.T) {
+	root := t.TempDir()
+	client, cleanup := startContractMemClient(t, root)
+	defer cleanup()
+
+	create := func(dir string) *pb.HolonIdentity {
+		t.Helper()
+		resp, err := client.CreateIdentity(context.Background(), validCreateReq(filepath.Join("holons", dir)))
+		if err != nil {
+			t.Fatalf("CreateIdentity failed: %v", err)
+		}
+		return resp.GetIdentity()
+	}
+	parent := create("parent")
+	child := create("child")
+
+	resp, err := client.Reparent(context.Background(), &pb.ReparentRequest{Uuid: child.GetUuid(), Parents: []string{parent.GetUuid()}})
+	if err != nil {
+		t.Fatalf("Reparent failed: %v", err)
+	}
+	if got := resp.GetIdentity().GetParents(); len(got) != 1 || got[0] != parent.GetUuid() {
+		t.Errorf("parents = %v, want [%s]", got, parent.GetUuid())
+	}
+	if len(resp.GetPreviousParents()) != 0 {
+		t.Errorf("previous parents = %v, want none", resp.GetPreviousParents())
+	}
+
+	_, err = client.Reparent(context.Background(), &pb.ReparentRequest{Uuid: parent.GetUuid(), Parents: []string{child.GetUuid()}})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("cycle-inducing reparent: err = %v, want FailedPrecondition", err)
+	}
+	_, err = client.Reparent(context.Background(), &pb.ReparentRequest{Uuid: child.GetUuid(), Parents: []string{"no-such-parent"}})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("unknown parent: err = %v, want InvalidArgument", err)
+	}
+}
@@ -0,0 +1,36 @@
+package server
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// fdScheme serves on a listening socket inherited from the parent
+// process, e.g. fd://3 under systemd socket activation. The parent keeps
+// the socket bound across restarts, so no connection is refused while
+// the server comes back up.
+const fdScheme = "fd://"
+
+// listenFD wraps the inherited descriptor of an fd://<n> URI in a
+// net.Listener. The listener owns a duplicate; the original is closed.
+func listenFD(listenURI string) (net.Listener, error) {
+	n, err := strconv.Atoi(strings.TrimPrefix(listenURI, fdScheme))
+	if err != nil || n < 0 {
+		return nil, fmt.Errorf("expected a file descriptor number, e.g. %s3", fdScheme)
+	}
+
+	f := os.NewFile(uintptr(n), listenURI)
+	if f == nil {
+		return nil, fmt.Errorf("invalid file descriptor %d", n)
+	}
+	defer f.Close()
+
+	lis, err := net.FileListener(f)
+	if err != nil {
+		return nil, fmt.Errorf("file descriptor %d is not a listening socket: %w", n, err)
+	}
+	return lis, nil
+}
@@ -0,0 +1,89 @@
+//go:build unix
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	pb "github.com/organic-programming/sophia-who/gen/go/sophia_who/v1"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+func TestListenFDServesInheritedSocket(t *testing.T) {
+	root := t.TempDir()
+	seedHolon(t, root, "fd-uuid-1", "Inherited")
+
+	// Stand in for the service manager: bind a socket and hand its
+	// descriptor over as if it had been inherited.
+	bound, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := bound.Addr().String()
+	f, err := bound.(*net.TCPListener).File()
+	if err != nil {
+		t.Fatal(err)
+	}
+	bound.Close()
+
+	lis, err := Listen(fmt.Sprintf("fd://%d", inherit(t, f)))
+	if err != nil {
+		t.Fatalf("Listen fd: %v", err)
+	}
+	s := newGRPCServer(Options{Root: root})
+	go func() { _ = s.Serve(lis) }()
+	defer s.Stop()
+
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	resp, err := pb.NewSophiaWhoServiceClient(conn).ShowIdentity(ctx, &pb.ShowIdentityRequest{Uuid: "fd-uuid-1"})
+	if err != nil {
+		t.Fatalf("ShowIdentity over fd://: %v", err)
+	}
+	if resp.GetIdentity().GetGivenName() != "Inherited" {
+		t.Errorf("given_name = %q, want %q", resp.GetIdentity().GetGivenName(), "Inherited")
+	}
+}
+
+func TestListenFDRejectsNonSockets(t *testing.T) {
+	if _, err := Listen("fd://three"); err == nil || !strings.Contains(err.Error(), "fd://three") {
+		t.Errorf("Listen(fd://three) error = %v, want one naming the URI", err)
+	}
+
+	pipeR, pipeW, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pipeW.Close()
+	if _, err := Listen(fmt.Sprintf("fd://%d", inherit(t, pipeR))); err == nil || !strings.Contains(err.Error(), "not a listening socket") {
+		t.Errorf("Listen on a pipe error = %v, want a not-a-socket error", err)
+	}
+}
+
+// inherit returns a duplicate of f's descriptor that no *os.File owns,
+// as a descriptor inherited from a parent process would be, and closes f.
+// listenFD takes ownership of the duplicate.
+func inherit(t *testing.T, f *os.File) int {
+	t.Helper()
+	fd, err := syscall.Dup(int(f.Fd()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+	return fd
+}
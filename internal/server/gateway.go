@@ -0,0 +1,171 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+
+	pb "github.com/organic-programming/sophia-who/gen/go/sophia_who/v1"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// newGateway returns the HTTP/JSON gateway for s. It is served next to
+// gRPC on h2c:// listeners, under the same opts.Limits and opts.Tracing:
+// each route is admitted by lim, shared with the gRPC server, as the
+// RPC it maps to.
+//
+//	PATCH /v1/identities/{uuid}   JSON merge patch (RFC 7386) → UpdateIdentity
+func newGateway(s *Server, opts Options, lim *limiter) http.Handler {
+	mux := http.NewServeMux()
+	const patchRoute = "PATCH /v1/identities/{uuid}"
+	mux.HandleFunc(patchRoute, opts.Tracing.httpHandler(patchRoute, func(w http.ResponseWriter, r *http.Request) {
+		release, err := lim.admit(pb.SophiaWhoService_UpdateIdentity_FullMethodName)
+		if err != nil {
+			writeGatewayError(w, err)
+			return
+		}
+		defer release()
+		patchIdentity(s, w, r, opts.Limits.maxRequestBytes())
+	}))
+	return mux
+}
+
+// patchIdentity applies a JSON merge patch keyed by frontmatter field
+// names: a value sets the field, null clears it, and absent fields are
+// left unchanged. Enum fields take their HOLON.md spelling, e.g.
+// {"clade": "probabilistic/generative"}. The body may be at most
+// maxBytes long.
+func patchIdentity(s *Server, w http.ResponseWriter, r *http.Request, maxBytes int64) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBytes))
+	if err != nil {
+		writeGatewayError(w, status.Errorf(codes.InvalidArgument, "read body: %v", err))
+		return
+	}
+	var patch map[string]json.RawMessage
+	if err := json.Unmarshal(body, &patch); err != nil {
+		writeGatewayError(w, status.Errorf(codes.InvalidArgument, "body must be a JSON object: %v", err))
+		return
+	}
+
+	req := &pb.UpdateIdentityRequest{
+		Uuid:       r.PathValue("uuid"),
+		Identity:   &pb.HolonIdentity{},
+		UpdateMask: &fieldmaskpb.FieldMask{},
+	}
+	for field, raw := range patch {
+		if err := applyPatchField(req.Identity, field, raw); err != nil {
+			writeGatewayError(w, status.Error(codes.InvalidArgument, err.Error()))
+			return
+		}
+		req.UpdateMask.Paths = append(req.UpdateMask.Paths, field)
+	}
+	slices.Sort(req.UpdateMask.Paths)
+
+	resp, err := s.UpdateIdentity(r.Context(), req)
+	if err != nil {
+		writeGatewayError(w, err)
+		return
+	}
+	out, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(resp)
+	if err != nil {
+		writeGatewayError(w, status.Errorf(codes.Internal, "encode response: %v", err))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(out) //nolint:errcheck
+}
+
+// applyPatchField sets one merge patch member on dst. JSON null leaves
+// dst's zero value in place, which UpdateIdentity treats as "clear".
+func applyPatchField(dst *pb.HolonIdentity, field string, raw json.RawMessage) error {
+	if string(raw) == "null" {
+		return nil
+	}
+
+	var list []string
+	if field == "parents" || field == "aliases" || field == "tags" {
+		if err := json.Unmarshal(raw, &list); err != nil {
+			return fmt.Errorf("%s: want a list of strings", field)
+		}
+		switch field {
+		case "parents":
+			dst.Parents = list
+		case "aliases":
+			dst.Aliases = list
+		default:
+			dst.Tags = list
+		}
+		return nil
+	}
+
+	var v string
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return fmt.Errorf("%s: want a string", field)
+	}
+	switch field {
+	case "given_name":
+		dst.GivenName = v
+	case "family_name":
+		dst.FamilyName = v
+	case "motto":
+		dst.Motto = v
+	case "composer":
+		dst.Composer = v
+	case "born":
+		dst.Born = v
+	case "died":
+		dst.Died = v
+	case "lang":
+		dst.Lang = v
+	case "generated_by":
+		dst.GeneratedBy = v
+	case "clade":
+		if dst.Clade = stringToClade(v); dst.Clade == pb.Clade_CLADE_UNSPECIFIED && v != "" {
+			return fmt.Errorf("unknown clade %q", v)
+		}
+	case "reproduction":
+		if dst.Reproduction = stringToReproduction(v); dst.Reproduction == pb.ReproductionMode_REPRODUCTION_UNSPECIFIED && v != "" {
+			return fmt.Errorf("unknown reproduction mode %q", v)
+		}
+	case "status", "proto_status":
+		// Any other value is a custom status, left for UpdateIdentity
+		// to check against the configured ones.
+		st := stringToStatus(v)
+		if field == "status" {
+			dst.Status, dst.CustomStatus = st, customStatus(v)
+		} else {
+			dst.ProtoStatus, dst.CustomProtoStatus = st, customStatus(v)
+		}
+	default:
+		// Let UpdateIdentity reject uuid and unknown fields uniformly.
+	}
+	return nil
+}
+
+// writeGatewayError maps a gRPC status to the matching HTTP status and
+// writes its message as a JSON error body.
+func writeGatewayError(w http.ResponseWriter, err error) {
+	st := status.Convert(err)
+	code := http.StatusInternalServerError
+	switch st.Code() {
+	case codes.InvalidArgument:
+		code = http.StatusBadRequest
+	case codes.NotFound:
+		code = http.StatusNotFound
+	case codes.AlreadyExists:
+		code = http.StatusConflict
+	case codes.PermissionDenied:
+		code = http.StatusForbidden
+	case codes.ResourceExhausted:
+		code = http.StatusTooManyRequests
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(map[string]string{"error": st.Message()}) //nolint:errcheck
+}
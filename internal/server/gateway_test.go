@@ -0,0 +1,149 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/organic-programming/sophia-who/pkg/identity"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func patch(t *testing.T, url, body string) *http.Response {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodPatch, url, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", "application/merge-patch+json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	return resp
+}
+
+func readIdentity(t *testing.T, path string) (identity.Identity, string) {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	id, body, err := identity.ParseFrontmatter(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return id, body
+}
+
+func TestGatewayPatchChangesOnlyMotto(t *testing.T) {
+	root := t.TempDir()
+	seedHolon(t, root, "9a7c0000-0000-4000-8000-000000000001", "Patchy")
+	path := filepath.Join(root, "Patchy", "HOLON.md")
+	before, beforeBody := readIdentity(t, path)
+
+	ts := httptest.NewServer(newGateway(&Server{Root: root}, Options{}, nil))
+	defer ts.Close()
+
+	resp := patch(t, ts.URL+"/v1/identities/9a7c0000-0000-4000-8000-000000000001", `{"motto": "Only this changes."}`)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("PATCH status = %d, want 200", resp.StatusCode)
+	}
+
+	after, afterBody := readIdentity(t, path)
+	if after.Motto != "Only this changes." {
+		t.Errorf("motto = %q, want the patched value", after.Motto)
+	}
+	after.Motto = before.Motto
+	if !reflect.DeepEqual(after, before) {
+		t.Errorf("other fields changed:\nbefore %+v\nafter  %+v", before, after)
+	}
+	if afterBody != beforeBody {
+		t.Errorf("body changed:\nbefore %q\nafter  %q", beforeBody, afterBody)
+	}
+}
+
+func TestGatewayPatchNullClears(t *testing.T) {
+	root := t.TempDir()
+	seedHolon(t, root, "9a7c0000-0000-4000-8000-000000000002", "Nully")
+	ts := httptest.NewServer(newGateway(&Server{Root: root}, Options{}, nil))
+	defer ts.Close()
+
+	resp := patch(t, ts.URL+"/v1/identities/9a7c0000-0000-4000-8000-000000000002", `{"lang": null, "clade": "probabilistic/adaptive"}`)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("PATCH status = %d, want 200", resp.StatusCode)
+	}
+	id, _ := readIdentity(t, filepath.Join(root, "Nully", "HOLON.md"))
+	if id.Lang != "" || id.Clade != "probabilistic/adaptive" {
+		t.Errorf("lang = %q, clade = %q; want cleared lang and the new clade", id.Lang, id.Clade)
+	}
+}
+
+func TestGatewayPatchErrors(t *testing.T) {
+	root := t.TempDir()
+	seedHolon(t, root, "9a7c0000-0000-4000-8000-000000000003", "Stubborn")
+	ts := httptest.NewServer(newGateway(&Server{Root: root}, Options{}, nil))
+	defer ts.Close()
+
+	tests := []struct {
+		name, uuid, body string
+		want             int
+	}{
+		{"immutable uuid", "9a7c0000-0000-4000-8000-000000000003", `{"uuid": "other"}`, http.StatusBadRequest},
+		{"required field cleared", "9a7c0000-0000-4000-8000-000000000003", `{"motto": null}`, http.StatusBadRequest},
+		{"unknown clade", "9a7c0000-0000-4000-8000-000000000003", `{"clade": "quantum"}`, http.StatusBadRequest},
+		{"not an object", "9a7c0000-0000-4000-8000-000000000003", `["motto"]`, http.StatusBadRequest},
+		{"unknown holon", "nope", `{"motto": "x"}`, http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		if resp := patch(t, ts.URL+"/v1/identities/"+tt.uuid, tt.body); resp.StatusCode != tt.want {
+			t.Errorf("%s: status = %d, want %d", tt.name, resp.StatusCode, tt.want)
+		}
+	}
+}
+
+func TestGatewayPatchHonoursLimitsAndTracing(t *testing.T) {
+	root := t.TempDir()
+	const uuid = "9a7c0000-0000-4000-8000-000000000004"
+	seedHolon(t, root, uuid, "Limited")
+
+	exporter := tracetest.NewInMemoryExporter()
+	tracing := Tracing{TracerProvider: sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))}
+	defer tracing.Shutdown(context.Background())
+
+	opts := Options{Limits: Limits{Rate: 1, Burst: 1, MaxRequestBytes: 64}, Tracing: tracing}
+	ts := httptest.NewServer(newGateway(&Server{Root: root}, opts, opts.Limits.rateLimiter()))
+	defer ts.Close()
+
+	url := ts.URL + "/v1/identities/" + uuid
+	if resp := patch(t, url, `{"motto": "First one passes."}`); resp.StatusCode != http.StatusOK {
+		t.Fatalf("first PATCH status = %d, want 200", resp.StatusCode)
+	}
+	if resp := patch(t, url, `{"motto": "Second one waits."}`); resp.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("second PATCH status = %d, want 429", resp.StatusCode)
+	}
+
+	spans := spansNamed(t, exporter, "PATCH /v1/identities/{uuid}")
+	if len(spans) != 2 {
+		t.Fatalf("got %d gateway spans, want 2", len(spans))
+	}
+	if got := attr(spans[1].Attributes, "http.response.status_code").AsInt64(); got != http.StatusTooManyRequests {
+		t.Errorf("refused request span status code = %d, want 429", got)
+	}
+
+	// Past the rate limit, the body cap is checked on a fresh gateway.
+	opts = Options{Limits: Limits{MaxRequestBytes: 64}}
+	capped := httptest.NewServer(newGateway(&Server{Root: root}, opts, nil))
+	defer capped.Close()
+	if resp := patch(t, capped.URL+"/v1/identities/"+uuid, `{"motto": "`+strings.Repeat("x", 64)+`"}`); resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("oversized PATCH status = %d, want 400", resp.StatusCode)
+	}
+}
@@ -0,0 +1,62 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+	"google.golang.org/grpc"
+)
+
+// h2cScheme selects plaintext HTTP/2 (h2c) on a TCP port. Unlike tcp://,
+// the port is served by net/http, so gRPC can share it with other HTTP
+// handlers behind proxies that only speak HTTP/2.
+const h2cScheme = "h2c://"
+
+// listenH2C opens the TCP listener for an h2c://<host>:<port> URI.
+func listenH2C(listenURI string) (net.Listener, error) {
+	return net.Listen("tcp", strings.TrimPrefix(listenURI, h2cScheme))
+}
+
+// newH2CHandler serves gRPC requests with s over cleartext HTTP/2 and
+// answers every other request with fallback (404 when nil).
+func newH2CHandler(s *grpc.Server, fallback http.Handler) http.Handler {
+	if fallback == nil {
+		fallback = http.NotFoundHandler()
+	}
+	mux := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.ProtoMajor == 2 && strings.HasPrefix(r.Header.Get("Content-Type"), "application/grpc") {
+			s.ServeHTTP(w, r)
+			return
+		}
+		fallback.ServeHTTP(w, r)
+	})
+	return h2c.NewHandler(mux, &http2.Server{})
+}
+
+// serveH2C serves s over h2c on lis, with every non-gRPC request going
+// to fallback (the HTTP gateway), until the listener is closed or ctx is
+// done. On ctx it shuts down gracefully and returns nil.
+func serveH2C(ctx context.Context, lis net.Listener, s *grpc.Server, fallback http.Handler) error {
+	srv := &http.Server{Handler: newH2CHandler(s, fallback)}
+
+	ctx, cancel := context.WithCancel(ctx)
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		<-ctx.Done()
+		_ = srv.Shutdown(context.Background())
+		s.GracefulStop()
+	}()
+	err := srv.Serve(lis)
+	cancel()
+	<-stopped
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+	return err
+}
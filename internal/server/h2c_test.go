@@ -0,0 +1,60 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	pb "github.com/organic-programming/sophia-who/gen/go/sophia_who/v1"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+func TestH2CServesGRPC(t *testing.T) {
+	root := t.TempDir()
+	seedHolon(t, root, "h2c-uuid-1", "Cleartext")
+
+	lis, err := Listen("h2c://127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen h2c: %v", err)
+	}
+	s := newGRPCServer(Options{Root: root})
+	go func() { _ = serveH2C(context.Background(), lis, s, nil) }()
+	defer lis.Close()
+
+	conn, err := grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	resp, err := pb.NewSophiaWhoServiceClient(conn).ShowIdentity(ctx, &pb.ShowIdentityRequest{Uuid: "h2c-uuid-1"})
+	if err != nil {
+		t.Fatalf("ShowIdentity over h2c: %v", err)
+	}
+	if resp.GetIdentity().GetGivenName() != "Cleartext" {
+		t.Errorf("given_name = %q, want %q", resp.GetIdentity().GetGivenName(), "Cleartext")
+	}
+
+	// Plain HTTP requests fall through to the (for now empty) fallback.
+	httpResp, err := http.Get("http://" + lis.Addr().String() + "/")
+	if err != nil {
+		t.Fatalf("GET over h2c listener: %v", err)
+	}
+	httpResp.Body.Close()
+	if httpResp.StatusCode != http.StatusNotFound {
+		t.Errorf("GET status = %d, want 404", httpResp.StatusCode)
+	}
+}
+
+func TestListenRejectsBadH2CAddress(t *testing.T) {
+	_, err := Listen("h2c://not-a-host:port")
+	if err == nil || !strings.Contains(err.Error(), "h2c://") {
+		t.Fatalf("Listen error = %v, want one naming the URI", err)
+	}
+}
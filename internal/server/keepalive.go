@@ -0,0 +1,75 @@
+package server
+
+import (
+	"math"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/keepalive"
+)
+
+// Default keepalive settings. Pinging idle clients every minute keeps
+// long-lived connections open through NAT gateways and load balancers,
+// which commonly drop flows silent for a few minutes.
+const (
+	DefaultKeepaliveTime      = time.Minute
+	DefaultKeepaliveTimeout   = 20 * time.Second
+	DefaultKeepaliveMinTime   = 10 * time.Second
+	DefaultMaxConnectionIdle  = 15 * time.Minute
+	DefaultConnectionTimeout  = 20 * time.Second
+	keepaliveDisabledDuration = time.Duration(math.MaxInt64)
+)
+
+// Keepalive configures connection health checking. Zero or negative
+// fields use the Default* values above, except that a negative Time or
+// MaxConnectionIdle disables server pings or idle closing.
+type Keepalive struct {
+	// Time is how long a connection may go without activity before the
+	// server pings the client.
+	Time time.Duration
+
+	// Timeout is how long the server waits for a ping ack before closing
+	// the connection.
+	Timeout time.Duration
+
+	// MinTime is the shortest interval at which clients may send pings;
+	// clients pinging more often are disconnected. Clients may ping even
+	// when they have no active stream.
+	MinTime time.Duration
+
+	// MaxConnectionIdle closes connections that have had no active RPC
+	// or stream for this long. Open streams keep a connection alive.
+	MaxConnectionIdle time.Duration
+
+	// ConnectionTimeout bounds connection setup, including the HTTP/2
+	// handshake.
+	ConnectionTimeout time.Duration
+}
+
+// serverOptions returns the gRPC options applying k.
+func (k Keepalive) serverOptions() []grpc.ServerOption {
+	or := func(d, def time.Duration) time.Duration {
+		if d <= 0 {
+			return def
+		}
+		return d
+	}
+	orNever := func(d, def time.Duration) time.Duration {
+		if d < 0 {
+			return keepaliveDisabledDuration
+		}
+		return or(d, def)
+	}
+	return []grpc.ServerOption{
+		grpc.KeepaliveParams(keepalive.ServerParameters{
+			Time:              orNever(k.Time, DefaultKeepaliveTime),
+			Timeout:           or(k.Timeout, DefaultKeepaliveTimeout),
+			MaxConnectionIdle: orNever(k.MaxConnectionIdle, DefaultMaxConnectionIdle),
+		}),
+		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			MinTime:             or(k.MinTime, DefaultKeepaliveMinTime),
+			PermitWithoutStream: true,
+		}),
+		grpc.ConnectionTimeout(or(k.ConnectionTimeout, DefaultConnectionTimeout)),
+	}
+}
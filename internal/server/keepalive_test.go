@@ -0,0 +1,82 @@
+package server
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/credentials/insecure"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestKeepaliveIdleStreamStaysOpen(t *testing.T) {
+	const idle = 100 * time.Millisecond
+
+	lis := bufconn.Listen(bufSize)
+	srv := newGRPCServer(Options{Reflect: true, Keepalive: Keepalive{MaxConnectionIdle: idle}})
+	go func() { _ = srv.Serve(lis) }()
+	defer srv.Stop()
+
+	dial := func() *grpc.ClientConn {
+		t.Helper()
+		conn, err := grpc.NewClient(
+			"passthrough:///bufnet",
+			grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+				return lis.DialContext(ctx)
+			}),
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return conn
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// Reflection is a bidirectional stream; hold one open and silent
+	// for several idle windows.
+	streaming := dial()
+	defer streaming.Close()
+	stream, err := reflectionpb.NewServerReflectionClient(streaming).ServerReflectionInfo(ctx)
+	if err != nil {
+		t.Fatalf("open stream: %v", err)
+	}
+	request := &reflectionpb.ServerReflectionRequest{
+		MessageRequest: &reflectionpb.ServerReflectionRequest_ListServices{},
+	}
+	if err := stream.Send(request); err != nil {
+		t.Fatalf("send: %v", err)
+	}
+	if _, err := stream.Recv(); err != nil {
+		t.Fatalf("recv: %v", err)
+	}
+
+	// A connection without streams is closed once idle, which shows the
+	// window is enforced.
+	idleConn := dial()
+	defer idleConn.Close()
+	idleConn.Connect()
+	for state := idleConn.GetState(); state != connectivity.Ready; state = idleConn.GetState() {
+		if !idleConn.WaitForStateChange(ctx, state) {
+			t.Fatal("idle connection never became ready")
+		}
+	}
+
+	time.Sleep(4 * idle)
+
+	if err := stream.Send(request); err != nil {
+		t.Fatalf("send after idling: %v", err)
+	}
+	if _, err := stream.Recv(); err != nil {
+		t.Fatalf("stream closed after idling past MaxConnectionIdle: %v", err)
+	}
+	if state := idleConn.GetState(); state == connectivity.Ready {
+		t.Errorf("connection without streams is still %v after the idle window", state)
+	}
+}
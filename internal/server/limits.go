@@ -0,0 +1,215 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Limits bounds how fast, how concurrently, and how large clients may call
+// the server. The zero value disables every limit.
+type Limits struct {
+	// Rate is the sustained number of requests per second allowed for each
+	// method. Methods listed in MethodRates use their own rate instead.
+	// Zero disables rate limiting for methods without an override.
+	Rate float64
+
+	// MethodRates overrides Rate for individual methods, keyed by the
+	// short method name (e.g. "CreateIdentity").
+	MethodRates map[string]float64
+
+	// Burst is the token bucket capacity. Defaults to the rate rounded up.
+	Burst int
+
+	// MaxInFlight caps concurrently executing requests. Zero disables it.
+	MaxInFlight int
+
+	// MaxRequestBytes caps the size of a single request message, or of
+	// an HTTP gateway request body. Zero keeps the gRPC default.
+	MaxRequestBytes int
+}
+
+// defaultMaxRequestBytes is the gRPC default message size limit, which
+// the HTTP gateway applies too when MaxRequestBytes is zero.
+const defaultMaxRequestBytes = 4 << 20
+
+// maxRequestBytes returns MaxRequestBytes, or defaultMaxRequestBytes
+// when it is zero.
+func (l Limits) maxRequestBytes() int64 {
+	if l.MaxRequestBytes > 0 {
+		return int64(l.MaxRequestBytes)
+	}
+	return defaultMaxRequestBytes
+}
+
+// ParseRateLimits parses a --rate-limit value: a comma-separated list of
+// a bare default rate and/or Method=rate overrides, e.g. "10,CreateIdentity=1".
+func ParseRateLimits(value string, limits *Limits) error {
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		method, rateStr, hasMethod := strings.Cut(part, "=")
+		if !hasMethod {
+			rateStr = method
+		}
+		rate, err := strconv.ParseFloat(strings.TrimSpace(rateStr), 64)
+		if err != nil || rate < 0 {
+			return fmt.Errorf("invalid rate limit %q", part)
+		}
+
+		if !hasMethod {
+			limits.Rate = rate
+			continue
+		}
+		if limits.MethodRates == nil {
+			limits.MethodRates = map[string]float64{}
+		}
+		limits.MethodRates[strings.TrimSpace(method)] = rate
+	}
+	return nil
+}
+
+// rateLimiter returns the limiter enforcing the rate and in-flight
+// limits, or nil when neither is set. The gRPC server and the HTTP
+// gateway share one, so that switching protocols gains a client nothing.
+func (l Limits) rateLimiter() *limiter {
+	if l.Rate > 0 || len(l.MethodRates) > 0 || l.MaxInFlight > 0 {
+		return newLimiter(l)
+	}
+	return nil
+}
+
+// serverOptions returns the gRPC options enforcing the limits, with lim
+// (see rateLimiter) checking the rate and in-flight ones.
+func (l Limits) serverOptions(lim *limiter) []grpc.ServerOption {
+	var opts []grpc.ServerOption
+	if l.MaxRequestBytes > 0 {
+		opts = append(opts, grpc.MaxRecvMsgSize(l.MaxRequestBytes))
+	}
+	if lim != nil {
+		opts = append(opts,
+			grpc.ChainUnaryInterceptor(lim.unary),
+			grpc.ChainStreamInterceptor(lim.stream),
+		)
+	}
+	return opts
+}
+
+// limiter enforces per-method token buckets and a global in-flight cap.
+type limiter struct {
+	limits   Limits
+	now      func() time.Time
+	mu       sync.Mutex
+	buckets  map[string]*tokenBucket
+	inFlight chan struct{}
+}
+
+func newLimiter(l Limits) *limiter {
+	lim := &limiter{
+		limits:  l,
+		now:     time.Now,
+		buckets: map[string]*tokenBucket{},
+	}
+	if l.MaxInFlight > 0 {
+		lim.inFlight = make(chan struct{}, l.MaxInFlight)
+	}
+	return lim
+}
+
+func (l *limiter) unary(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	release, err := l.admit(info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return handler(ctx, req)
+}
+
+func (l *limiter) stream(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	release, err := l.admit(info.FullMethod)
+	if err != nil {
+		return err
+	}
+	defer release()
+	return handler(srv, ss)
+}
+
+// admit checks the rate limit and reserves an in-flight slot.
+// The returned release function must be called when the request completes.
+// A nil limiter admits every request.
+func (l *limiter) admit(fullMethod string) (func(), error) {
+	if l == nil {
+		return func() {}, nil
+	}
+	if !l.allow(fullMethod) {
+		return nil, status.Errorf(codes.ResourceExhausted, "rate limit exceeded for %s", path.Base(fullMethod))
+	}
+
+	if l.inFlight == nil {
+		return func() {}, nil
+	}
+	select {
+	case l.inFlight <- struct{}{}:
+		return func() { <-l.inFlight }, nil
+	default:
+		return nil, status.Errorf(codes.ResourceExhausted, "too many requests in flight (max %d)", l.limits.MaxInFlight)
+	}
+}
+
+func (l *limiter) allow(fullMethod string) bool {
+	method := path.Base(fullMethod)
+	rate := l.limits.Rate
+	if r, ok := l.limits.MethodRates[method]; ok {
+		rate = r
+	}
+	if rate <= 0 {
+		return true
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b, ok := l.buckets[method]
+	if !ok {
+		burst := l.limits.Burst
+		if burst <= 0 {
+			burst = int(math.Ceil(rate))
+		}
+		b = &tokenBucket{rate: rate, capacity: float64(burst), tokens: float64(burst), last: l.now()}
+		l.buckets[method] = b
+	}
+	return b.take(l.now())
+}
+
+// tokenBucket refills at rate tokens per second up to capacity.
+type tokenBucket struct {
+	rate     float64
+	capacity float64
+	tokens   float64
+	last     time.Time
+}
+
+func (b *tokenBucket) take(now time.Time) bool {
+	elapsed := now.Sub(b.last).Seconds()
+	if elapsed > 0 {
+		b.tokens = math.Min(b.capacity, b.tokens+elapsed*b.rate)
+		b.last = now
+	}
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
@@ -0,0 +1,146 @@
+package server
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	pb "github.com/organic-programming/sophia-who/gen/go/sophia_who/v1"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// startLimitedServer launches an in-memory server enforcing limits.
+func startLimitedServer(t *testing.T, limits Limits) pb.SophiaWhoServiceClient {
+	t.Helper()
+
+	lis := bufconn.Listen(bufSize)
+	s := grpc.NewServer(limits.serverOptions(limits.rateLimiter())...)
+	pb.RegisterSophiaWhoServiceServer(s, &Server{})
+	go func() { _ = s.Serve(lis) }()
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.NewClient(
+		"passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return pb.NewSophiaWhoServiceClient(conn)
+}
+
+func TestRateLimitBurstExhausted(t *testing.T) {
+	client := startLimitedServer(t, Limits{Rate: 1, Burst: 2})
+
+	exhausted := 0
+	for i := 0; i < 10; i++ {
+		_, err := client.ListIdentities(context.Background(), &pb.ListIdentitiesRequest{RootDir: t.TempDir()})
+		if status.Code(err) == codes.ResourceExhausted {
+			exhausted++
+		} else if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if exhausted == 0 {
+		t.Fatal("expected some requests to be rejected with ResourceExhausted")
+	}
+	if exhausted == 10 {
+		t.Fatal("expected the burst to admit at least one request")
+	}
+}
+
+func TestRateLimitPerMethodOverride(t *testing.T) {
+	var limits Limits
+	if err := ParseRateLimits("1,ListIdentities=100", &limits); err != nil {
+		t.Fatalf("ParseRateLimits: %v", err)
+	}
+	client := startLimitedServer(t, limits)
+
+	// ListIdentities is overridden above the default rate.
+	for i := 0; i < 5; i++ {
+		if _, err := client.ListIdentities(context.Background(), &pb.ListIdentitiesRequest{RootDir: t.TempDir()}); err != nil {
+			t.Fatalf("ListIdentities should not be limited: %v", err)
+		}
+	}
+
+	// ShowIdentity has no override: still limited at the default rate.
+	show := func() error {
+		_, err := client.ShowIdentity(context.Background(), &pb.ShowIdentityRequest{Uuid: "no-such-holon"})
+		return err
+	}
+	if err := show(); status.Code(err) == codes.ResourceExhausted {
+		t.Fatalf("first ShowIdentity was limited: %v", err)
+	}
+	if err := show(); status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("second ShowIdentity: err = %v, want ResourceExhausted at the default rate", err)
+	}
+}
+
+func TestMaxInFlightRejects(t *testing.T) {
+	lim := newLimiter(Limits{MaxInFlight: 1})
+
+	release, err := lim.admit("/svc/Method")
+	if err != nil {
+		t.Fatalf("first admit failed: %v", err)
+	}
+	if _, err := lim.admit("/svc/Method"); status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("second admit code = %v, want ResourceExhausted", status.Code(err))
+	}
+	release()
+	if _, err := lim.admit("/svc/Method"); err != nil {
+		t.Fatalf("admit after release failed: %v", err)
+	}
+}
+
+func TestTokenBucketRefills(t *testing.T) {
+	start := time.Now()
+	lim := newLimiter(Limits{Rate: 1, Burst: 1})
+	now := start
+	lim.now = func() time.Time { return now }
+
+	if !lim.allow("/svc/Method") {
+		t.Fatal("first request should be allowed")
+	}
+	if lim.allow("/svc/Method") {
+		t.Fatal("second immediate request should be rejected")
+	}
+	now = start.Add(time.Second)
+	if !lim.allow("/svc/Method") {
+		t.Fatal("request after refill should be allowed")
+	}
+}
+
+func TestParseRateLimits(t *testing.T) {
+	var limits Limits
+	if err := ParseRateLimits("10, CreateIdentity=0.5", &limits); err != nil {
+		t.Fatalf("ParseRateLimits: %v", err)
+	}
+	if limits.Rate != 10 {
+		t.Errorf("Rate = %v, want 10", limits.Rate)
+	}
+	if limits.MethodRates["CreateIdentity"] != 0.5 {
+		t.Errorf("CreateIdentity rate = %v, want 0.5", limits.MethodRates["CreateIdentity"])
+	}
+
+	if err := ParseRateLimits("fast", &limits); err == nil {
+		t.Fatal("expected error for non-numeric rate")
+	}
+}
+
+func TestLimitsOffByDefault(t *testing.T) {
+	if opts := (Limits{}).serverOptions((Limits{}).rateLimiter()); len(opts) != 0 {
+		t.Fatalf("zero Limits produced %d server options, want 0", len(opts))
+	}
+}
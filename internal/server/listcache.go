@@ -0,0 +1,86 @@
+package server
+
+import (
+	"sync"
+	"time"
+
+	pb "github.com/organic-programming/sophia-who/gen/go/sophia_who/v1"
+	"github.com/organic-programming/sophia-who/pkg/identity"
+)
+
+// scanWithOptions is identity.ScanWithOptions, replaceable in tests to
+// count the scans ListIdentities performs.
+var scanWithOptions = identity.ScanWithOptions
+
+// listCache holds the last ListIdentities response for each scan root.
+// Entries expire after a TTL so edits made outside the server are
+// eventually seen; writes made through the server drop every entry.
+type listCache struct {
+	mu      sync.Mutex
+	entries map[string]cachedList
+	closed  bool
+
+	// gen counts invalidations, so that a scan overlapping a write does
+	// not cache the listing it read before the write.
+	gen uint64
+}
+
+type cachedList struct {
+	resp    *pb.ListIdentitiesResponse
+	expires time.Time
+}
+
+// get returns the cached response for root if it has not expired.
+func (c *listCache) get(root string) (*pb.ListIdentitiesResponse, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[root]
+	if !ok || !time.Now().Before(e.expires) {
+		return nil, false
+	}
+	return e.resp, true
+}
+
+// generation returns the number of invalidations so far. Take it
+// before scanning and pass it to put.
+func (c *listCache) generation() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.gen
+}
+
+// put caches resp for root for ttl, unless the cache was invalidated
+// since generation gen: resp may then predate a write.
+func (c *listCache) put(root string, gen uint64, resp *pb.ListIdentitiesResponse, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed || gen != c.gen {
+		return
+	}
+	if c.entries == nil {
+		c.entries = make(map[string]cachedList)
+	}
+	c.entries[root] = cachedList{resp: resp, expires: time.Now().Add(ttl)}
+}
+
+// invalidate drops every cached response. A holon written anywhere may
+// fall under any cached root, so no attempt is made to be selective.
+func (c *listCache) invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries = nil
+	c.gen++
+}
+
+// close drops every cached response and stops caching new ones.
+func (c *listCache) close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries = nil
+	c.closed = true
+}
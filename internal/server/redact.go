@@ -0,0 +1,111 @@
+package server
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/organic-programming/sophia-who/pkg/identity"
+)
+
+// Redacted replaces redacted text fields in responses. Redacted lists
+// are emptied instead.
+const Redacted = "[redacted]"
+
+// redactors blank one frontmatter field of an identity and return the
+// value standing in for it in the raw HOLON.md content.
+var redactors = map[string]func(id *identity.Identity) any{
+	"composer":     redactString(func(id *identity.Identity) *string { return &id.Composer }),
+	"motto":        redactString(func(id *identity.Identity) *string { return &id.Motto }),
+	"lang":         redactString(func(id *identity.Identity) *string { return &id.Lang }),
+	"generated_by": redactString(func(id *identity.Identity) *string { return &id.GeneratedBy }),
+	"born":         redactString(func(id *identity.Identity) *string { return &id.Born }),
+	"died":         redactString(func(id *identity.Identity) *string { return &id.Died }),
+	"aliases":      redactList(func(id *identity.Identity) *identity.StringList { return &id.Aliases }),
+	"tags":         redactList(func(id *identity.Identity) *identity.StringList { return &id.Tags }),
+	"parents":      redactList(func(id *identity.Identity) *identity.StringList { return &id.Parents }),
+	"children":     redactList(func(id *identity.Identity) *identity.StringList { return &id.Children }),
+}
+
+func redactString(field func(*identity.Identity) *string) func(*identity.Identity) any {
+	return func(id *identity.Identity) any {
+		if dst := field(id); *dst != "" {
+			*dst = Redacted
+		}
+		return *field(id)
+	}
+}
+
+func redactList(field func(*identity.Identity) *identity.StringList) func(*identity.Identity) any {
+	return func(id *identity.Identity) any {
+		*field(id) = nil
+		return []string{}
+	}
+}
+
+// ParseRedactFields parses a --redact value, a comma-separated list of
+// frontmatter fields, into fields.
+func ParseRedactFields(value string, fields *[]string) error {
+	for _, field := range strings.Split(value, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		if _, ok := redactors[field]; !ok {
+			names := make([]string, 0, len(redactors))
+			for name := range redactors {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			return fmt.Errorf("cannot redact %q (want %s)", field, strings.Join(names, ", "))
+		}
+		if !slices.Contains(*fields, field) {
+			*fields = append(*fields, field)
+		}
+	}
+	return nil
+}
+
+// redact blanks s.RedactFields in id and returns the frontmatter
+// updates doing the same to its raw HOLON.md content, or nil when
+// nothing is redacted.
+func (s *Server) redact(id *identity.Identity) map[string]any {
+	if len(s.RedactFields) == 0 {
+		return nil
+	}
+	set := make(map[string]any, len(s.RedactFields))
+	for _, field := range s.RedactFields {
+		if redactor, ok := redactors[field]; ok {
+			set[field] = redactor(id)
+		}
+	}
+	return set
+}
+
+// redactContent is redact applied to both id and its raw HOLON.md
+// content data. Besides the frontmatter, the body quotes the motto under
+// the title, so a redacted motto is replaced wherever it appears there,
+// plain or markdown-escaped.
+func (s *Server) redactContent(id *identity.Identity, data []byte) ([]byte, error) {
+	motto := id.Motto
+	set := s.redact(id)
+	if set == nil {
+		return data, nil
+	}
+	data, err := identity.UpdateFrontmatter(data, set)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := set["motto"]; !ok || strings.TrimSpace(motto) == "" {
+		return data, nil
+	}
+
+	_, body, err := identity.ParseFrontmatter(data)
+	if err != nil {
+		return nil, err
+	}
+	head := data[: len(data)-len(body) : len(data)-len(body)]
+	body = strings.NewReplacer(identity.EscapeMarkdown(motto), Redacted, motto, Redacted).Replace(body)
+	return append(head, body...), nil
+}
@@ -3,25 +3,151 @@ package server
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
+	"net"
 	"os"
+	"os/signal"
+	"os/user"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"syscall"
+	"time"
 
 	"github.com/organic-programming/go-holons/pkg/transport"
 	pb "github.com/organic-programming/sophia-who/gen/go/sophia_who/v1"
+	"github.com/organic-programming/sophia-who/internal/bulk"
 	"github.com/organic-programming/sophia-who/pkg/identity"
 
+	"go.opentelemetry.io/otel/attribute"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	grpcReflection "google.golang.org/grpc/reflection"
 	"google.golang.org/grpc/status"
 )
 
+// readFile reads a HOLON.md under the default size limit. It is
+// replaceable in tests to simulate files that change between lookup and
+// read.
+var readFile = func(path string) ([]byte, error) {
+	return identity.ReadHolonFile(path, 0)
+}
+
 // Server implements the SophiaWhoService gRPC interface.
 type Server struct {
 	pb.UnimplementedSophiaWhoServiceServer
+
+	// Defaults supplies values for omitted CreateIdentity fields
+	// (composer, lang, clade, reproduction, output directory) and the
+	// custom statuses identities may have.
+	Defaults identity.Config
+
+	// Root is the base directory for every RPC: relative output and scan
+	// directories are resolved against it. Empty means the process cwd.
+	Root string
+
+	// MaxScanResults caps how many holons ListIdentities collects before
+	// it stops scanning and marks the response truncated. Zero: no cap.
+	MaxScanResults int
+
+	// ListCacheTTL, when non-zero, serves repeated ListIdentities calls
+	// for the same root from the last scan for this long. Identities
+	// created or updated through the server invalidate the cache.
+	ListCacheTTL time.Duration
+
+	// AllowedRoots, when set, restricts the root_dir a request may ask
+	// to scan to these directories and their subdirectories. Relative
+	// entries are resolved against Root. Requests without root_dir
+	// always scan Root.
+	AllowedRoots []string
+
+	// PreWriteHook, when set, is a shell command given each rendered
+	// HOLON.md on stdin before CreateIdentity writes it. A non-zero exit
+	// refuses the holon with FailedPrecondition and the command's stderr.
+	PreWriteHook string
+
+	// TrackChildren makes CreateIdentity append each new holon to the
+	// children list of its parents found under Root (see
+	// identity.AppendChild). Parents that cannot be updated are
+	// reported as warnings.
+	TrackChildren bool
+
+	// RedactFields lists frontmatter fields, such as composer, that
+	// ShowIdentity and ListIdentities blank in their responses: text
+	// becomes Redacted and lists become empty. Files on disk are left
+	// as they are. See ParseRedactFields for the fields supported.
+	RedactFields []string
+
+	// BulkConcurrency bounds how many holons bulk RPCs such as
+	// UpdateStatus rewrite at once. Zero: bulk.DefaultConcurrency().
+	BulkConcurrency int
+
+	listCache listCache
+}
+
+// Close releases the resources held by s: it flushes the list cache
+// and stops caching responses. RPCs still work afterwards, uncached.
+// s runs no watcher or other goroutine of its own, so there is nothing
+// else to stop. Close is safe to call more than once.
+func (s *Server) Close() error {
+	s.listCache.close()
+	return nil
+}
+
+// hookRunner runs PreWriteHook commands; replaceable in tests.
+var hookRunner identity.HookRunner = identity.ShellHookRunner
+
+// setStatus rewrites the status of one holon for UpdateStatus;
+// replaceable in tests.
+var setStatus = identity.SetStatus
+
+// scanRoot returns the directory to scan for a request's root_dir,
+// refusing with PermissionDenied a root outside AllowedRoots.
+func (s *Server) scanRoot(requested string) (string, error) {
+	if strings.TrimSpace(requested) == "" {
+		return s.resolve("."), nil
+	}
+	rootDir := s.resolve(requested)
+	if len(s.AllowedRoots) == 0 {
+		return rootDir, nil
+	}
+
+	dir := canonicalPath(rootDir)
+	for _, allowed := range s.AllowedRoots {
+		rel, err := filepath.Rel(canonicalPath(s.resolve(allowed)), dir)
+		if err == nil && (rel == "." || filepath.IsLocal(rel)) {
+			return rootDir, nil
+		}
+	}
+	return "", status.Errorf(codes.PermissionDenied, "root_dir %s is outside the allowed roots", requested)
+}
+
+// canonicalPath makes path absolute and resolves symlinks where it can,
+// so a link inside an allowed root cannot lead outside it.
+func canonicalPath(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	if real, err := filepath.EvalSymlinks(path); err == nil {
+		path = real
+	}
+	return path
+}
+
+// resolve returns path relative to the server root, or path itself
+// when it is absolute.
+func (s *Server) resolve(path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	root := s.Root
+	if root == "" {
+		root = "."
+	}
+	return filepath.Join(root, path)
 }
 
 // CreateIdentity creates a new holon identity from a gRPC request.
@@ -30,6 +156,11 @@ func (s *Server) CreateIdentity(ctx context.Context, req *pb.CreateIdentityReque
 		return nil, status.Error(codes.InvalidArgument, "request is required")
 	}
 
+	composer := req.Composer
+	if strings.TrimSpace(composer) == "" {
+		composer = s.Defaults.Composer
+	}
+
 	if strings.TrimSpace(req.GivenName) == "" {
 		return nil, status.Error(codes.InvalidArgument, "given_name is required")
 	}
@@ -39,31 +170,77 @@ func (s *Server) CreateIdentity(ctx context.Context, req *pb.CreateIdentityReque
 	if strings.TrimSpace(req.Motto) == "" {
 		return nil, status.Error(codes.InvalidArgument, "motto is required")
 	}
-	if strings.TrimSpace(req.Composer) == "" {
+	if strings.TrimSpace(composer) == "" {
 		return nil, status.Error(codes.InvalidArgument, "composer is required")
 	}
 
-	id := identity.New()
+	id := s.Defaults.New()
+	if req.Born != "" {
+		if err := identity.ValidateBorn(req.Born); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		id.Born = req.Born
+	}
 
 	id.GivenName = req.GivenName
 	id.FamilyName = req.FamilyName
 	id.Motto = req.Motto
-	id.Composer = req.Composer
-	id.Clade = cladeToString(req.Clade)
-	id.Reproduction = reproductionToString(req.Reproduction)
+	id.Composer = composer
+	if req.Clade != pb.Clade_CLADE_UNSPECIFIED {
+		id.Clade = cladeToString(req.Clade)
+	}
+	if req.Reproduction != pb.ReproductionMode_REPRODUCTION_UNSPECIFIED {
+		id.Reproduction = reproductionToString(req.Reproduction)
+	}
+	id.Lang = req.Lang
+	s.Defaults.Apply(&id)
+	if id.Clade == "" {
+		id.Clade = cladeToString(pb.Clade_CLADE_UNSPECIFIED)
+	}
+	if id.Reproduction == "" {
+		id.Reproduction = reproductionToString(pb.ReproductionMode_REPRODUCTION_UNSPECIFIED)
+	}
+
+	if aliases := identity.NormalizeAliases(req.Aliases); len(aliases) > 0 {
+		id.Aliases = aliases
+	}
+	if tags := identity.NormalizeTags(req.Tags); len(tags) > 0 {
+		id.Tags = tags
+	}
+	for _, parent := range req.Parents {
+		if parent = strings.TrimSpace(parent); parent != "" {
+			id.Parents = append(id.Parents, parent)
+		}
+	}
 
-	if req.Lang != "" {
-		id.Lang = req.Lang
+	validate := id.ValidateWithOptions
+	if req.Strict {
+		validate = id.ValidateStrictWithOptions
 	}
-	if len(req.Aliases) > 0 {
-		id.Aliases = req.Aliases
+	if err := validate(s.Defaults.ValidateOptions()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
 	outputDir := req.OutputDir
 	if outputDir == "" {
-		dirName := strings.ToLower(id.GivenName + "-" + strings.TrimSuffix(id.FamilyName, "?"))
-		dirName = strings.ReplaceAll(dirName, " ", "-")
-		outputDir = filepath.Join("holons", dirName)
+		outputDir = s.Defaults.OutputDir(id)
+	}
+	if err := identity.ValidateOutputDir(s.resolve("."), outputDir); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	outputDir = s.resolve(outputDir)
+
+	data, err := identity.RenderHolonMDWithOptions(id, identity.RenderOptions{
+		SortLists: s.Defaults.SortLists,
+		Minimal:   req.OmitBody,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "render HOLON.md: %v", err)
+	}
+	if s.PreWriteHook != "" {
+		if err := identity.RunPreWriteHook(hookRunner, s.PreWriteHook, data); err != nil {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
 	}
 
 	if err := os.MkdirAll(outputDir, 0755); err != nil {
@@ -71,39 +248,68 @@ func (s *Server) CreateIdentity(ctx context.Context, req *pb.CreateIdentityReque
 	}
 
 	outputPath := filepath.Join(outputDir, "HOLON.md")
-	if err := identity.WriteHolonMD(id, outputPath); err != nil {
+	if err := identity.CreateHolonMD(outputPath, data); err != nil {
+		if errors.Is(err, os.ErrExist) {
+			return nil, status.Errorf(codes.AlreadyExists, "%s already exists", outputPath)
+		}
 		return nil, status.Errorf(codes.Internal, "write HOLON.md: %v", err)
 	}
+	s.listCache.invalidate()
+	s.audit(ctx, identity.AuditCreate, id, outputPath)
+
+	var warnings []string
+	for _, w := range id.Warnings() {
+		warnings = append(warnings, w.Error())
+	}
+	if base := filepath.Base(filepath.Clean(outputDir)); base != id.Slug() {
+		warnings = append(warnings, fmt.Sprintf("output directory %q does not match the holon slug %q", base, id.Slug()))
+	}
+	if s.TrackChildren {
+		for _, parent := range id.Parents {
+			path, err := identity.FindByUUID(s.resolve("."), parent)
+			if err == nil {
+				err = identity.AppendChild(path, id.UUID)
+			}
+			if err != nil {
+				warnings = append(warnings, fmt.Sprintf("cannot record child in parent %s: %v", parent, err))
+			}
+		}
+		s.listCache.invalidate()
+	}
 
 	return &pb.CreateIdentityResponse{
-		Identity: toProto(id),
-		FilePath: outputPath,
+		Identity:   toProto(id),
+		FilePath:   outputPath,
+		Warnings:   warnings,
+		RawContent: string(data),
 	}, nil
 }
 
-// ShowIdentity retrieves a holon's identity by UUID.
+// ShowIdentity retrieves a holon's identity by UUID, with
+// s.RedactFields blanked in both the identity and the raw content.
 func (s *Server) ShowIdentity(ctx context.Context, req *pb.ShowIdentityRequest) (*pb.ShowIdentityResponse, error) {
 	if req == nil || strings.TrimSpace(req.Uuid) == "" {
 		return nil, status.Error(codes.InvalidArgument, "uuid is required")
 	}
 
-	path, err := identity.FindByUUID(".", req.Uuid)
+	h, err := s.resolveHolon(req.Uuid)
 	if err != nil {
-		if isIdentityNotFound(err) {
-			return nil, status.Error(codes.NotFound, err.Error())
-		}
-		return nil, status.Errorf(codes.Internal, "resolve holon by uuid: %v", err)
+		return nil, err
 	}
+	path := h.Path
 
-	data, err := os.ReadFile(path)
+	data, err := readFile(path)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "cannot read %s: %v", path, err)
+		return nil, fileStatus(req.Uuid, path, err)
 	}
 
 	id, _, err := identity.ParseFrontmatter(data)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "parse HOLON.md: %v", err)
 	}
+	if data, err = s.redactContent(&id, data); err != nil {
+		return nil, status.Errorf(codes.Internal, "redact HOLON.md: %v", err)
+	}
 
 	return &pb.ShowIdentityResponse{
 		Identity:   toProto(id),
@@ -112,60 +318,767 @@ func (s *Server) ShowIdentity(ctx context.Context, req *pb.ShowIdentityRequest)
 	}, nil
 }
 
-// ListIdentities scans the project for all known holons.
+// ListIdentities scans the project for all known holons. A root_dir
+// naming a HOLON.md file lists that one holon; any other file is
+// InvalidArgument.
 func (s *Server) ListIdentities(ctx context.Context, req *pb.ListIdentitiesRequest) (*pb.ListIdentitiesResponse, error) {
-	rootDir := "."
-	if req != nil && strings.TrimSpace(req.RootDir) != "" {
-		rootDir = req.RootDir
+	rootDir, err := s.scanRoot(req.GetRootDir())
+	if err != nil {
+		return nil, err
 	}
-
-	holons, err := identity.FindAllWithPaths(rootDir)
+	fileRoot, err := identity.HolonFileRoot(rootDir)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "scan identities: %v", err)
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	// A HOLON.md root lists that one holon, at relative path ".".
+	relativeTo := rootDir
+	if fileRoot {
+		relativeTo = filepath.Dir(rootDir)
+	}
+	// Stats are cheap to get wrong when stale and expensive to compute,
+	// so only plain, unfiltered listings are cached.
+	cacheable := s.ListCacheTTL > 0 && !req.GetIncludeStats() && len(req.GetTags()) == 0
+	var gen uint64
+	if cacheable {
+		if resp, ok := s.listCache.get(rootDir); ok {
+			return resp, nil
+		}
+		gen = s.listCache.generation()
+	}
+
+	// Scan one past the cap so a tree of exactly MaxScanResults holons
+	// is not reported as truncated. Holons the tags leave out are
+	// filtered by the scan itself, so that they do not count.
+	var opts identity.ScanOptions
+	if s.MaxScanResults > 0 {
+		opts.MaxResults = s.MaxScanResults + 1
+	}
+	if tags := req.GetTags(); len(tags) > 0 {
+		opts.Filter = func(h identity.LocatedIdentity) bool { return h.Identity.HasTags(tags) }
 	}
 
-	entries := make([]*pb.HolonEntry, 0, len(holons))
-	for _, h := range holons {
-		entries = append(entries, &pb.HolonEntry{
+	_, span := startSpan(ctx, "scan", attribute.String("scan.root", rootDir))
+	var entries []*pb.HolonEntry
+	err = scanWithOptions(rootDir, opts, func(h identity.LocatedIdentity) {
+		s.redact(&h.Identity)
+		entry := &pb.HolonEntry{
 			Identity:     toProto(h.Identity),
 			Origin:       "local",
-			RelativePath: relativeHolonDir(rootDir, h.Path),
+			RelativePath: relativeHolonDir(relativeTo, h.Path),
+		}
+		if req.GetIncludeStats() {
+			entry.DirFileCount, entry.DirSizeBytes = dirStats(filepath.Dir(h.Path))
+		}
+		entries = append(entries, entry)
+	}, nil)
+	span.SetAttributes(attribute.Int("scan.holons_found", len(entries)))
+	endSpan(span, err)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "scan identities: %v", err)
+	}
+
+	resp := &pb.ListIdentitiesResponse{Entries: entries}
+	if s.MaxScanResults > 0 && len(entries) > s.MaxScanResults {
+		resp.Entries = entries[:s.MaxScanResults]
+		resp.Truncated = true
+	}
+	if cacheable {
+		s.listCache.put(rootDir, gen, resp, s.ListCacheTTL)
+	}
+	return resp, nil
+}
+
+// dirStats counts the regular files under a holon directory and their
+// total size. Subdirectories holding their own HOLON.md belong to other
+// holons and are not counted. Unreadable entries are skipped.
+func dirStats(dir string) (files, size int64) {
+	_ = filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if d.IsDir() {
+			if path != dir {
+				if _, err := os.Stat(filepath.Join(path, "HOLON.md")); err == nil {
+					return filepath.SkipDir
+				}
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		if info, err := d.Info(); err == nil {
+			files++
+			size += info.Size()
+		}
+		return nil
+	})
+	return files, size
+}
+
+// UpdateIdentity rewrites the fields listed in update_mask in place. A
+// proto_status moving backward in, or leaving, its lifecycle (see
+// identity.CheckProtoStatusTransition) is FailedPrecondition.
+func (s *Server) UpdateIdentity(ctx context.Context, req *pb.UpdateIdentityRequest) (*pb.UpdateIdentityResponse, error) {
+	if req == nil || strings.TrimSpace(req.Uuid) == "" {
+		return nil, status.Error(codes.InvalidArgument, "uuid is required")
+	}
+	paths := req.GetUpdateMask().GetPaths()
+	if len(paths) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "update_mask is required")
+	}
+
+	h, err := s.resolveHolon(req.Uuid)
+	if err != nil {
+		return nil, err
+	}
+	path := h.Path
+
+	var id identity.Identity
+	err = identity.RewriteHolonFile(path, func(data []byte) ([]byte, error) {
+		var err error
+		if id, _, err = identity.ParseFrontmatter(data); err != nil {
+			return nil, status.Errorf(codes.Internal, "parse HOLON.md: %v", err)
+		}
+
+		previousProtoStatus := id.ProtoStatus
+		set := make(map[string]any, len(paths))
+		for _, field := range paths {
+			value, err := updateValue(field, req.GetIdentity(), &id)
+			if err != nil {
+				return nil, status.Error(codes.InvalidArgument, err.Error())
+			}
+			set[field] = value
+		}
+		if err := identity.CheckProtoStatusTransition(previousProtoStatus, id.ProtoStatus); err != nil {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		if err := id.ValidateWithOptions(s.Defaults.ValidateOptions()); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+
+		updated, err := identity.UpdateFrontmatter(data, set)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "update HOLON.md: %v", err)
+		}
+		return updated, nil
+	})
+	if err != nil {
+		if _, ok := status.FromError(err); !ok {
+			err = fileStatus(req.Uuid, path, err)
+		}
+		return nil, err
+	}
+	s.listCache.invalidate()
+	s.audit(ctx, identity.AuditUpdate, id, path)
+
+	return &pb.UpdateIdentityResponse{Identity: toProto(id), FilePath: path}, nil
+}
+
+// UpdateStatus moves each requested holon to new_status, up to
+// s.BulkConcurrency of them at a time. A holon that cannot be resolved
+// or written is reported in its result; the others are still updated.
+func (s *Server) UpdateStatus(ctx context.Context, req *pb.UpdateStatusRequest) (*pb.UpdateStatusResponse, error) {
+	if req == nil || len(req.Uuids) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "uuids is required")
+	}
+	if !s.Defaults.ValidateOptions().KnownStatus(req.NewStatus) {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown status %q", req.NewStatus)
+	}
+
+	// Resolve every holon first: one named twice, by UUID and by alias
+	// say, is then rewritten once and both results report the outcome.
+	results := make([]*pb.UpdateStatusResult, len(req.Uuids))
+	paths := make([]string, len(req.Uuids))
+	bulk.Run(len(req.Uuids), s.BulkConcurrency, func(i int) {
+		results[i] = &pb.UpdateStatusResult{Uuid: req.Uuids[i]}
+		h, err := identity.ResolveTarget(s.resolve("."), req.Uuids[i])
+		if err != nil {
+			results[i].Error = err.Error()
+			return
+		}
+		paths[i] = filepath.Clean(h.Path)
+		results[i].FilePath = h.Path
+	})
+
+	first := bulk.RunUnique(paths, s.BulkConcurrency, func(i int) {
+		id, previous, err := setStatus(paths[i], req.NewStatus, s.Defaults.ValidateOptions())
+		if err != nil {
+			results[i].Error = err.Error()
+			return
+		}
+		results[i].PreviousStatus = previous
+		s.listCache.invalidate()
+		s.audit(ctx, identity.AuditUpdate, id, paths[i])
+	})
+	for i, j := range first {
+		if j >= 0 && j != i {
+			results[i].Error = results[j].Error
+			results[i].PreviousStatus = results[j].PreviousStatus
+		}
+	}
+	return &pb.UpdateStatusResponse{Results: results}, nil
+}
+
+// Reparent replaces the parents of the requested holon. Unknown or
+// duplicate parents are InvalidArgument; a parent that is the holon or
+// one of its descendants is FailedPrecondition.
+func (s *Server) Reparent(ctx context.Context, req *pb.ReparentRequest) (*pb.ReparentResponse, error) {
+	if req == nil || strings.TrimSpace(req.Uuid) == "" {
+		return nil, status.Error(codes.InvalidArgument, "uuid is required")
+	}
+
+	h, err := s.resolveHolon(req.Uuid)
+	if err != nil {
+		return nil, err
+	}
+	id, previous, err := identity.Reparent(s.resolve("."), h.Path, req.Parents)
+	switch {
+	case errors.Is(err, identity.ErrInvalidParent):
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, identity.ErrLineageCycle):
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	case err != nil:
+		return nil, status.Errorf(codes.Internal, "reparent: %v", err)
+	}
+	s.listCache.invalidate()
+	s.audit(ctx, identity.AuditUpdate, id, h.Path)
+
+	return &pb.ReparentResponse{Identity: toProto(id), FilePath: h.Path, PreviousParents: previous}, nil
+}
+
+// updateValue copies the field at path from src into id and returns the
+// value to write to the frontmatter. Zero values clear the field.
+func updateValue(path string, src *pb.HolonIdentity, id *identity.Identity) (any, error) {
+	if src == nil {
+		src = &pb.HolonIdentity{}
+	}
+	str := func(dst *string, v string) (any, error) {
+		*dst = v
+		return v, nil
+	}
+	list := func(dst *identity.StringList, v []string) (any, error) {
+		*dst = v
+		if v == nil {
+			v = []string{}
+		}
+		return v, nil
+	}
+	lifecycle := func(dst *string, st pb.Status, custom string) (any, error) {
+		switch st {
+		case pb.Status_STATUS_UNSPECIFIED:
+			*dst = ""
+		case pb.Status_STATUS_CUSTOM:
+			*dst = custom
+		default:
+			*dst = strings.ToLower(st.String())
+		}
+		return *dst, nil
+	}
+
+	switch path {
+	case "uuid":
+		return nil, fmt.Errorf("uuid is immutable")
+	case "given_name":
+		return str(&id.GivenName, src.GivenName)
+	case "family_name":
+		return str(&id.FamilyName, src.FamilyName)
+	case "motto":
+		return str(&id.Motto, src.Motto)
+	case "composer":
+		return str(&id.Composer, src.Composer)
+	case "born":
+		return str(&id.Born, src.Born)
+	case "died":
+		return str(&id.Died, src.Died)
+	case "lang":
+		return str(&id.Lang, src.Lang)
+	case "generated_by":
+		return str(&id.GeneratedBy, src.GeneratedBy)
+	case "parents":
+		return list(&id.Parents, src.Parents)
+	case "aliases":
+		return list(&id.Aliases, identity.NormalizeAliases(src.Aliases))
+	case "tags":
+		return list(&id.Tags, identity.NormalizeTags(src.Tags))
+	case "clade":
+		if src.Clade == pb.Clade_CLADE_UNSPECIFIED {
+			return str(&id.Clade, "")
+		}
+		return str(&id.Clade, cladeToString(src.Clade))
+	case "reproduction":
+		if src.Reproduction == pb.ReproductionMode_REPRODUCTION_UNSPECIFIED {
+			return str(&id.Reproduction, "")
+		}
+		return str(&id.Reproduction, reproductionToString(src.Reproduction))
+	case "status":
+		return lifecycle(&id.Status, src.Status, src.CustomStatus)
+	case "proto_status":
+		return lifecycle(&id.ProtoStatus, src.ProtoStatus, src.CustomProtoStatus)
+	default:
+		return nil, fmt.Errorf("unknown or read-only field %q in update_mask", path)
+	}
+}
+
+// CountIdentities scans root_dir and tallies the holons matching the
+// optional clade and status filters.
+func (s *Server) CountIdentities(ctx context.Context, req *pb.CountIdentitiesRequest) (*pb.CountIdentitiesResponse, error) {
+	rootDir, err := s.scanRoot(req.GetRootDir())
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &pb.CountIdentitiesResponse{
+		ByClade:  map[string]int32{},
+		ByStatus: map[string]int32{},
+	}
+	_, span := startSpan(ctx, "scan", attribute.String("scan.root", rootDir))
+	err = identity.ScanAllWithPaths(rootDir, 0, func(h identity.LocatedIdentity) {
+		id := h.Identity
+		if req.GetClade() != "" && id.Clade != req.GetClade() {
+			return
+		}
+		if req.GetStatus() != "" && id.Status != req.GetStatus() {
+			return
+		}
+		resp.Total++
+		resp.ByClade[id.Clade]++
+		resp.ByStatus[id.Status]++
+	}, nil)
+	endSpan(span, err)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "scan identities: %v", err)
+	}
+
+	return resp, nil
+}
+
+// ValidateContent parses and validates raw HOLON.md content.
+func (s *Server) ValidateContent(ctx context.Context, req *pb.ValidateContentRequest) (*pb.ValidateContentResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "request is required")
+	}
+
+	errs := identity.ValidateContentWithOptions([]byte(req.RawContent), s.Defaults.ValidateOptions())
+	resp := &pb.ValidateContentResponse{Valid: len(errs) == 0}
+	for _, fe := range errs {
+		resp.Errors = append(resp.Errors, &pb.ValidationError{
+			Field:   fe.Field,
+			Message: fe.Message,
+			Line:    int32(fe.Line),
 		})
 	}
+	return resp, nil
+}
 
-	return &pb.ListIdentitiesResponse{Entries: entries}, nil
+// ListHolonFiles lists the regular files in the directory of the
+// requested holon. Recursive listings skip nested holon directories,
+// which belong to another holon.
+func (s *Server) ListHolonFiles(ctx context.Context, req *pb.ListHolonFilesRequest) (*pb.ListHolonFilesResponse, error) {
+	if req == nil || strings.TrimSpace(req.Uuid) == "" {
+		return nil, status.Error(codes.InvalidArgument, "uuid is required")
+	}
+
+	h, err := s.resolveHolon(req.Uuid)
+	if err != nil {
+		return nil, err
+	}
+	dir := filepath.Dir(h.Path)
+
+	resp := &pb.ListHolonFilesResponse{Directory: dir}
+	err = filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			if path == dir {
+				return err
+			}
+			return nil
+		}
+		if d.IsDir() {
+			if path == dir {
+				return nil
+			}
+			if !req.Recursive {
+				return filepath.SkipDir
+			}
+			if _, err := os.Stat(filepath.Join(path, "HOLON.md")); err == nil {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() || (req.ExcludeHolonMd && path == h.Path) {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return nil
+		}
+		resp.Files = append(resp.Files, &pb.HolonFile{Name: filepath.ToSlash(rel), SizeBytes: info.Size()})
+		return nil
+	})
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return nil, status.Errorf(codes.NotFound, "holon not found: %s", req.Uuid)
+	case errors.Is(err, os.ErrPermission):
+		return nil, status.Errorf(codes.PermissionDenied, "cannot list %s: %v", dir, err)
+	case err != nil:
+		return nil, status.Errorf(codes.Internal, "cannot list %s: %v", dir, err)
+	}
+	return resp, nil
+}
+
+// validateProgressEvery is how many scanned files separate the progress
+// messages of a ValidateAll stream.
+const validateProgressEvery = 100
+
+// ValidateAll validates every HOLON.md under the requested root,
+// streaming each file's result as soon as it is checked. Progress is
+// sent every validateProgressEvery scanned files and once at the end.
+func (s *Server) ValidateAll(req *pb.ValidateAllRequest, stream grpc.ServerStreamingServer[pb.ValidationResult]) error {
+	rootDir, err := s.scanRoot(req.GetRootDir())
+	if err != nil {
+		return err
+	}
+
+	ctx := stream.Context()
+	var invalid int32
+	var sendErr error
+	send := func(result *pb.ValidationResult) bool {
+		if sendErr == nil && ctx.Err() == nil {
+			sendErr = stream.Send(result)
+		}
+		return sendErr == nil && ctx.Err() == nil
+	}
+
+	_, span := startSpan(ctx, "scan", attribute.String("scan.root", rootDir))
+	opts := identity.ScanOptions{ProgressEvery: validateProgressEvery}
+	err = identity.ValidateFilesWithOptions(rootDir, opts, s.Defaults.ValidateOptions(), func(v identity.FileValidation) bool {
+		file := &pb.FileValidation{
+			Path: relativePath(rootDir, v.Path),
+			Ok:   len(v.Errors) == 0,
+		}
+		for _, fe := range v.Errors {
+			file.Errors = append(file.Errors, &pb.ValidationError{
+				Field:   fe.Field,
+				Message: fe.Message,
+				Line:    int32(fe.Line),
+			})
+		}
+		if !file.Ok {
+			invalid++
+		}
+		return send(&pb.ValidationResult{Result: &pb.ValidationResult_File{File: file}})
+	}, func(p identity.ScanProgress) {
+		send(&pb.ValidationResult{Result: &pb.ValidationResult_Progress{Progress: &pb.ValidationProgress{
+			ScannedFiles: int32(p.ScannedFiles),
+			CheckedFiles: int32(p.HolonsFound),
+			InvalidFiles: invalid,
+		}}})
+	})
+	endSpan(span, err)
+	if err != nil {
+		return status.Errorf(codes.Internal, "scan identities: %v", err)
+	}
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return status.FromContextError(ctxErr).Err()
+	}
+	return sendErr
+}
+
+// resolveHolon finds the holon named by target under the server root
+// (see identity.ResolveTarget), as a gRPC status error on failure.
+func (s *Server) resolveHolon(target string) (identity.LocatedIdentity, error) {
+	h, err := identity.ResolveTarget(s.resolve("."), target)
+	var ambiguous *identity.AmbiguousTargetError
+	switch {
+	case errors.As(err, &ambiguous):
+		return h, status.Error(codes.InvalidArgument, err.Error())
+	case isIdentityNotFound(err):
+		return h, status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, os.ErrPermission):
+		return h, status.Errorf(codes.PermissionDenied, "resolve holon: %v", err)
+	case err != nil:
+		return h, status.Errorf(codes.Internal, "resolve holon: %v", err)
+	}
+	return h, nil
+}
+
+// fileStatus maps an error accessing path, the HOLON.md found for the
+// holon target, to a gRPC status.
+func fileStatus(target, path string, err error) error {
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		// Removed since it was resolved.
+		return status.Errorf(codes.NotFound, "holon not found: %s", target)
+	case errors.Is(err, os.ErrPermission):
+		return status.Errorf(codes.PermissionDenied, "cannot access %s: %v", path, err)
+	case errors.Is(err, identity.ErrFileTooLarge):
+		return status.Errorf(codes.FailedPrecondition, "cannot read %v", err)
+	default:
+		return status.Errorf(codes.Internal, "cannot access %s: %v", path, err)
+	}
+}
+
+// callerMetadataKey is the request metadata key identifying the caller
+// in audit log entries.
+const callerMetadataKey = "x-caller"
+
+// audit records a mutation in the audit log under the server root.
+// Logging is best-effort: a failure is logged and never fails the RPC.
+func (s *Server) audit(ctx context.Context, op string, id identity.Identity, path string) {
+	var caller string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if v := md.Get(callerMetadataKey); len(v) > 0 {
+			caller = v[0]
+		}
+	}
+	err := identity.AppendAudit(s.resolve("."), identity.AuditEntry{
+		Op:       op,
+		UUID:     id.UUID,
+		Composer: id.Composer,
+		Caller:   caller,
+		Path:     path,
+	})
+	if err != nil {
+		log.Printf("warning: %v", err)
+	}
+}
+
+// Options configures the gRPC server started by ListenAndServeWithOptions.
+type Options struct {
+	// Reflect enables server reflection (mandatory per Constitution).
+	Reflect bool
+
+	// Limits bounds request rate, concurrency, and size. Off by default.
+	Limits Limits
+
+	// Keepalive tunes connection pings and timeouts. The zero value uses
+	// the defaults (see Keepalive).
+	Keepalive Keepalive
+
+	// Tracing records a span per RPC. Off by default.
+	Tracing Tracing
+
+	// Defaults supplies values for omitted CreateIdentity fields and the
+	// custom statuses identities may have.
+	Defaults identity.Config
+
+	// Root is the base directory for all RPCs (default: the process cwd).
+	Root string
+
+	// MaxScanResults caps ListIdentities results (see Server.MaxScanResults).
+	MaxScanResults int
+
+	// ListCacheTTL enables the ListIdentities cache (see Server.ListCacheTTL).
+	ListCacheTTL time.Duration
+
+	// AllowedRoots restricts request root_dir values (see
+	// Server.AllowedRoots).
+	AllowedRoots []string
+
+	// PreWriteHook vets new holons before they are written (see
+	// Server.PreWriteHook).
+	PreWriteHook string
+
+	// TrackChildren maintains parents' children lists (see
+	// Server.TrackChildren).
+	TrackChildren bool
+
+	// RedactFields blanks fields in read responses (see
+	// Server.RedactFields).
+	RedactFields []string
+
+	// BulkConcurrency bounds bulk RPCs (see Server.BulkConcurrency).
+	BulkConcurrency int
+
+	// UnixSocketPerms, when non-zero, is applied to the socket file of a
+	// unix:// listener (e.g. 0660).
+	UnixSocketPerms os.FileMode
+
+	// UnixSocketGroup, when set, is the group name or numeric GID given
+	// ownership of the socket file of a unix:// listener.
+	UnixSocketGroup string
 }
 
 // ListenAndServe starts the gRPC server on the given transport URI.
-// Supported URIs: tcp://<host>:<port>, unix://<path>, stdio://
+// Supported URIs: tcp://<host>:<port>, h2c://<host>:<port>, unix://<path>, stdio://, ws://<host>:<port>, fd://<n>
 // When reflect is true, server reflection is enabled (mandatory per Constitution).
 func ListenAndServe(listenURI string, reflect bool) error {
-	lis, err := transport.Listen(listenURI)
-	if err != nil {
-		return fmt.Errorf("listen %s: %w", listenURI, err)
-	}
+	return ListenAndServeWithOptions(listenURI, Options{Reflect: reflect})
+}
 
-	s := grpc.NewServer()
-	pb.RegisterSophiaWhoServiceServer(s, &Server{})
-	if reflect {
-		grpcReflection.Register(s)
+// ListenAndServeWithOptions starts the gRPC server on the given transport URI
+// with the given options.
+func ListenAndServeWithOptions(listenURI string, opts Options) error {
+	lis, err := listenWithOptions(listenURI, opts)
+	if err != nil {
+		return err
 	}
 
 	mode := "reflection ON"
-	if !reflect {
+	if !opts.Reflect {
 		mode = "reflection OFF"
 	}
 	log.Printf("Sophia Who? gRPC server listening on %s (%s)", listenURI, mode)
-	return s.Serve(lis)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	return serve(ctx, lis, newService(opts), opts, strings.HasPrefix(listenURI, h2cScheme))
 }
 
-func relativeHolonDir(rootDir, holonFilePath string) string {
-	dir := filepath.Dir(holonFilePath)
-	rel, err := filepath.Rel(rootDir, dir)
+// serve serves svc on lis until ctx is done, then stops gracefully:
+// in-flight RPCs finish and svc is closed before serve returns. With
+// h2c, gRPC and the HTTP gateway share svc so writes through either
+// invalidate the same list cache.
+func serve(ctx context.Context, lis net.Listener, svc *Server, opts Options, h2c bool) error {
+	defer svc.Close()
+
+	lim := opts.Limits.rateLimiter()
+	s := newGRPCServerFor(svc, opts, lim)
+	if h2c {
+		return serveH2C(ctx, lis, s, newGateway(svc, opts, lim))
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		<-ctx.Done()
+		s.GracefulStop()
+	}()
+	err := s.Serve(lis)
+	cancel()
+	<-stopped
+	return err
+}
+
+// Listen opens a listener for a transport URI reachable from outside the
+// process: tcp://<host>:<port>, h2c://<host>:<port>, unix://<path>, stdio://,
+// ws://<host>:<port>, or fd://<n> for a socket inherited from the parent.
+// mem:// is rejected because in-process listeners cannot be dialed by clients.
+func Listen(listenURI string) (net.Listener, error) {
+	if strings.HasPrefix(listenURI, "mem://") {
+		return nil, fmt.Errorf("listen %s: mem:// is in-process only and cannot be served from the CLI", listenURI)
+	}
+	if strings.HasPrefix(listenURI, h2cScheme) {
+		lis, err := listenH2C(listenURI)
+		if err != nil {
+			return nil, fmt.Errorf("listen %s: %w", listenURI, err)
+		}
+		return lis, nil
+	}
+	if strings.HasPrefix(listenURI, fdScheme) {
+		lis, err := listenFD(listenURI)
+		if err != nil {
+			return nil, fmt.Errorf("listen %s: %w", listenURI, err)
+		}
+		return lis, nil
+	}
+
+	lis, err := transport.Listen(listenURI)
+	if err != nil {
+		return nil, fmt.Errorf("listen %s: %w", listenURI, err)
+	}
+	return lis, nil
+}
+
+// listenWithOptions is Listen followed by the socket permission and
+// group changes requested in opts, which only apply to unix:// URIs.
+func listenWithOptions(listenURI string, opts Options) (net.Listener, error) {
+	lis, err := Listen(listenURI)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasPrefix(listenURI, "unix://") {
+		return lis, nil
+	}
+
+	path := strings.TrimPrefix(listenURI, "unix://")
+	if opts.UnixSocketPerms != 0 {
+		if err := os.Chmod(path, opts.UnixSocketPerms); err != nil {
+			lis.Close()
+			return nil, fmt.Errorf("set socket permissions: %w", err)
+		}
+	}
+	if opts.UnixSocketGroup != "" {
+		gid, err := lookupGID(opts.UnixSocketGroup)
+		if err == nil {
+			err = os.Chown(path, -1, gid)
+		}
+		if err != nil {
+			lis.Close()
+			return nil, fmt.Errorf("set socket group: %w", err)
+		}
+	}
+	return lis, nil
+}
+
+// lookupGID resolves a group name or numeric GID.
+func lookupGID(group string) (int, error) {
+	if gid, err := strconv.Atoi(group); err == nil {
+		return gid, nil
+	}
+	g, err := user.LookupGroup(group)
 	if err != nil {
-		return filepath.Clean(dir)
+		return 0, err
+	}
+	return strconv.Atoi(g.Gid)
+}
+
+// newService builds the service implementation configured by opts.
+func newService(opts Options) *Server {
+	return &Server{
+		Defaults:        opts.Defaults,
+		Root:            opts.Root,
+		MaxScanResults:  opts.MaxScanResults,
+		ListCacheTTL:    opts.ListCacheTTL,
+		AllowedRoots:    opts.AllowedRoots,
+		PreWriteHook:    opts.PreWriteHook,
+		TrackChildren:   opts.TrackChildren,
+		RedactFields:    opts.RedactFields,
+		BulkConcurrency: opts.BulkConcurrency,
+	}
+}
+
+// newGRPCServer builds a gRPC server with the Sophia Who? service registered.
+func newGRPCServer(opts Options) *grpc.Server {
+	return newGRPCServerFor(newService(opts), opts, opts.Limits.rateLimiter())
+}
+
+// newGRPCServerFor builds a gRPC server serving svc, rate limited by lim.
+func newGRPCServerFor(svc *Server, opts Options, lim *limiter) *grpc.Server {
+	// Tracing is a stats handler rather than an interceptor, so requests
+	// refused by the limits are traced too.
+	serverOpts := opts.Tracing.serverOptions()
+	serverOpts = append(serverOpts, opts.Limits.serverOptions(lim)...)
+	serverOpts = append(serverOpts, opts.Keepalive.serverOptions()...)
+	s := grpc.NewServer(serverOpts...)
+	pb.RegisterSophiaWhoServiceServer(s, svc)
+	if opts.Reflect {
+		grpcReflection.Register(s)
 	}
-	return filepath.Clean(rel)
+	return s
+}
+
+// relativeHolonDir returns the holon's directory relative to rootDir,
+// or its absolute path when it lies outside rootDir.
+func relativeHolonDir(rootDir, holonFilePath string) string {
+	return relativePath(rootDir, filepath.Dir(holonFilePath))
+}
+
+// relativePath returns path relative to rootDir, or its absolute form
+// when it lies outside rootDir.
+func relativePath(rootDir, path string) string {
+	if rel, err := filepath.Rel(rootDir, path); err == nil && (rel == "." || filepath.IsLocal(rel)) {
+		return rel
+	}
+	if abs, err := filepath.Abs(path); err == nil {
+		return abs
+	}
+	return filepath.Clean(path)
 }
 
 func isIdentityNotFound(err error) bool {
@@ -179,90 +1092,61 @@ func isIdentityNotFound(err error) bool {
 
 func toProto(id identity.Identity) *pb.HolonIdentity {
 	return &pb.HolonIdentity{
-		Uuid:         id.UUID,
-		GivenName:    id.GivenName,
-		FamilyName:   id.FamilyName,
-		Motto:        id.Motto,
-		Composer:     id.Composer,
-		Clade:        stringToClade(id.Clade),
-		Status:       stringToStatus(id.Status),
-		Born:         id.Born,
-		Parents:      id.Parents,
-		Reproduction: stringToReproduction(id.Reproduction),
-		Aliases:      id.Aliases,
-		GeneratedBy:  id.GeneratedBy,
-		Lang:         id.Lang,
-		ProtoStatus:  stringToStatus(id.ProtoStatus),
+		Uuid:              id.UUID,
+		GivenName:         id.GivenName,
+		FamilyName:        id.FamilyName,
+		Motto:             id.Motto,
+		Composer:          id.Composer,
+		Clade:             stringToClade(id.Clade),
+		Status:            stringToStatus(id.Status),
+		Born:              id.Born,
+		Died:              id.Died,
+		Parents:           id.Parents,
+		Reproduction:      stringToReproduction(id.Reproduction),
+		Aliases:           id.Aliases,
+		Tags:              id.Tags,
+		GeneratedBy:       id.GeneratedBy,
+		Lang:              id.Lang,
+		ProtoStatus:       stringToStatus(id.ProtoStatus),
+		CustomStatus:      customStatus(id.Status),
+		CustomProtoStatus: customStatus(id.ProtoStatus),
 	}
 }
 
 func cladeToString(c pb.Clade) string {
-	m := map[pb.Clade]string{
-		pb.Clade_DETERMINISTIC_PURE:       "deterministic/pure",
-		pb.Clade_DETERMINISTIC_STATEFUL:   "deterministic/stateful",
-		pb.Clade_DETERMINISTIC_IO_BOUND:   "deterministic/io_bound",
-		pb.Clade_PROBABILISTIC_GENERATIVE: "probabilistic/generative",
-		pb.Clade_PROBABILISTIC_PERCEPTUAL: "probabilistic/perceptual",
-		pb.Clade_PROBABILISTIC_ADAPTIVE:   "probabilistic/adaptive",
-	}
-	if s, ok := m[c]; ok {
-		return s
+	if c := identity.Clade(c); c.Valid() {
+		return c.String()
 	}
-	return "deterministic/pure"
+	return identity.CladeDeterministicPure.String()
 }
 
 func stringToClade(s string) pb.Clade {
-	m := map[string]pb.Clade{
-		"deterministic/pure":       pb.Clade_DETERMINISTIC_PURE,
-		"deterministic/stateful":   pb.Clade_DETERMINISTIC_STATEFUL,
-		"deterministic/io_bound":   pb.Clade_DETERMINISTIC_IO_BOUND,
-		"probabilistic/generative": pb.Clade_PROBABILISTIC_GENERATIVE,
-		"probabilistic/perceptual": pb.Clade_PROBABILISTIC_PERCEPTUAL,
-		"probabilistic/adaptive":   pb.Clade_PROBABILISTIC_ADAPTIVE,
-	}
-	if c, ok := m[s]; ok {
-		return c
-	}
-	return pb.Clade_CLADE_UNSPECIFIED
+	c, _ := identity.ParseClade(s)
+	return pb.Clade(c)
 }
 
 func stringToStatus(s string) pb.Status {
-	m := map[string]pb.Status{
-		"draft":      pb.Status_DRAFT,
-		"stable":     pb.Status_STABLE,
-		"deprecated": pb.Status_DEPRECATED,
-		"dead":       pb.Status_DEAD,
-	}
-	if st, ok := m[s]; ok {
-		return st
+	st, _ := identity.ParseStatus(s)
+	return pb.Status(st)
+}
+
+// customStatus returns s when it is a custom status, so the string
+// survives the STATUS_CUSTOM enum mapping.
+func customStatus(s string) string {
+	if stringToStatus(s) == pb.Status_STATUS_CUSTOM {
+		return s
 	}
-	return pb.Status_STATUS_UNSPECIFIED
+	return ""
 }
 
 func reproductionToString(r pb.ReproductionMode) string {
-	m := map[pb.ReproductionMode]string{
-		pb.ReproductionMode_MANUAL:      "manual",
-		pb.ReproductionMode_ASSISTED:    "assisted",
-		pb.ReproductionMode_AUTOMATIC:   "automatic",
-		pb.ReproductionMode_AUTOPOIETIC: "autopoietic",
-		pb.ReproductionMode_BRED:        "bred",
-	}
-	if s, ok := m[r]; ok {
-		return s
+	if r := identity.Reproduction(r); r.Valid() {
+		return r.String()
 	}
-	return "manual"
+	return identity.ReproductionManual.String()
 }
 
 func stringToReproduction(s string) pb.ReproductionMode {
-	m := map[string]pb.ReproductionMode{
-		"manual":      pb.ReproductionMode_MANUAL,
-		"assisted":    pb.ReproductionMode_ASSISTED,
-		"automatic":   pb.ReproductionMode_AUTOMATIC,
-		"autopoietic": pb.ReproductionMode_AUTOPOIETIC,
-		"bred":        pb.ReproductionMode_BRED,
-	}
-	if r, ok := m[s]; ok {
-		return r
-	}
-	return pb.ReproductionMode_REPRODUCTION_UNSPECIFIED
+	r, _ := identity.ParseReproduction(s)
+	return pb.ReproductionMode(r)
 }
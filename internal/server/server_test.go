@@ -6,6 +6,8 @@ import (
 	"net"
 	"os"
 	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -15,9 +17,12 @@ import (
 	pb "github.com/organic-programming/sophia-who/gen/go/sophia_who/v1"
 	"github.com/organic-programming/sophia-who/pkg/identity"
 
+	"go.uber.org/goleak"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
 	"google.golang.org/grpc/test/bufconn"
 )
 
@@ -107,6 +112,79 @@ func TestListIdentities(t *testing.T) {
 	}
 }
 
+func TestListIdentitiesTags(t *testing.T) {
+	root := t.TempDir()
+	client, cleanup := startTestServer(t, root)
+	defer cleanup()
+
+	tagged := validCreateReq(filepath.Join("holons", "tagged"))
+	tagged.Tags = []string{" Audio", "audio", "Experimental"}
+	created, err := client.CreateIdentity(context.Background(), tagged)
+	if err != nil {
+		t.Fatalf("CreateIdentity failed: %v", err)
+	}
+	if got := created.GetIdentity().GetTags(); strings.Join(got, ",") != "audio,experimental" {
+		t.Errorf("created tags = %q, want normalized [audio experimental]", got)
+	}
+	if _, err := client.CreateIdentity(context.Background(), validCreateReq(filepath.Join("holons", "plain"))); err != nil {
+		t.Fatalf("CreateIdentity failed: %v", err)
+	}
+
+	for _, tt := range []struct {
+		tags []string
+		want int
+	}{
+		{nil, 2},
+		{[]string{"AUDIO"}, 1},
+		{[]string{"audio", "experimental"}, 1},
+		{[]string{"audio", "video"}, 0},
+	} {
+		resp, err := client.ListIdentities(context.Background(), &pb.ListIdentitiesRequest{Tags: tt.tags})
+		if err != nil {
+			t.Fatalf("ListIdentities(%q) failed: %v", tt.tags, err)
+		}
+		if len(resp.Entries) != tt.want {
+			t.Errorf("ListIdentities(%q) returned %d entries, want %d", tt.tags, len(resp.Entries), tt.want)
+		}
+		for _, e := range resp.Entries {
+			if len(tt.tags) > 0 && e.GetRelativePath() != filepath.Join("holons", "tagged") {
+				t.Errorf("ListIdentities(%q) returned %s", tt.tags, e.GetRelativePath())
+			}
+		}
+	}
+
+	tagged.Tags = []string{"two words"}
+	tagged.OutputDir = filepath.Join("holons", "bad-tag")
+	if _, err := client.CreateIdentity(context.Background(), tagged); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("CreateIdentity with an invalid tag: err = %v, want InvalidArgument", err)
+	}
+}
+
+func TestListIdentitiesFileRoot(t *testing.T) {
+	root := t.TempDir()
+	seedHolon(t, root, "file-root-1", "Alpha")
+	seedHolon(t, root, "file-root-2", "Beta")
+	if err := os.WriteFile(filepath.Join(root, "notes.txt"), []byte("not a holon\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	client, cleanup := startTestServer(t, root)
+	defer cleanup()
+
+	resp, err := client.ListIdentities(context.Background(), &pb.ListIdentitiesRequest{RootDir: filepath.Join("Alpha", "HOLON.md")})
+	if err != nil {
+		t.Fatalf("ListIdentities(HOLON.md) failed: %v", err)
+	}
+	if len(resp.Entries) != 1 || resp.Entries[0].GetIdentity().GetUuid() != "file-root-1" || resp.Entries[0].GetRelativePath() != "." {
+		t.Errorf("ListIdentities(HOLON.md) = %v, want only Alpha at .", resp.Entries)
+	}
+
+	_, err = client.ListIdentities(context.Background(), &pb.ListIdentitiesRequest{RootDir: "notes.txt"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("ListIdentities(notes.txt): err = %v, want InvalidArgument", err)
+	}
+}
+
 func TestListIdentitiesEmpty(t *testing.T) {
 	root := t.TempDir()
 
@@ -146,6 +224,90 @@ func TestShowIdentity(t *testing.T) {
 	}
 }
 
+func TestRedactFields(t *testing.T) {
+	root := t.TempDir()
+	seedHolon(t, root, "redact-uuid-1", "Secret")
+	path := filepath.Join(root, "Secret", "HOLON.md")
+	before, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var fields []string
+	if err := ParseRedactFields("composer, parents", &fields); err != nil {
+		t.Fatalf("ParseRedactFields: %v", err)
+	}
+	srv := &Server{Root: root, RedactFields: fields}
+
+	shown, err := srv.ShowIdentity(context.Background(), &pb.ShowIdentityRequest{Uuid: "redact-uuid-1"})
+	if err != nil {
+		t.Fatalf("ShowIdentity failed: %v", err)
+	}
+	if got := shown.GetIdentity().GetComposer(); got != Redacted {
+		t.Errorf("ShowIdentity composer = %q, want %q", got, Redacted)
+	}
+	if !strings.Contains(shown.GetRawContent(), `composer: "[redacted]"`) {
+		t.Errorf("raw content still carries the composer:\n%s", shown.GetRawContent())
+	}
+	if shown.GetIdentity().GetGivenName() != "Secret" || shown.GetIdentity().GetMotto() != "Testing." {
+		t.Errorf("ShowIdentity redacted more than asked: %v", shown.GetIdentity())
+	}
+
+	listed, err := srv.ListIdentities(context.Background(), &pb.ListIdentitiesRequest{})
+	if err != nil {
+		t.Fatalf("ListIdentities failed: %v", err)
+	}
+	if len(listed.GetEntries()) != 1 || listed.GetEntries()[0].GetIdentity().GetComposer() != Redacted {
+		t.Errorf("ListIdentities = %v, want the composer redacted", listed.GetEntries())
+	}
+
+	after, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(after) != string(before) {
+		t.Errorf("redaction changed the file on disk:\n%s", after)
+	}
+
+	if err := ParseRedactFields("uuid", &fields); err == nil {
+		t.Error("ParseRedactFields(uuid) succeeded, want an error")
+	}
+}
+
+func TestRedactMottoInBody(t *testing.T) {
+	root := t.TempDir()
+	id := identity.New()
+	id.GivenName, id.FamilyName, id.Composer = "Quiet", "Keeper", "Test"
+	id.Motto = "Keep the *hidden* door shut."
+	if err := os.MkdirAll(filepath.Join(root, "quiet"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := identity.WriteHolonMD(id, filepath.Join(root, "quiet", "HOLON.md")); err != nil {
+		t.Fatal(err)
+	}
+
+	var fields []string
+	if err := ParseRedactFields("motto", &fields); err != nil {
+		t.Fatalf("ParseRedactFields: %v", err)
+	}
+	srv := &Server{Root: root, RedactFields: fields}
+	shown, err := srv.ShowIdentity(context.Background(), &pb.ShowIdentityRequest{Uuid: id.UUID})
+	if err != nil {
+		t.Fatalf("ShowIdentity failed: %v", err)
+	}
+
+	raw := shown.GetRawContent()
+	if strings.Contains(raw, "hidden") || strings.Contains(raw, "door shut") {
+		t.Errorf("raw content still carries the motto:\n%s", raw)
+	}
+	if !strings.Contains(raw, `> *"`+Redacted+`"*`) || !strings.Contains(raw, "# Quiet Keeper") {
+		t.Errorf("raw content body not redacted in place:\n%s", raw)
+	}
+	if got := shown.GetIdentity().GetMotto(); got != Redacted {
+		t.Errorf("ShowIdentity motto = %q, want %q", got, Redacted)
+	}
+}
+
 func TestShowIdentityPrefix(t *testing.T) {
 	root := t.TempDir()
 	seedHolon(t, root, "prefix-abcd-1234", "Delta")
@@ -164,6 +326,28 @@ func TestShowIdentityPrefix(t *testing.T) {
 	}
 }
 
+func TestShowIdentityByNameAndAmbiguous(t *testing.T) {
+	root := t.TempDir()
+	seedHolon(t, root, "twin-uuid-1", "Castor")
+	seedHolon(t, root, "twin-uuid-2", "Pollux")
+
+	client, cleanup := startTestServer(t, root)
+	defer cleanup()
+
+	resp, err := client.ShowIdentity(context.Background(), &pb.ShowIdentityRequest{Uuid: "Pollux Test"})
+	if err != nil {
+		t.Fatalf("ShowIdentity by name failed: %v", err)
+	}
+	if resp.Identity.Uuid != "twin-uuid-2" {
+		t.Errorf("UUID = %q, want twin-uuid-2", resp.Identity.Uuid)
+	}
+
+	_, err = client.ShowIdentity(context.Background(), &pb.ShowIdentityRequest{Uuid: "twin-uuid"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("ambiguous prefix: code = %v, want InvalidArgument (err %v)", status.Code(err), err)
+	}
+}
+
 func TestShowIdentityNotFound(t *testing.T) {
 	root := t.TempDir()
 
@@ -178,6 +362,64 @@ func TestShowIdentityNotFound(t *testing.T) {
 	}
 }
 
+// raceReadFile makes the server's readFile run mutate on the resolved
+// path first, simulating a file changing between lookup and read.
+// readFile runs on a server goroutine, so a mutate error is handed back
+// to the test goroutine, which fails the test when it cleans up.
+func raceReadFile(t *testing.T, mutate func(path string) error) {
+	t.Helper()
+	orig := readFile
+	errs := make(chan error, 1)
+	readFile = func(path string) ([]byte, error) {
+		if err := mutate(path); err != nil {
+			select {
+			case errs <- fmt.Errorf("mutate %s: %w", path, err):
+			default:
+			}
+		}
+		return orig(path)
+	}
+	t.Cleanup(func() {
+		readFile = orig
+		select {
+		case err := <-errs:
+			t.Error(err)
+		default:
+		}
+	})
+}
+
+func TestShowIdentityPermissionDenied(t *testing.T) {
+	if os.Geteuid() == 0 {
+		t.Skip("file permissions are not enforced for root")
+	}
+	root := t.TempDir()
+	seedHolon(t, root, "perm-uuid-1", "Locked")
+	raceReadFile(t, func(path string) error { return os.Chmod(path, 0) })
+
+	client, cleanup := startTestServer(t, root)
+	defer cleanup()
+
+	_, err := client.ShowIdentity(context.Background(), &pb.ShowIdentityRequest{Uuid: "perm-uuid-1"})
+	if got := status.Code(err); got != codes.PermissionDenied {
+		t.Fatalf("status code = %v, want %v (err: %v)", got, codes.PermissionDenied, err)
+	}
+}
+
+func TestShowIdentityDeletedAfterLookup(t *testing.T) {
+	root := t.TempDir()
+	seedHolon(t, root, "gone-uuid-1", "Gone")
+	raceReadFile(t, os.Remove)
+
+	client, cleanup := startTestServer(t, root)
+	defer cleanup()
+
+	_, err := client.ShowIdentity(context.Background(), &pb.ShowIdentityRequest{Uuid: "gone-uuid-1"})
+	if got := status.Code(err); got != codes.NotFound {
+		t.Fatalf("status code = %v, want %v (err: %v)", got, codes.NotFound, err)
+	}
+}
+
 func TestCreateIdentity(t *testing.T) {
 	root := t.TempDir()
 
@@ -304,7 +546,7 @@ func TestStringToStatusAllValues(t *testing.T) {
 		{"stable", pb.Status_STABLE},
 		{"deprecated", pb.Status_DEPRECATED},
 		{"dead", pb.Status_DEAD},
-		{"unknown", pb.Status_STATUS_UNSPECIFIED},
+		{"", pb.Status_STATUS_UNSPECIFIED},
 	}
 	for _, tc := range cases {
 		got := stringToStatus(tc.s)
@@ -314,6 +556,22 @@ func TestStringToStatusAllValues(t *testing.T) {
 	}
 }
 
+func TestCustomStatusMapsToStatusCustom(t *testing.T) {
+	if got := stringToStatus("frozen"); got != pb.Status_STATUS_CUSTOM {
+		t.Errorf("stringToStatus(frozen) = %v, want STATUS_CUSTOM", got)
+	}
+
+	id := identity.New()
+	id.Status = "frozen"
+	p := toProto(id)
+	if p.Status != pb.Status_STATUS_CUSTOM || p.CustomStatus != "frozen" {
+		t.Errorf("toProto status = %v/%q, want STATUS_CUSTOM/frozen", p.Status, p.CustomStatus)
+	}
+	if p.ProtoStatus != pb.Status_DRAFT || p.CustomProtoStatus != "" {
+		t.Errorf("toProto proto_status = %v/%q, want DRAFT with no custom value", p.ProtoStatus, p.CustomProtoStatus)
+	}
+}
+
 func TestReproductionToStringAllValues(t *testing.T) {
 	cases := []struct {
 		mode pb.ReproductionMode
@@ -387,6 +645,125 @@ func TestCreateIdentityAllFields(t *testing.T) {
 	}
 }
 
+func TestCreateIdentityAppliesDefaults(t *testing.T) {
+	root := t.TempDir()
+
+	original, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(root); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(original) //nolint:errcheck
+
+	srv := &Server{Defaults: identity.Config{
+		Composer:     "B. ALTER",
+		Lang:         "rust",
+		Clade:        "probabilistic/adaptive",
+		Reproduction: "assisted",
+		OutputRoot:   "agents",
+		IDScheme:     identity.IDSchemeULID,
+	}}
+
+	resp, err := srv.CreateIdentity(context.Background(), &pb.CreateIdentityRequest{
+		GivenName:  "Quiet",
+		FamilyName: "Listener",
+		Motto:      "Defaults matter.",
+	})
+	if err != nil {
+		t.Fatalf("CreateIdentity failed: %v", err)
+	}
+
+	if resp.Identity.Composer != "B. ALTER" {
+		t.Errorf("Composer = %q, want %q", resp.Identity.Composer, "B. ALTER")
+	}
+	if resp.Identity.Lang != "rust" {
+		t.Errorf("Lang = %q, want %q", resp.Identity.Lang, "rust")
+	}
+	if resp.Identity.Clade != pb.Clade_PROBABILISTIC_ADAPTIVE {
+		t.Errorf("Clade = %v, want PROBABILISTIC_ADAPTIVE", resp.Identity.Clade)
+	}
+	if resp.Identity.Reproduction != pb.ReproductionMode_ASSISTED {
+		t.Errorf("Reproduction = %v, want ASSISTED", resp.Identity.Reproduction)
+	}
+	if want := filepath.Join("agents", "quiet-listener", "HOLON.md"); resp.FilePath != want {
+		t.Errorf("FilePath = %q, want %q", resp.FilePath, want)
+	}
+	if !identity.IsULID(resp.Identity.Uuid) {
+		t.Errorf("Uuid = %q, want a ULID from the id_scheme default", resp.Identity.Uuid)
+	}
+
+	// Explicit fields override the defaults.
+	resp, err = srv.CreateIdentity(context.Background(), &pb.CreateIdentityRequest{
+		GivenName:  "Loud",
+		FamilyName: "Speaker",
+		Motto:      "Explicit wins.",
+		Composer:   "Someone Else",
+		Clade:      pb.Clade_DETERMINISTIC_PURE,
+		Lang:       "go",
+	})
+	if err != nil {
+		t.Fatalf("CreateIdentity failed: %v", err)
+	}
+	if resp.Identity.Composer != "Someone Else" || resp.Identity.Lang != "go" || resp.Identity.Clade != pb.Clade_DETERMINISTIC_PURE {
+		t.Errorf("explicit fields not honored: %+v", resp.Identity)
+	}
+}
+
+func TestServerRootScopesRPCs(t *testing.T) {
+	root := t.TempDir()
+	seedHolon(t, root, "root-uuid-1", "RootAlpha")
+	seedHolon(t, root, "root-uuid-2", "RootBeta")
+
+	lis := bufconn.Listen(bufSize)
+	s := newGRPCServer(Options{Root: root})
+	go func() { _ = s.Serve(lis) }()
+	defer s.Stop()
+
+	conn, err := grpc.NewClient(
+		"passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	client := pb.NewSophiaWhoServiceClient(conn)
+
+	list, err := client.ListIdentities(context.Background(), &pb.ListIdentitiesRequest{})
+	if err != nil {
+		t.Fatalf("ListIdentities failed: %v", err)
+	}
+	if len(list.Entries) != 2 {
+		t.Fatalf("ListIdentities returned %d entries, want 2", len(list.Entries))
+	}
+
+	show, err := client.ShowIdentity(context.Background(), &pb.ShowIdentityRequest{Uuid: "root-uuid-2"})
+	if err != nil {
+		t.Fatalf("ShowIdentity failed: %v", err)
+	}
+	if show.Identity.GivenName != "RootBeta" {
+		t.Errorf("GivenName = %q, want RootBeta", show.Identity.GivenName)
+	}
+
+	created, err := client.CreateIdentity(context.Background(), &pb.CreateIdentityRequest{
+		GivenName:  "Rooted",
+		FamilyName: "Child",
+		Motto:      "Born under root.",
+		Composer:   "Test",
+	})
+	if err != nil {
+		t.Fatalf("CreateIdentity failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(root, "holons", "rooted-child", "HOLON.md")); err != nil {
+		t.Errorf("CreateIdentity did not write under the server root (%s): %v", created.FilePath, err)
+	}
+}
+
 // --- ListenAndServe error (port conflict) ---
 
 func TestListenAndServePortConflict(t *testing.T) {
@@ -488,6 +865,60 @@ func TestListenAndServeStartStopNoReflect(t *testing.T) {
 	}
 }
 
+func TestServeClosesServiceOnShutdown(t *testing.T) {
+	root := t.TempDir()
+	seedHolon(t, root, "close-uuid-1", "Closing")
+
+	// Every goroutine started for serving must be gone once serve has
+	// returned and the service is closed.
+	defer goleak.VerifyNone(t, goleak.IgnoreCurrent())
+
+	lis := bufconn.Listen(bufSize)
+	svc := newService(Options{Root: root, ListCacheTTL: time.Hour})
+	ctx, cancel := context.WithCancel(context.Background())
+	errCh := make(chan error, 1)
+	go func() { errCh <- serve(ctx, lis, svc, Options{Reflect: true}, false) }()
+
+	conn, err := grpc.NewClient(
+		"passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := pb.NewSophiaWhoServiceClient(conn).ListIdentities(context.Background(), &pb.ListIdentitiesRequest{}); err != nil {
+		t.Fatalf("ListIdentities failed: %v", err)
+	}
+	if _, ok := svc.listCache.get(root); !ok {
+		t.Fatal("list response was not cached")
+	}
+	conn.Close()
+
+	cancel()
+	select {
+	case err := <-errCh:
+		if err != nil {
+			t.Fatalf("serve returned %v after shutdown, want nil", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("serve did not return after shutdown")
+	}
+
+	if _, ok := svc.listCache.get(root); ok {
+		t.Error("list cache was not flushed on shutdown")
+	}
+	svc.listCache.put(root, svc.listCache.generation(), &pb.ListIdentitiesResponse{}, time.Hour)
+	if _, ok := svc.listCache.get(root); ok {
+		t.Error("closed server still caches responses")
+	}
+	if err := svc.Close(); err != nil {
+		t.Errorf("second Close: %v", err)
+	}
+}
+
 func TestCreateIdentityReadOnlyDir(t *testing.T) {
 	root := t.TempDir()
 
@@ -628,3 +1059,461 @@ func TestWSTransport(t *testing.T) {
 		t.Errorf("ListIdentities returned %d entries, want 1", len(resp.Entries))
 	}
 }
+
+// --- CLI listen URIs ---
+
+func TestListenRejectsMem(t *testing.T) {
+	lis, err := Listen("mem://")
+	if err == nil {
+		lis.Close()
+		t.Fatal("expected error for mem:// listen URI")
+	}
+	if !strings.Contains(err.Error(), "in-process") {
+		t.Errorf("error %q should explain that mem:// is in-process only", err)
+	}
+}
+
+func TestListenServesWS(t *testing.T) {
+	root := t.TempDir()
+	seedHolon(t, root, "cli-ws-uuid-1", "CLIWSTest")
+
+	original, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(root); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(original) //nolint:errcheck
+
+	lis, err := Listen("ws://127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen ws://: %v", err)
+	}
+	defer lis.Close()
+
+	s := newGRPCServer(Options{Reflect: true})
+	go func() { _ = s.Serve(lis) }()
+	defer s.Stop()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	c, _, err := websocket.Dial(ctx, lis.Addr().String(), &websocket.DialOptions{
+		Subprotocols: []string{"grpc"},
+	})
+	if err != nil {
+		t.Fatalf("ws dial: %v", err)
+	}
+	wsConn := websocket.NetConn(ctx, c, websocket.MessageBinary)
+
+	dialed := false
+	conn, err := grpc.NewClient(
+		"passthrough:///ws",
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			if dialed {
+				return nil, fmt.Errorf("already consumed")
+			}
+			dialed = true
+			return wsConn, nil
+		}),
+	)
+	if err != nil {
+		wsConn.Close()
+		t.Fatalf("grpc client over ws: %v", err)
+	}
+	defer conn.Close()
+
+	resp, err := pb.NewSophiaWhoServiceClient(conn).ListIdentities(ctx, &pb.ListIdentitiesRequest{})
+	if err != nil {
+		t.Fatalf("ListIdentities over ws://: %v", err)
+	}
+	if len(resp.Entries) != 1 || resp.Entries[0].Identity.Uuid != "cli-ws-uuid-1" {
+		t.Errorf("ListIdentities over ws:// returned %v, want the seeded holon", resp.Entries)
+	}
+}
+
+func TestListIdentitiesTruncatesAtMaxScanResults(t *testing.T) {
+	root := t.TempDir()
+	for i := range 5 {
+		seedHolon(t, root, fmt.Sprintf("cap-uuid-%d", i), fmt.Sprintf("Cap%d", i))
+	}
+
+	list := func(max int) *pb.ListIdentitiesResponse {
+		t.Helper()
+		srv := &Server{Root: root, MaxScanResults: max}
+		resp, err := srv.ListIdentities(context.Background(), &pb.ListIdentitiesRequest{})
+		if err != nil {
+			t.Fatalf("ListIdentities failed: %v", err)
+		}
+		return resp
+	}
+
+	resp := list(3)
+	if !resp.GetTruncated() || len(resp.GetEntries()) != 3 {
+		t.Errorf("cap 3: truncated=%v entries=%d, want true and 3", resp.GetTruncated(), len(resp.GetEntries()))
+	}
+
+	resp = list(5)
+	if resp.GetTruncated() || len(resp.GetEntries()) != 5 {
+		t.Errorf("cap 5: truncated=%v entries=%d, want false and 5", resp.GetTruncated(), len(resp.GetEntries()))
+	}
+
+	resp = list(0)
+	if resp.GetTruncated() || len(resp.GetEntries()) != 5 {
+		t.Errorf("no cap: truncated=%v entries=%d, want false and 5", resp.GetTruncated(), len(resp.GetEntries()))
+	}
+}
+
+func TestListIdentitiesTagsWithMaxScanResults(t *testing.T) {
+	root := t.TempDir()
+	for i := range 4 {
+		seedHolon(t, root, fmt.Sprintf("cap-tag-%d", i), fmt.Sprintf("Plain%d", i))
+	}
+	tagged := identity.New()
+	tagged.GivenName, tagged.FamilyName, tagged.Motto, tagged.Composer = "Zeta", "Tagged", "Last in the walk.", "Test"
+	tagged.Tags = identity.StringList{"audio"}
+	if err := os.MkdirAll(filepath.Join(root, "Zeta"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := identity.WriteHolonMD(tagged, filepath.Join(root, "Zeta", "HOLON.md")); err != nil {
+		t.Fatal(err)
+	}
+
+	srv := &Server{Root: root, MaxScanResults: 2}
+	resp, err := srv.ListIdentities(context.Background(), &pb.ListIdentitiesRequest{Tags: []string{"audio"}})
+	if err != nil {
+		t.Fatalf("ListIdentities failed: %v", err)
+	}
+	if resp.GetTruncated() || len(resp.GetEntries()) != 1 || resp.GetEntries()[0].GetIdentity().GetUuid() != tagged.UUID {
+		t.Errorf("tagged listing = %v (truncated=%v), want only Zeta, not truncated", resp.GetEntries(), resp.GetTruncated())
+	}
+}
+
+func TestListIdentitiesCacheWriteDuringScan(t *testing.T) {
+	root := t.TempDir()
+	seedHolon(t, root, "race-uuid-1", "Before")
+	srv := &Server{Root: root, ListCacheTTL: time.Hour}
+
+	// A create lands while the first listing is scanning, after the scan
+	// has read the tree: that listing must not be cached.
+	var once sync.Once
+	original := scanWithOptions
+	scanWithOptions = func(root string, opts identity.ScanOptions, fn func(identity.LocatedIdentity), progress func(identity.ScanProgress)) error {
+		err := original(root, opts, fn, progress)
+		once.Do(func() {
+			done := make(chan error)
+			go func() {
+				_, err := srv.CreateIdentity(context.Background(), validCreateReq(filepath.Join(root, "after")))
+				done <- err
+			}()
+			if err := <-done; err != nil {
+				t.Errorf("CreateIdentity failed: %v", err)
+			}
+		})
+		return err
+	}
+	t.Cleanup(func() { scanWithOptions = original })
+
+	for i, want := range []int{1, 2} {
+		resp, err := srv.ListIdentities(context.Background(), &pb.ListIdentitiesRequest{})
+		if err != nil {
+			t.Fatalf("ListIdentities failed: %v", err)
+		}
+		if len(resp.GetEntries()) != want {
+			t.Errorf("list %d: %d entries, want %d", i+1, len(resp.GetEntries()), want)
+		}
+	}
+}
+
+func TestListIdentitiesCache(t *testing.T) {
+	root := t.TempDir()
+	seedHolon(t, root, "cache-uuid-1", "Cached")
+
+	scans := 0
+	original := scanWithOptions
+	scanWithOptions = func(root string, opts identity.ScanOptions, fn func(identity.LocatedIdentity), progress func(identity.ScanProgress)) error {
+		scans++
+		return original(root, opts, fn, progress)
+	}
+	t.Cleanup(func() { scanWithOptions = original })
+
+	srv := &Server{Root: root, ListCacheTTL: time.Hour}
+	list := func() *pb.ListIdentitiesResponse {
+		t.Helper()
+		resp, err := srv.ListIdentities(context.Background(), &pb.ListIdentitiesRequest{})
+		if err != nil {
+			t.Fatalf("ListIdentities failed: %v", err)
+		}
+		return resp
+	}
+
+	list()
+	if resp := list(); scans != 1 || len(resp.GetEntries()) != 1 {
+		t.Fatalf("second list: scans=%d entries=%d, want 1 and 1", scans, len(resp.GetEntries()))
+	}
+
+	_, err := srv.CreateIdentity(context.Background(), &pb.CreateIdentityRequest{
+		GivenName:  "Fresh",
+		FamilyName: "Holon",
+		Motto:      "Seen at once.",
+		Composer:   "Test",
+		Clade:      pb.Clade_DETERMINISTIC_PURE,
+	})
+	if err != nil {
+		t.Fatalf("CreateIdentity failed: %v", err)
+	}
+	if resp := list(); scans != 2 || len(resp.GetEntries()) != 2 {
+		t.Errorf("list after create: scans=%d entries=%d, want 2 and 2", scans, len(resp.GetEntries()))
+	}
+
+	// Without a TTL every call scans.
+	uncached := &Server{Root: root}
+	for range 2 {
+		if _, err := uncached.ListIdentities(context.Background(), &pb.ListIdentitiesRequest{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if scans != 4 {
+		t.Errorf("uncached scans = %d, want 4", scans)
+	}
+}
+
+func TestRootDirAllowlist(t *testing.T) {
+	root := t.TempDir()
+	seedHolon(t, filepath.Join(root, "public"), "allow-uuid-1", "Open")
+	seedHolon(t, filepath.Join(root, "private"), "allow-uuid-2", "Closed")
+	if err := os.Symlink(filepath.Join(root, "private"), filepath.Join(root, "public", "link")); err != nil {
+		t.Fatal(err)
+	}
+
+	srv := &Server{Root: root, AllowedRoots: []string{"public"}}
+	ctx := context.Background()
+
+	for _, dir := range []string{"public", filepath.Join("public", "Open"), filepath.Join(root, "public")} {
+		if _, err := srv.ListIdentities(ctx, &pb.ListIdentitiesRequest{RootDir: dir}); err != nil {
+			t.Errorf("ListIdentities(%q) = %v, want allowed", dir, err)
+		}
+	}
+	for _, dir := range []string{"private", "..", filepath.Join("public", ".."), filepath.Join("public", "link")} {
+		_, err := srv.ListIdentities(ctx, &pb.ListIdentitiesRequest{RootDir: dir})
+		if status.Code(err) != codes.PermissionDenied {
+			t.Errorf("ListIdentities(%q) code = %v, want PermissionDenied", dir, status.Code(err))
+		}
+	}
+	if _, err := srv.CountIdentities(ctx, &pb.CountIdentitiesRequest{RootDir: "private"}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("CountIdentities(private) code = %v, want PermissionDenied", status.Code(err))
+	}
+
+	// No root_dir scans the server root; no allowlist allows any root.
+	if resp, err := srv.ListIdentities(ctx, &pb.ListIdentitiesRequest{}); err != nil || len(resp.GetEntries()) != 2 {
+		t.Errorf("default root: %v entries, err %v", len(resp.GetEntries()), err)
+	}
+	open := &Server{Root: root}
+	if _, err := open.ListIdentities(ctx, &pb.ListIdentitiesRequest{RootDir: "private"}); err != nil {
+		t.Errorf("without an allowlist: %v", err)
+	}
+}
+
+func TestUpdateStatus(t *testing.T) {
+	root := t.TempDir()
+	seedHolon(t, root, "bulk-uuid-1", "First")
+	seedHolon(t, root, "bulk-uuid-2", "Second")
+	srv := &Server{Root: root}
+
+	resp, err := srv.UpdateStatus(context.Background(), &pb.UpdateStatusRequest{
+		Uuids:     []string{"bulk-uuid-1", "bulk-uuid-2", "missing"},
+		NewStatus: "deprecated",
+	})
+	if err != nil {
+		t.Fatalf("UpdateStatus failed: %v", err)
+	}
+	if len(resp.Results) != 3 {
+		t.Fatalf("results = %v, want 3", resp.Results)
+	}
+	for i, r := range resp.Results[:2] {
+		if r.Error != "" || r.PreviousStatus != "draft" {
+			t.Errorf("result %d = %+v, want success from draft", i, r)
+		}
+		data, err := os.ReadFile(r.FilePath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(string(data), `status: "deprecated"`) {
+			t.Errorf("%s not updated:\n%s", r.FilePath, data)
+		}
+	}
+	if resp.Results[2].Error == "" {
+		t.Error("missing holon reported no error")
+	}
+
+	if _, err := srv.UpdateStatus(context.Background(), &pb.UpdateStatusRequest{Uuids: []string{"bulk-uuid-1"}, NewStatus: "asleep"}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("unknown status: code = %v, want InvalidArgument", status.Code(err))
+	}
+}
+
+func TestUpdateStatusCustomStatusFromDefaults(t *testing.T) {
+	root := t.TempDir()
+	seedHolon(t, root, "8f5a1c2e-0000-4000-8000-000000000001", "Custom")
+	req := &pb.UpdateStatusRequest{Uuids: []string{"8f5a1c2e-0000-4000-8000-000000000001"}, NewStatus: "frozen"}
+
+	if _, err := (&Server{Root: root}).UpdateStatus(context.Background(), req); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("unconfigured status: code = %v, want InvalidArgument", status.Code(err))
+	}
+
+	srv := &Server{Root: root, Defaults: identity.Config{Statuses: []string{"frozen"}}}
+	resp, err := srv.UpdateStatus(context.Background(), req)
+	if err != nil || resp.Results[0].Error != "" {
+		t.Fatalf("UpdateStatus = %v, %v", resp, err)
+	}
+	data, err := os.ReadFile(resp.Results[0].FilePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if errs := identity.ValidateContent(data); len(errs) == 0 {
+		t.Error("ValidateContent accepted frozen without the config")
+	}
+	if errs := identity.ValidateContentWithOptions(data, srv.Defaults.ValidateOptions()); len(errs) != 0 {
+		t.Errorf("ValidateContentWithOptions = %v, want no errors", errs)
+	}
+}
+
+func TestCreateIdentityReservedAliasFromDefaults(t *testing.T) {
+	root := t.TempDir()
+	srv := &Server{Root: root, Defaults: identity.Config{ReservedAliases: []string{"legacy"}}}
+
+	req := validCreateReq(filepath.Join(root, "reserved"))
+	req.Aliases = []string{"legacy"}
+	if _, err := srv.CreateIdentity(context.Background(), req); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("alias reserved by the config: code = %v, want InvalidArgument", status.Code(err))
+	}
+	if _, err := (&Server{Root: root}).CreateIdentity(context.Background(), req); err != nil {
+		t.Errorf("alias reserved only by another config: %v", err)
+	}
+}
+
+func TestUpdateStatusDuplicateTargets(t *testing.T) {
+	root := t.TempDir()
+	seedHolon(t, root, "bulk-dup-1", "Alpha")
+	seedHolon(t, root, "bulk-dup-2", "Beta")
+
+	var mu sync.Mutex
+	calls := map[string]int{}
+	original := setStatus
+	setStatus = func(path, st string, opts identity.ValidateOptions) (identity.Identity, string, error) {
+		mu.Lock()
+		calls[path]++
+		mu.Unlock()
+		return original(path, st, opts)
+	}
+	t.Cleanup(func() { setStatus = original })
+
+	uuids := []string{"bulk-dup-1", "Alpha Test", "bulk-dup-2", "bulk-dup-1"}
+	srv := &Server{Root: root, BulkConcurrency: 4}
+	resp, err := srv.UpdateStatus(context.Background(), &pb.UpdateStatusRequest{Uuids: uuids, NewStatus: "stable"})
+	if err != nil {
+		t.Fatalf("UpdateStatus failed: %v", err)
+	}
+	for i, r := range resp.GetResults() {
+		if r.GetUuid() != uuids[i] || r.GetError() != "" || r.GetPreviousStatus() != "draft" {
+			t.Errorf("result %d = %+v, want %s updated from draft", i, r, uuids[i])
+		}
+	}
+	if len(calls) != 2 || calls[filepath.Join(root, "Alpha", "HOLON.md")] != 1 {
+		t.Errorf("rewrites per file = %v, want each holon once", calls)
+	}
+}
+
+func TestUpdateStatusBulkConcurrency(t *testing.T) {
+	root := t.TempDir()
+	const n, limit = 20, 2
+	var uuids []string
+	for i := range n {
+		uuid := fmt.Sprintf("bulk-many-%02d", i)
+		seedHolon(t, root, uuid, fmt.Sprintf("Holon%02d", i))
+		uuids = append(uuids, uuid)
+	}
+
+	var mu sync.Mutex
+	inFlight, peak := 0, 0
+	original := setStatus
+	setStatus = func(path, st string, opts identity.ValidateOptions) (identity.Identity, string, error) {
+		mu.Lock()
+		inFlight++
+		peak = max(peak, inFlight)
+		mu.Unlock()
+		defer func() {
+			mu.Lock()
+			inFlight--
+			mu.Unlock()
+		}()
+		time.Sleep(2 * time.Millisecond)
+		return original(path, st, opts)
+	}
+	t.Cleanup(func() { setStatus = original })
+
+	srv := &Server{Root: root, BulkConcurrency: limit}
+	resp, err := srv.UpdateStatus(context.Background(), &pb.UpdateStatusRequest{Uuids: uuids, NewStatus: "stable"})
+	if err != nil {
+		t.Fatalf("UpdateStatus failed: %v", err)
+	}
+	for i, r := range resp.GetResults() {
+		if r.GetUuid() != uuids[i] || r.GetError() != "" || r.GetPreviousStatus() != "draft" {
+			t.Errorf("result %d = %+v, want %s updated from draft", i, r, uuids[i])
+		}
+	}
+	if len(resp.GetResults()) != n {
+		t.Errorf("%d results, want %d", len(resp.GetResults()), n)
+	}
+	if peak > limit {
+		t.Errorf("peak concurrency = %d, want at most %d", peak, limit)
+	}
+}
+
+func TestRelativeHolonDir(t *testing.T) {
+	root := t.TempDir()
+	if got := relativeHolonDir(root, filepath.Join(root, "holons", "a", "HOLON.md")); got != filepath.Join("holons", "a") {
+		t.Errorf("in root = %q, want holons/a", got)
+	}
+	outside := filepath.Join(filepath.Dir(root), "cache", "b", "HOLON.md")
+	if got := relativeHolonDir(root, outside); got != filepath.Dir(outside) {
+		t.Errorf("outside root = %q, want %q", got, filepath.Dir(outside))
+	}
+}
+
+func TestUnixSocketPermsAndGroup(t *testing.T) {
+	sock := filepath.Join(t.TempDir(), "who.sock")
+	opts := Options{UnixSocketPerms: 0o660, UnixSocketGroup: fmt.Sprint(os.Getgid())}
+
+	lis, err := listenWithOptions("unix://"+sock, opts)
+	if err != nil {
+		t.Fatalf("listenWithOptions: %v", err)
+	}
+	s := newGRPCServer(opts)
+	go func() { _ = s.Serve(lis) }()
+	defer s.Stop()
+
+	info, err := os.Stat(sock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := info.Mode().Perm(); got != 0o660 {
+		t.Errorf("socket permissions = %o, want 660", got)
+	}
+}
+
+func TestUnixSocketPermsIgnoredForTCP(t *testing.T) {
+	lis, err := listenWithOptions("tcp://127.0.0.1:0", Options{UnixSocketPerms: 0o600, UnixSocketGroup: "no-such-group"})
+	if err != nil {
+		t.Fatalf("socket options should not apply to tcp://: %v", err)
+	}
+	lis.Close()
+}
+
+func TestUnixSocketUnknownGroup(t *testing.T) {
+	sock := filepath.Join(t.TempDir(), "who.sock")
+	if _, err := listenWithOptions("unix://"+sock, Options{UnixSocketGroup: "no-such-group-sophia"}); err == nil {
+		t.Fatal("expected an error for an unknown socket group")
+	}
+}
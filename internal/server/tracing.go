@@ -0,0 +1,175 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+)
+
+// tracerName identifies the spans sophia-who starts itself, such as
+// scans, as opposed to those of the gRPC instrumentation.
+const tracerName = "github.com/organic-programming/sophia-who/internal/server"
+
+// Tracing records an OpenTelemetry span for every RPC, through the
+// otelgrpc instrumentation, with child spans for the scans an RPC runs.
+// The zero value disables tracing at no cost.
+type Tracing struct {
+	// TracerProvider creates the spans. Nil disables tracing.
+	TracerProvider trace.TracerProvider
+}
+
+// TracingFromEnv configures tracing from the standard OpenTelemetry
+// environment variables. Tracing is on when enabled is set (the --otel
+// flag) or OTEL_TRACES_EXPORTER names an exporter, and off when
+// OTEL_SDK_DISABLED is true or the exporter is "none".
+//
+// The otlp exporter, the default, sends spans to a collector over the
+// protocol named by OTEL_EXPORTER_OTLP_TRACES_PROTOCOL or
+// OTEL_EXPORTER_OTLP_PROTOCOL (http/protobuf unless grpc is given), and
+// honours the other OTEL_EXPORTER_OTLP_* variables, such as the
+// endpoint and headers. The console exporter writes spans to stderr.
+// OTEL_SERVICE_NAME and OTEL_RESOURCE_ATTRIBUTES describe the service,
+// named sophia-who by default.
+//
+// Call Shutdown on the result to flush spans before exiting.
+func TracingFromEnv(enabled bool) (Tracing, error) {
+	exporter := strings.TrimSpace(os.Getenv("OTEL_TRACES_EXPORTER"))
+	if strings.EqualFold(os.Getenv("OTEL_SDK_DISABLED"), "true") || exporter == "none" || (!enabled && exporter == "") {
+		return Tracing{}, nil
+	}
+
+	ctx := context.Background()
+	var exp sdktrace.SpanExporter
+	var err error
+	switch exporter {
+	case "", "otlp":
+		exp, err = newOTLPExporter(ctx)
+	case "console":
+		exp, err = stdouttrace.New(stdouttrace.WithWriter(os.Stderr))
+	default:
+		return Tracing{}, fmt.Errorf("OTEL_TRACES_EXPORTER %q is not supported (want otlp, console, or none)", exporter)
+	}
+	if err != nil {
+		return Tracing{}, fmt.Errorf("tracing: %w", err)
+	}
+
+	res, err := resource.New(ctx,
+		resource.WithAttributes(attribute.String("service.name", "sophia-who")),
+		resource.WithFromEnv(),
+		resource.WithTelemetrySDK(),
+	)
+	if err != nil {
+		exp.Shutdown(ctx) //nolint:errcheck
+		return Tracing{}, fmt.Errorf("tracing: %w", err)
+	}
+	tp := sdktrace.NewTracerProvider(sdktrace.WithBatcher(exp), sdktrace.WithResource(res))
+	return Tracing{TracerProvider: tp}, nil
+}
+
+// newOTLPExporter returns the OTLP span exporter for the protocol the
+// environment asks for. The exporters read the remaining
+// OTEL_EXPORTER_OTLP_* variables themselves.
+func newOTLPExporter(ctx context.Context) (sdktrace.SpanExporter, error) {
+	protocol := strings.TrimSpace(os.Getenv("OTEL_EXPORTER_OTLP_TRACES_PROTOCOL"))
+	if protocol == "" {
+		protocol = strings.TrimSpace(os.Getenv("OTEL_EXPORTER_OTLP_PROTOCOL"))
+	}
+	switch protocol {
+	case "", "http/protobuf":
+		return otlptracehttp.New(ctx)
+	case "grpc":
+		return otlptracegrpc.New(ctx)
+	default:
+		return nil, fmt.Errorf("OTLP protocol %q is not supported (want grpc or http/protobuf)", protocol)
+	}
+}
+
+// Shutdown flushes the spans not yet exported and stops the tracer
+// provider, when it is one that can be stopped. It is a no-op when
+// tracing is off.
+func (t Tracing) Shutdown(ctx context.Context) error {
+	if tp, ok := t.TracerProvider.(interface{ Shutdown(context.Context) error }); ok {
+		return tp.Shutdown(ctx)
+	}
+	return nil
+}
+
+// serverOptions returns the stats handler starting a span per RPC. It
+// continues the caller's trace when the request carries a W3C
+// traceparent header.
+func (t Tracing) serverOptions() []grpc.ServerOption {
+	if t.TracerProvider == nil {
+		return nil
+	}
+	return []grpc.ServerOption{grpc.StatsHandler(otelgrpc.NewServerHandler(
+		otelgrpc.WithTracerProvider(t.TracerProvider),
+		otelgrpc.WithPropagators(propagation.TraceContext{}),
+	))}
+}
+
+// httpHandler wraps h, serving route, to start a server span per
+// request as serverOptions does per RPC, continuing the caller's trace
+// when the request carries a W3C traceparent header.
+func (t Tracing) httpHandler(route string, h http.HandlerFunc) http.HandlerFunc {
+	if t.TracerProvider == nil {
+		return h
+	}
+	tracer := t.TracerProvider.Tracer(tracerName)
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := propagation.TraceContext{}.Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+		ctx, span := tracer.Start(ctx, route, trace.WithSpanKind(trace.SpanKindServer), trace.WithAttributes(
+			attribute.String("http.request.method", r.Method),
+			attribute.String("http.route", route),
+		))
+		defer span.End()
+
+		rec := &statusRecorder{ResponseWriter: w, code: http.StatusOK}
+		h(rec, r.WithContext(ctx))
+		span.SetAttributes(attribute.Int("http.response.status_code", rec.code))
+		if rec.code >= http.StatusInternalServerError {
+			span.SetStatus(otelcodes.Error, http.StatusText(rec.code))
+		}
+	}
+}
+
+// statusRecorder remembers the status code written through it.
+type statusRecorder struct {
+	http.ResponseWriter
+	code int
+}
+
+func (r *statusRecorder) WriteHeader(code int) {
+	r.code = code
+	r.ResponseWriter.WriteHeader(code)
+}
+
+// startSpan starts a child of the span in ctx, from the same tracer
+// provider. Without one, tracing is off and the span records nothing,
+// so handlers can trace unconditionally.
+func startSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	tracer := trace.SpanFromContext(ctx).TracerProvider().Tracer(tracerName)
+	return tracer.Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// endSpan finishes span with the outcome err.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(otelcodes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,149 @@
+package server
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	pb "github.com/organic-programming/sophia-who/gen/go/sophia_who/v1"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// spansNamed waits for exporter to hold a span called name, as the RPC
+// span may end just after the client has its response, and returns
+// every span with that name.
+func spansNamed(t *testing.T, exporter *tracetest.InMemoryExporter, name string) tracetest.SpanStubs {
+	t.Helper()
+	for deadline := time.Now().Add(5 * time.Second); ; {
+		var out tracetest.SpanStubs
+		for _, s := range exporter.GetSpans() {
+			if s.Name == name {
+				out = append(out, s)
+			}
+		}
+		if len(out) > 0 || time.Now().After(deadline) {
+			return out
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+// attr returns the value of the attribute key in attrs.
+func attr(attrs []attribute.KeyValue, key attribute.Key) attribute.Value {
+	for _, kv := range attrs {
+		if kv.Key == key {
+			return kv.Value
+		}
+	}
+	return attribute.Value{}
+}
+
+func TestTracingRecordsListIdentitiesSpans(t *testing.T) {
+	root := t.TempDir()
+	seedHolon(t, root, "trace-uuid-1", "Traced")
+
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	tracing := Tracing{TracerProvider: tp}
+	defer tracing.Shutdown(context.Background())
+
+	lis := bufconn.Listen(bufSize)
+	srv := newGRPCServer(Options{Root: root, Tracing: tracing})
+	go func() { _ = srv.Serve(lis) }()
+	defer srv.Stop()
+
+	conn, err := grpc.NewClient(
+		"passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	const traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "traceparent", "00-"+traceID+"-00f067aa0ba902b7-01")
+	if _, err := pb.NewSophiaWhoServiceClient(conn).ListIdentities(ctx, &pb.ListIdentitiesRequest{}); err != nil {
+		t.Fatalf("ListIdentities failed: %v", err)
+	}
+
+	rpcs := spansNamed(t, exporter, "sophia_who.v1.SophiaWhoService/ListIdentities")
+	if len(rpcs) != 1 {
+		t.Fatalf("got %d ListIdentities spans, want 1: %+v", len(rpcs), exporter.GetSpans())
+	}
+	rpc := rpcs[0]
+	if rpc.SpanContext.TraceID().String() != traceID || rpc.Parent.SpanID().String() != "00f067aa0ba902b7" {
+		t.Errorf("rpc span trace=%s parent=%s, want the caller's traceparent", rpc.SpanContext.TraceID(), rpc.Parent.SpanID())
+	}
+	if got := attr(rpc.Attributes, "rpc.method").AsString(); got != "ListIdentities" {
+		t.Errorf("rpc.method = %q, want ListIdentities", got)
+	}
+
+	scans := spansNamed(t, exporter, "scan")
+	if len(scans) != 1 {
+		t.Fatalf("got %d scan spans, want 1", len(scans))
+	}
+	scan := scans[0]
+	if scan.SpanContext.TraceID() != rpc.SpanContext.TraceID() || scan.Parent.SpanID() != rpc.SpanContext.SpanID() {
+		t.Errorf("scan span is not a child of the rpc span: %+v", scan)
+	}
+	if got := attr(scan.Attributes, "scan.holons_found").AsInt64(); got != 1 {
+		t.Errorf("scan.holons_found = %d, want 1", got)
+	}
+	if scan.StartTime.Before(rpc.StartTime) || scan.EndTime.After(rpc.EndTime) {
+		t.Errorf("scan span [%v, %v] is outside the rpc span [%v, %v]", scan.StartTime, scan.EndTime, rpc.StartTime, rpc.EndTime)
+	}
+}
+
+func TestTracingFromEnv(t *testing.T) {
+	for _, key := range []string{"OTEL_TRACES_EXPORTER", "OTEL_SDK_DISABLED", "OTEL_EXPORTER_OTLP_PROTOCOL", "OTEL_EXPORTER_OTLP_TRACES_PROTOCOL"} {
+		t.Setenv(key, "")
+	}
+	enabled := func(t *testing.T, tr Tracing, err error) {
+		t.Helper()
+		if err != nil || tr.TracerProvider == nil {
+			t.Errorf("got %+v, %v; want tracing on", tr, err)
+		}
+		tr.Shutdown(context.Background())
+	}
+
+	if tr, err := TracingFromEnv(false); err != nil || tr.TracerProvider != nil {
+		t.Errorf("unconfigured: %+v, %v; want tracing off", tr, err)
+	}
+	tr, err := TracingFromEnv(true)
+	enabled(t, tr, err)
+
+	t.Setenv("OTEL_EXPORTER_OTLP_PROTOCOL", "grpc")
+	tr, err = TracingFromEnv(true)
+	enabled(t, tr, err)
+
+	t.Setenv("OTEL_EXPORTER_OTLP_TRACES_PROTOCOL", "http/json")
+	if _, err := TracingFromEnv(true); err == nil {
+		t.Error("unsupported OTLP protocol accepted")
+	}
+
+	t.Setenv("OTEL_TRACES_EXPORTER", "console")
+	tr, err = TracingFromEnv(false)
+	enabled(t, tr, err)
+
+	t.Setenv("OTEL_TRACES_EXPORTER", "none")
+	if tr, _ := TracingFromEnv(true); tr.TracerProvider != nil {
+		t.Error("OTEL_TRACES_EXPORTER=none did not disable tracing")
+	}
+
+	t.Setenv("OTEL_TRACES_EXPORTER", "zipkin")
+	if _, err := TracingFromEnv(false); err == nil {
+		t.Error("unsupported exporter accepted")
+	}
+}
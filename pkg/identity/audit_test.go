@@ -0,0 +1,48 @@
+package identity
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestAppendAndReadAudit(t *testing.T) {
+	root := t.TempDir()
+
+	if entries, err := ReadAudit(root); err != nil || len(entries) != 0 {
+		t.Fatalf("ReadAudit on a fresh root = %v, %v; want no entries", entries, err)
+	}
+
+	for _, e := range []AuditEntry{
+		{Op: AuditCreate, UUID: "u-1", Composer: "B. Alter"},
+		{Op: AuditUpdate, UUID: "u-1", Caller: "ci"},
+	} {
+		if err := AppendAudit(root, e); err != nil {
+			t.Fatalf("AppendAudit failed: %v", err)
+		}
+	}
+
+	entries, err := ReadAudit(root)
+	if err != nil {
+		t.Fatalf("ReadAudit failed: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("got %d entries, want 2", len(entries))
+	}
+	if entries[0].Op != AuditCreate || entries[0].Composer != "B. Alter" || entries[0].Time.IsZero() {
+		t.Errorf("first entry = %+v", entries[0])
+	}
+	if entries[1].Op != AuditUpdate || entries[1].Caller != "ci" {
+		t.Errorf("second entry = %+v", entries[1])
+	}
+}
+
+func TestAppendAuditFailsWhenLogDirIsAFile(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, ".holon"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := AppendAudit(root, AuditEntry{Op: AuditCreate, UUID: "u-1"}); err == nil {
+		t.Fatal("AppendAudit succeeded with .holon as a file")
+	}
+}
@@ -0,0 +1,93 @@
+package identity
+
+import (
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// unclassified heads the catalog section for holons without a clade.
+const unclassified = "unclassified"
+
+// CatalogMarkdown renders every holon under root as a Markdown page for
+// wikis: one section per clade, in the order of Clades followed by any
+// unknown clades alphabetically, and one table row per holon sorted by
+// name. Directory links are relative to root. The output depends only on
+// the holons found, so regenerating an unchanged tree gives no diff.
+func CatalogMarkdown(root string) (string, error) {
+	holons, err := FindAllWithPaths(root)
+	if err != nil {
+		return "", err
+	}
+
+	byClade := make(map[string][]LocatedIdentity)
+	for _, h := range holons {
+		clade := h.Identity.Clade
+		if clade == "" {
+			clade = unclassified
+		}
+		byClade[clade] = append(byClade[clade], h)
+	}
+
+	var clades []string
+	for _, c := range Clades {
+		if len(byClade[c]) > 0 {
+			clades = append(clades, c)
+		}
+	}
+	var extra []string
+	for c := range byClade {
+		if !slices.Contains(Clades, c) {
+			extra = append(extra, c)
+		}
+	}
+	sort.Strings(extra)
+	clades = append(clades, extra...)
+
+	var b strings.Builder
+	b.WriteString("# Holon catalog\n")
+	for _, clade := range clades {
+		entries := byClade[clade]
+		sort.Slice(entries, func(i, j int) bool {
+			ni, nj := catalogName(entries[i].Identity), catalogName(entries[j].Identity)
+			if ni != nj {
+				return ni < nj
+			}
+			return entries[i].Identity.UUID < entries[j].Identity.UUID
+		})
+
+		b.WriteString("\n## " + clade + "\n\n")
+		b.WriteString("| Name | Motto | Status | Directory |\n")
+		b.WriteString("| --- | --- | --- | --- |\n")
+		for _, h := range entries {
+			dir := catalogDir(root, h.Path)
+			b.WriteString("| " + markdownCell(catalogName(h.Identity)) +
+				" | " + markdownCell(h.Identity.Motto) +
+				" | " + markdownCell(h.Identity.Status) +
+				" | [" + markdownCell(dir) + "](" + dir + "/) |\n")
+		}
+	}
+	return b.String(), nil
+}
+
+func catalogName(id Identity) string {
+	return strings.TrimSpace(id.GivenName + " " + id.FamilyName)
+}
+
+// catalogDir returns the directory of holonPath relative to root, with
+// forward slashes so links work on every platform.
+func catalogDir(root, holonPath string) string {
+	dir := filepath.Dir(holonPath)
+	if rel, err := filepath.Rel(root, dir); err == nil {
+		dir = rel
+	}
+	return filepath.ToSlash(dir)
+}
+
+// markdownCell makes s safe inside a table cell: pipes are escaped and
+// line breaks folded into spaces.
+func markdownCell(s string) string {
+	s = strings.Join(strings.Fields(s), " ")
+	return strings.ReplaceAll(s, "|", `\|`)
+}
@@ -0,0 +1,55 @@
+package identity
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCatalogMarkdown(t *testing.T) {
+	root := t.TempDir()
+	seed := func(dir, given, clade, motto string) {
+		t.Helper()
+		id := validIdentity()
+		id.GivenName = given
+		id.Clade = clade
+		id.Motto = motto
+		path := filepath.Join(root, dir, "HOLON.md")
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := WriteHolonMD(id, path); err != nil {
+			t.Fatal(err)
+		}
+	}
+	seed("holons/zeta", "Zeta", "probabilistic/generative", "Dream | wake.")
+	seed("holons/beta", "Beta", "deterministic/pure", "Be pure.")
+	seed("holons/alpha", "Alpha", "deterministic/pure", "Be first.")
+
+	md, err := CatalogMarkdown(root)
+	if err != nil {
+		t.Fatalf("CatalogMarkdown failed: %v", err)
+	}
+
+	for _, want := range []string{
+		"## deterministic/pure\n",
+		"## probabilistic/generative\n",
+		"| Alpha Holon | Be first. | draft | [holons/alpha](holons/alpha/) |\n",
+		"| Beta Holon | Be pure. | draft | [holons/beta](holons/beta/) |\n",
+		`| Zeta Holon | Dream \| wake. | draft | [holons/zeta](holons/zeta/) |` + "\n",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("catalog missing %q:\n%s", want, md)
+		}
+	}
+	if strings.Index(md, "Alpha Holon") > strings.Index(md, "Beta Holon") ||
+		strings.Index(md, "## deterministic/pure") > strings.Index(md, "## probabilistic/generative") {
+		t.Errorf("catalog not in clade then name order:\n%s", md)
+	}
+
+	again, err := CatalogMarkdown(root)
+	if err != nil || again != md {
+		t.Errorf("second render differs (err %v)", err)
+	}
+}
@@ -0,0 +1,198 @@
+package identity
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigFileName is the name of the per-user or per-project defaults file.
+const ConfigFileName = ".holonrc"
+
+// Config holds default values for new identities, loaded from .holonrc.
+// Explicit flags and request fields always take precedence.
+type Config struct {
+	Composer     string `yaml:"composer,omitempty"`
+	Lang         string `yaml:"lang,omitempty"`
+	Clade        string `yaml:"clade,omitempty"`
+	Reproduction string `yaml:"reproduction,omitempty"`
+
+	// OutputRoot is the directory new holons are created under
+	// (default: holons). Each holon gets its own <slug>/ subdirectory.
+	OutputRoot string `yaml:"output_root,omitempty"`
+
+	// Statuses extends the built-in lifecycle stages (e.g. experimental,
+	// frozen). Validate with ValidateOptions to accept them.
+	Statuses []string `yaml:"statuses,omitempty"`
+
+	// ReservedAliases lists aliases to refuse besides the built-in
+	// reserved ones, e.g. names a team's tooling gives meaning to.
+	ReservedAliases []string `yaml:"reserved_aliases,omitempty"`
+
+	// IDScheme selects how new identities are numbered: "uuid" (the
+	// default) or "ulid". Config.New applies it.
+	IDScheme string `yaml:"id_scheme,omitempty"`
+
+	// Generator, when set, overrides IDScheme as the source of new ids,
+	// e.g. to make fixtures and golden files reproducible (see
+	// NewSeededGenerator). It is never read from .holonrc.
+	Generator IDGenerator `yaml:"-"`
+
+	// SortLists renders the aliases and parents of new holons in sorted
+	// order (see RenderOptions.SortLists).
+	SortLists bool `yaml:"sort_lists,omitempty"`
+}
+
+// LoadConfig reads $HOME/.holonrc and <root>/.holonrc, in that order.
+// Values from the root file override those from the home file.
+// Missing files are not an error.
+func LoadConfig(root string) (Config, error) {
+	var cfg Config
+
+	var paths []string
+	if home, err := os.UserHomeDir(); err == nil {
+		paths = append(paths, filepath.Join(home, ConfigFileName))
+	}
+	paths = append(paths, filepath.Join(root, ConfigFileName))
+
+	seen := map[string]bool{}
+	for _, path := range paths {
+		abs, err := filepath.Abs(path)
+		if err == nil {
+			if seen[abs] {
+				continue
+			}
+			seen[abs] = true
+		}
+
+		layer, err := readConfig(path)
+		if err != nil {
+			return Config{}, err
+		}
+		cfg = cfg.merge(layer)
+	}
+
+	return cfg, nil
+}
+
+// GitComposer returns git's user.name, then user.email, as configured
+// for dir, or "" when neither is set. It is a default for the local
+// user's own holons only: a server must not attribute remote requests
+// to the user it runs as.
+func GitComposer(dir string) string {
+	if name := gitConfigValue(dir, "user.name"); name != "" {
+		return name
+	}
+	return gitConfigValue(dir, "user.email")
+}
+
+// gitConfigValue returns the git config value of key as seen from dir,
+// or "" when it is unset or git is unavailable. Replaceable in tests.
+var gitConfigValue = func(dir, key string) string {
+	out, err := runGit(dir, "config", "--get", key)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+func readConfig(path string) (Config, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return Config{}, nil
+	}
+	if err != nil {
+		return Config{}, fmt.Errorf("cannot read %s: %w", path, err)
+	}
+
+	var cfg Config
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return Config{}, fmt.Errorf("%s: YAML parse error: %w", path, err)
+	}
+	if cfg.Clade != "" && !slices.Contains(Clades, cfg.Clade) {
+		return Config{}, fmt.Errorf("%s: unknown clade %q", path, cfg.Clade)
+	}
+	if cfg.Reproduction != "" && !slices.Contains(ReproductionModes, cfg.Reproduction) {
+		return Config{}, fmt.Errorf("%s: unknown reproduction mode %q", path, cfg.Reproduction)
+	}
+	if cfg.IDScheme != "" && cfg.IDScheme != IDSchemeUUID && cfg.IDScheme != IDSchemeULID {
+		return Config{}, fmt.Errorf("%s: unknown id_scheme %q (want %s or %s)", path, cfg.IDScheme, IDSchemeUUID, IDSchemeULID)
+	}
+	for _, st := range cfg.Statuses {
+		if strings.TrimSpace(st) == "" || strings.ContainsAny(st, " \t") {
+			return Config{}, fmt.Errorf("%s: invalid status %q", path, st)
+		}
+	}
+	for _, alias := range cfg.ReservedAliases {
+		if strings.TrimSpace(alias) == "" {
+			return Config{}, fmt.Errorf("%s: invalid reserved alias %q", path, alias)
+		}
+	}
+	return cfg, nil
+}
+
+// merge returns c with every non-empty field of override applied.
+func (c Config) merge(override Config) Config {
+	set := func(dst *string, v string) {
+		if strings.TrimSpace(v) != "" {
+			*dst = v
+		}
+	}
+	set(&c.Composer, override.Composer)
+	set(&c.Lang, override.Lang)
+	set(&c.Clade, override.Clade)
+	set(&c.Reproduction, override.Reproduction)
+	set(&c.OutputRoot, override.OutputRoot)
+	set(&c.IDScheme, override.IDScheme)
+	if override.SortLists {
+		c.SortLists = true
+	}
+	for _, st := range override.Statuses {
+		if !slices.Contains(c.Statuses, st) {
+			c.Statuses = append(c.Statuses, st)
+		}
+	}
+	for _, alias := range override.ReservedAliases {
+		if !slices.Contains(c.ReservedAliases, alias) {
+			c.ReservedAliases = append(c.ReservedAliases, alias)
+		}
+	}
+	return c
+}
+
+// ValidateOptions returns the options validating identities against c:
+// its custom statuses are accepted besides the built-in ones, and its
+// reserved aliases refused besides the built-in ones.
+func (c Config) ValidateOptions() ValidateOptions {
+	return ValidateOptions{Statuses: c.Statuses, ReservedAliases: c.ReservedAliases}
+}
+
+// Apply fills the empty fields of id with the configured defaults.
+func (c Config) Apply(id *Identity) {
+	if id.Composer == "" {
+		id.Composer = c.Composer
+	}
+	if id.Lang == "" {
+		id.Lang = c.Lang
+	}
+	if id.Clade == "" {
+		id.Clade = c.Clade
+	}
+	if id.Reproduction == "" {
+		id.Reproduction = c.Reproduction
+	}
+}
+
+// OutputDir returns the default directory for id: <output_root>/<slug>.
+func (c Config) OutputDir(id Identity) string {
+	root := c.OutputRoot
+	if root == "" {
+		root = "holons"
+	}
+	return filepath.Join(root, id.Slug())
+}
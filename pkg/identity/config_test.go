@@ -0,0 +1,173 @@
+package identity
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func writeConfig(t *testing.T, dir, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, ConfigFileName), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// fakeGitConfig makes GitComposer see values as the git configuration.
+func fakeGitConfig(t *testing.T, values map[string]string) {
+	t.Helper()
+	original := gitConfigValue
+	gitConfigValue = func(_, key string) string { return values[key] }
+	t.Cleanup(func() { gitConfigValue = original })
+}
+
+func TestLoadConfigAppliesDefaults(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	root := t.TempDir()
+	writeConfig(t, root, "composer: \"B. ALTER\"\nlang: rust\nclade: probabilistic/generative\nreproduction: assisted\noutput_root: agents\n")
+
+	cfg, err := LoadConfig(root)
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	id := New()
+	id.GivenName = "Swift"
+	id.FamilyName = "Prober"
+	cfg.Apply(&id)
+
+	if id.Composer != "B. ALTER" {
+		t.Errorf("Composer = %q, want %q", id.Composer, "B. ALTER")
+	}
+	if id.Lang != "rust" {
+		t.Errorf("Lang = %q, want %q", id.Lang, "rust")
+	}
+	if id.Clade != "probabilistic/generative" {
+		t.Errorf("Clade = %q, want %q", id.Clade, "probabilistic/generative")
+	}
+	if id.Reproduction != "assisted" {
+		t.Errorf("Reproduction = %q, want %q", id.Reproduction, "assisted")
+	}
+	if got, want := cfg.OutputDir(id), filepath.Join("agents", "swift-prober"); got != want {
+		t.Errorf("OutputDir = %q, want %q", got, want)
+	}
+}
+
+func TestConfigApplyKeepsExplicitValues(t *testing.T) {
+	cfg := Config{Composer: "Default", Lang: "go"}
+
+	id := Identity{Composer: "Explicit"}
+	cfg.Apply(&id)
+
+	if id.Composer != "Explicit" {
+		t.Errorf("Composer = %q, want explicit value kept", id.Composer)
+	}
+	if id.Lang != "go" {
+		t.Errorf("Lang = %q, want default %q", id.Lang, "go")
+	}
+}
+
+func TestLoadConfigRootOverridesHome(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	writeConfig(t, home, "composer: Home\nlang: go\n")
+
+	root := t.TempDir()
+	writeConfig(t, root, "composer: Project\n")
+
+	cfg, err := LoadConfig(root)
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if cfg.Composer != "Project" {
+		t.Errorf("Composer = %q, want %q", cfg.Composer, "Project")
+	}
+	if cfg.Lang != "go" {
+		t.Errorf("Lang = %q, want %q (from home)", cfg.Lang, "go")
+	}
+}
+
+func TestLoadConfigMissingFiles(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	fakeGitConfig(t, nil)
+
+	cfg, err := LoadConfig(t.TempDir())
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if !reflect.DeepEqual(cfg, Config{}) {
+		t.Errorf("LoadConfig = %+v, want zero Config", cfg)
+	}
+	if got, want := cfg.OutputDir(Identity{GivenName: "A", FamilyName: "B"}), filepath.Join("holons", "a-b"); got != want {
+		t.Errorf("OutputDir = %q, want %q", got, want)
+	}
+}
+
+func TestGitComposer(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	fakeGitConfig(t, map[string]string{"user.name": "Ada Lovelace", "user.email": "ada@example.com"})
+
+	root := t.TempDir()
+	if got := GitComposer(root); got != "Ada Lovelace" {
+		t.Errorf("GitComposer = %q, want git user.name", got)
+	}
+	if cfg, err := LoadConfig(root); err != nil || cfg.Composer != "" {
+		t.Errorf("LoadConfig = %+v, %v, want no composer from git", cfg, err)
+	}
+
+	fakeGitConfig(t, map[string]string{"user.email": "ada@example.com"})
+	if got := GitComposer(root); got != "ada@example.com" {
+		t.Errorf("GitComposer = %q, want git user.email without user.name", got)
+	}
+}
+
+func TestLoadConfigRejectsUnknownClade(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	root := t.TempDir()
+	writeConfig(t, root, "clade: quantum/entangled\n")
+
+	if _, err := LoadConfig(root); err == nil {
+		t.Fatal("expected error for unknown clade")
+	}
+}
+
+func TestConfigCustomStatusValidatesAndRoundTrips(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	root := t.TempDir()
+	writeConfig(t, root, "statuses: [experimental, frozen]\n")
+
+	cfg, err := LoadConfig(root)
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	id := validIdentity()
+	id.Status = "experimental"
+	if err := id.Validate(); err == nil {
+		t.Fatal("custom status must be rejected without the config")
+	}
+	if err := id.ValidateWithOptions(cfg.ValidateOptions()); err != nil {
+		t.Fatalf("ValidateWithOptions failed: %v", err)
+	}
+	if !slices.Equal(Statuses, []string{"draft", "stable", "deprecated", "dead"}) {
+		t.Errorf("Statuses = %v, want the built-in stages only", Statuses)
+	}
+
+	path := filepath.Join(root, "HOLON.md")
+	if err := WriteHolonMD(id, path); err != nil {
+		t.Fatalf("WriteHolonMD failed: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed, _, err := ParseFrontmatter(data)
+	if err != nil {
+		t.Fatalf("ParseFrontmatter failed: %v", err)
+	}
+	if parsed.Status != "experimental" {
+		t.Errorf("Status = %q, want %q", parsed.Status, "experimental")
+	}
+}
@@ -0,0 +1,100 @@
+package identity
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// UpdateFrontmatter rewrites top-level frontmatter keys of a HOLON.md file,
+// leaving every other line — comments, unknown keys, and the markdown body —
+// untouched. Values must be a string (written quoted) or a []string (written
+// as a flow sequence). Keys missing from the frontmatter are appended.
+// The uuid key is immutable and cannot be updated.
+func UpdateFrontmatter(data []byte, set map[string]any) ([]byte, error) {
+	if _, ok := set["uuid"]; ok {
+		return nil, fmt.Errorf("uuid is immutable")
+	}
+
+	yamlBlock, body, err := splitFrontmatter(data)
+	if err != nil {
+		return nil, err
+	}
+
+	rendered := make(map[string]string, len(set))
+	for key, value := range set {
+		v, err := renderValue(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", key, err)
+		}
+		rendered[key] = v
+	}
+
+	lines := strings.Split(yamlBlock, "\n")
+	done := map[string]bool{}
+	for i := 0; i < len(lines); i++ {
+		key, ok := topLevelKey(lines[i])
+		if !ok {
+			continue
+		}
+		v, ok := rendered[key]
+		if !ok {
+			continue
+		}
+		lines[i] = key + ": " + v
+		done[key] = true
+
+		// Drop continuation lines of a block value being replaced.
+		j := i + 1
+		for j < len(lines) && isContinuation(lines[j]) {
+			j++
+		}
+		lines = append(lines[:i+1], lines[j:]...)
+	}
+
+	var missing []string
+	for key := range rendered {
+		if !done[key] {
+			missing = append(missing, key)
+		}
+	}
+	sort.Strings(missing)
+	for _, key := range missing {
+		lines = append(lines, key+": "+rendered[key])
+	}
+
+	return []byte("---\n" + strings.Join(lines, "\n") + "\n---" + body), nil
+}
+
+func renderValue(value any) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return fmt.Sprintf("%q", v), nil
+	case []string:
+		quoted := make([]string, len(v))
+		for i, s := range v {
+			quoted[i] = fmt.Sprintf("%q", s)
+		}
+		return "[" + strings.Join(quoted, ", ") + "]", nil
+	default:
+		return "", fmt.Errorf("unsupported value type %T", value)
+	}
+}
+
+// topLevelKey returns the mapping key declared on an unindented line.
+func topLevelKey(line string) (string, bool) {
+	if line == "" || line[0] == ' ' || line[0] == '\t' || line[0] == '#' || line[0] == '-' {
+		return "", false
+	}
+	key, _, ok := strings.Cut(line, ":")
+	if !ok {
+		return "", false
+	}
+	return strings.TrimSpace(key), true
+}
+
+// isContinuation reports whether line belongs to the value of the
+// preceding top-level key (an indented or list-item line).
+func isContinuation(line string) bool {
+	return strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t") || strings.HasPrefix(line, "- ")
+}
@@ -0,0 +1,68 @@
+package identity
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestUpdateFrontmatterPreservesOtherLines(t *testing.T) {
+	updated, err := UpdateFrontmatter([]byte(validFrontmatter), map[string]any{
+		"given_name": "Renamed",
+		"parents":    []string{"p-1", "p-2"},
+	})
+	if err != nil {
+		t.Fatalf("UpdateFrontmatter failed: %v", err)
+	}
+
+	out := string(updated)
+	if !strings.Contains(out, "given_name: \"Renamed\"\n") {
+		t.Errorf("given_name not updated:\n%s", out)
+	}
+	if !strings.Contains(out, "parents: [\"p-1\", \"p-2\"]\n") {
+		t.Errorf("parents not updated:\n%s", out)
+	}
+	if !strings.Contains(out, "motto: \"Test all the things.\"\n") {
+		t.Error("unrelated keys must be preserved")
+	}
+
+	id, body, err := ParseFrontmatter(updated)
+	if err != nil {
+		t.Fatalf("ParseFrontmatter failed on updated content: %v", err)
+	}
+	if id.GivenName != "Renamed" || id.UUID != "test-uuid-1234" {
+		t.Errorf("parsed identity = %+v", id)
+	}
+	_, originalBody, _ := ParseFrontmatter([]byte(validFrontmatter))
+	if body != originalBody {
+		t.Errorf("body changed:\n%q\nwant\n%q", body, originalBody)
+	}
+}
+
+func TestUpdateFrontmatterReplacesBlockList(t *testing.T) {
+	data := "---\nuuid: \"x\"\naliases:\n  - one\n  - two\nlang: go\n---\n"
+
+	updated, err := UpdateFrontmatter([]byte(data), map[string]any{"aliases": []string{"three"}})
+	if err != nil {
+		t.Fatalf("UpdateFrontmatter failed: %v", err)
+	}
+	want := "---\nuuid: \"x\"\naliases: [\"three\"]\nlang: go\n---\n"
+	if string(updated) != want {
+		t.Errorf("updated = %q, want %q", updated, want)
+	}
+}
+
+func TestUpdateFrontmatterAppendsMissingKey(t *testing.T) {
+	updated, err := UpdateFrontmatter([]byte("---\nuuid: \"x\"\n---\n"), map[string]any{"lang": "go"})
+	if err != nil {
+		t.Fatalf("UpdateFrontmatter failed: %v", err)
+	}
+	if string(updated) != "---\nuuid: \"x\"\nlang: \"go\"\n---\n" {
+		t.Errorf("updated = %q", updated)
+	}
+}
+
+func TestUpdateFrontmatterRejectsUUID(t *testing.T) {
+	if _, err := UpdateFrontmatter([]byte(validFrontmatter), map[string]any{"uuid": "other"}); err == nil {
+		t.Fatal("expected error when changing the uuid")
+	}
+}
@@ -0,0 +1,160 @@
+package identity
+
+import "fmt"
+
+// Clade is the typed form of an identity's clade. The numeric values
+// are stable and match the sophia_who.v1 Clade enum.
+type Clade int
+
+const (
+	CladeUnspecified Clade = iota
+	CladeDeterministicPure
+	CladeDeterministicStateful
+	CladeDeterministicIOBound
+	CladeProbabilisticGenerative
+	CladeProbabilisticPerceptual
+	CladeProbabilisticAdaptive
+)
+
+var cladeNames = [...]string{
+	CladeDeterministicPure:       "deterministic/pure",
+	CladeDeterministicStateful:   "deterministic/stateful",
+	CladeDeterministicIOBound:    "deterministic/io_bound",
+	CladeProbabilisticGenerative: "probabilistic/generative",
+	CladeProbabilisticPerceptual: "probabilistic/perceptual",
+	CladeProbabilisticAdaptive:   "probabilistic/adaptive",
+}
+
+// ParseClade returns the Clade for a HOLON.md clade value such as
+// "deterministic/pure".
+func ParseClade(s string) (Clade, error) {
+	for c, name := range cladeNames {
+		if name != "" && name == s {
+			return Clade(c), nil
+		}
+	}
+	return CladeUnspecified, fmt.Errorf("unknown clade %q", s)
+}
+
+// String returns the HOLON.md value of c, or "" for CladeUnspecified.
+func (c Clade) String() string {
+	if c == CladeUnspecified {
+		return ""
+	}
+	if !c.Valid() {
+		return fmt.Sprintf("Clade(%d)", int(c))
+	}
+	return cladeNames[c]
+}
+
+// Valid reports whether c is a known clade other than CladeUnspecified.
+func (c Clade) Valid() bool {
+	return c > CladeUnspecified && int(c) < len(cladeNames)
+}
+
+// Status is the typed form of a lifecycle stage. The numeric values are
+// stable and match the sophia_who.v1 Status enum. Custom stages, such
+// as those of Config.Statuses, all map to StatusCustom, so their names
+// do not survive the conversion and must be carried separately.
+type Status int
+
+const (
+	StatusUnspecified Status = iota
+	StatusDraft
+	StatusStable
+	StatusDeprecated
+	StatusDead
+	StatusCustom
+)
+
+var statusNames = [...]string{
+	StatusDraft:      "draft",
+	StatusStable:     "stable",
+	StatusDeprecated: "deprecated",
+	StatusDead:       "dead",
+}
+
+// ParseStatus returns the Status for a HOLON.md status value. Any other
+// non-empty value is a custom stage and parses as StatusCustom; whether
+// the configuration allows it is for ValidateWithOptions to decide.
+func ParseStatus(s string) (Status, error) {
+	for st, name := range statusNames {
+		if name != "" && name == s {
+			return Status(st), nil
+		}
+	}
+	if s != "" {
+		return StatusCustom, nil
+	}
+	return StatusUnspecified, fmt.Errorf("unknown status %q", s)
+}
+
+// String returns the HOLON.md value of st, "custom" for StatusCustom,
+// or "" for StatusUnspecified.
+func (st Status) String() string {
+	switch {
+	case st == StatusUnspecified:
+		return ""
+	case st == StatusCustom:
+		return "custom"
+	case !st.Valid():
+		return fmt.Sprintf("Status(%d)", int(st))
+	}
+	return statusNames[st]
+}
+
+// Valid reports whether st is a known status other than
+// StatusUnspecified.
+func (st Status) Valid() bool {
+	return st > StatusUnspecified && st <= StatusCustom
+}
+
+// Reproduction is the typed form of a reproduction mode. The numeric
+// values are stable and match the sophia_who.v1 ReproductionMode enum.
+type Reproduction int
+
+const (
+	ReproductionUnspecified Reproduction = iota
+	ReproductionManual
+	ReproductionAssisted
+	ReproductionAutomatic
+	ReproductionAutopoietic
+	ReproductionBred
+)
+
+var reproductionNames = [...]string{
+	ReproductionManual:      "manual",
+	ReproductionAssisted:    "assisted",
+	ReproductionAutomatic:   "automatic",
+	ReproductionAutopoietic: "autopoietic",
+	ReproductionBred:        "bred",
+}
+
+// ParseReproduction returns the Reproduction for a HOLON.md
+// reproduction value such as "manual".
+func ParseReproduction(s string) (Reproduction, error) {
+	for r, name := range reproductionNames {
+		if name != "" && name == s {
+			return Reproduction(r), nil
+		}
+	}
+	return ReproductionUnspecified, fmt.Errorf("unknown reproduction mode %q", s)
+}
+
+// String returns the HOLON.md value of r, or "" for
+// ReproductionUnspecified.
+func (r Reproduction) String() string {
+	if r == ReproductionUnspecified {
+		return ""
+	}
+	if !r.Valid() {
+		return fmt.Sprintf("Reproduction(%d)", int(r))
+	}
+	return reproductionNames[r]
+}
+
+// Valid reports whether r is a known reproduction mode other than
+// ReproductionUnspecified.
+func (r Reproduction) Valid() bool {
+	return r > ReproductionUnspecified && int(r) < len(reproductionNames)
+}
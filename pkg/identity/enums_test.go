@@ -0,0 +1,60 @@
+package identity
+
+import "testing"
+
+func TestCladeRoundTrip(t *testing.T) {
+	for _, name := range Clades {
+		c, err := ParseClade(name)
+		if err != nil || !c.Valid() {
+			t.Fatalf("ParseClade(%q) = %v, %v", name, c, err)
+		}
+		if c.String() != name {
+			t.Errorf("ParseClade(%q).String() = %q", name, c.String())
+		}
+	}
+	for _, name := range []string{"", "quantum", "Deterministic/Pure"} {
+		if c, err := ParseClade(name); err == nil || c != CladeUnspecified {
+			t.Errorf("ParseClade(%q) = %v, %v, want an error", name, c, err)
+		}
+	}
+	if Clade(99).Valid() || Clade(99).String() != "Clade(99)" {
+		t.Errorf("Clade(99) = %q, valid %v", Clade(99).String(), Clade(99).Valid())
+	}
+}
+
+func TestStatusRoundTrip(t *testing.T) {
+	for _, name := range []string{"draft", "stable", "deprecated", "dead"} {
+		st, err := ParseStatus(name)
+		if err != nil || !st.Valid() || st == StatusCustom {
+			t.Fatalf("ParseStatus(%q) = %v, %v", name, st, err)
+		}
+		if st.String() != name {
+			t.Errorf("ParseStatus(%q).String() = %q", name, st.String())
+		}
+	}
+
+	if st, err := ParseStatus("frozen"); err != nil || st != StatusCustom {
+		t.Errorf("ParseStatus(frozen) = %v, %v, want StatusCustom", st, err)
+	}
+	if st, err := ParseStatus(""); err == nil || st != StatusUnspecified {
+		t.Errorf("ParseStatus(\"\") = %v, %v, want an error", st, err)
+	}
+}
+
+func TestReproductionRoundTrip(t *testing.T) {
+	for _, name := range ReproductionModes {
+		r, err := ParseReproduction(name)
+		if err != nil || !r.Valid() {
+			t.Fatalf("ParseReproduction(%q) = %v, %v", name, r, err)
+		}
+		if r.String() != name {
+			t.Errorf("ParseReproduction(%q).String() = %q", name, r.String())
+		}
+	}
+	if r, err := ParseReproduction("cloned"); err == nil || r != ReproductionUnspecified {
+		t.Errorf("ParseReproduction(cloned) = %v, %v, want an error", r, err)
+	}
+	if ReproductionUnspecified.Valid() || ReproductionUnspecified.String() != "" {
+		t.Error("ReproductionUnspecified should be invalid and print empty")
+	}
+}
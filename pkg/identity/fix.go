@@ -0,0 +1,46 @@
+package identity
+
+import (
+	"bytes"
+	"slices"
+)
+
+// utf8BOM is the byte order mark some Windows editors prepend to files.
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+// Fix repairs mechanical problems in HOLON.md content that would make
+// it unreadable or noisy: a leading byte order mark, CRLF line endings,
+// missing or repeated trailing newlines, and duplicate aliases. It
+// returns the repaired content and a short description of each change;
+// no changes means data was already clean. Fix is idempotent: fixing
+// its own output changes nothing. It backs who fmt and who doctor --fix.
+func Fix(data []byte) ([]byte, []string) {
+	var changes []string
+
+	if bytes.HasPrefix(data, utf8BOM) {
+		data = data[len(utf8BOM):]
+		changes = append(changes, "removed byte order mark")
+	}
+	if bytes.Contains(data, []byte("\r\n")) {
+		data = bytes.ReplaceAll(data, []byte("\r\n"), []byte("\n"))
+		changes = append(changes, "normalized line endings")
+	}
+	if len(data) > 0 && !bytes.HasSuffix(data, []byte("\n")) || bytes.HasSuffix(data, []byte("\n\n")) {
+		data = withTrailingNewline(data)
+		changes = append(changes, "normalized trailing newline")
+	}
+
+	id, _, err := ParseFrontmatter(data)
+	if err != nil {
+		return data, changes
+	}
+	if aliases := NormalizeAliases(id.Aliases); !slices.Equal(aliases, id.Aliases) {
+		updated, err := UpdateFrontmatter(data, map[string]any{"aliases": aliases})
+		if err == nil {
+			data = updated
+			changes = append(changes, "removed duplicate aliases")
+		}
+	}
+
+	return data, changes
+}
@@ -0,0 +1,57 @@
+package identity
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFixRepairsBOMAndDuplicateAliases(t *testing.T) {
+	content := strings.Replace(validFrontmatter, "reproduction:", "aliases: [swift, swift, quick]\nreproduction:", 1)
+	data := append([]byte("\xEF\xBB\xBF"), []byte(strings.ReplaceAll(content, "\n", "\r\n"))...)
+
+	fixed, changes := Fix(data)
+	want := []string{"removed byte order mark", "normalized line endings", "removed duplicate aliases"}
+	if !reflect.DeepEqual(changes, want) {
+		t.Fatalf("changes = %q, want %q", changes, want)
+	}
+	if bytes.HasPrefix(fixed, []byte("\xEF\xBB\xBF")) || bytes.Contains(fixed, []byte("\r")) {
+		t.Errorf("fixed content still has a BOM or CR:\n%q", fixed)
+	}
+
+	id, _, err := ParseFrontmatter(fixed)
+	if err != nil {
+		t.Fatalf("fixed content does not parse: %v", err)
+	}
+	if !reflect.DeepEqual([]string(id.Aliases), []string{"swift", "quick"}) {
+		t.Errorf("aliases = %q, want [swift quick]", id.Aliases)
+	}
+
+	again, changes := Fix(fixed)
+	if len(changes) != 0 || !bytes.Equal(again, fixed) {
+		t.Errorf("second Fix changed content: %q", changes)
+	}
+}
+
+func TestFixLeavesCleanContentAlone(t *testing.T) {
+	fixed, changes := Fix([]byte(validFrontmatter))
+	if len(changes) != 0 || string(fixed) != validFrontmatter {
+		t.Errorf("Fix changed clean content: %q", changes)
+	}
+}
+
+func TestFixTrailingNewline(t *testing.T) {
+	for name, data := range map[string]string{
+		"missing":  strings.TrimSuffix(validFrontmatter, "\n"),
+		"repeated": validFrontmatter + "\n\n",
+	} {
+		fixed, changes := Fix([]byte(data))
+		if !reflect.DeepEqual(changes, []string{"normalized trailing newline"}) {
+			t.Errorf("%s: changes = %q", name, changes)
+		}
+		if string(fixed) != validFrontmatter {
+			t.Errorf("%s: fixed = %q, want a single trailing newline", name, fixed)
+		}
+	}
+}
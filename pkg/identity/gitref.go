@@ -0,0 +1,251 @@
+package identity
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// gitCommand returns a git invocation run from dir.
+func gitCommand(dir string, args ...string) *exec.Cmd {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	return cmd
+}
+
+// gitBlob is a HOLON.md entry listed from a git tree.
+type gitBlob struct {
+	object string
+	size   int64
+	path   string // slash-separated, relative to the scanned root
+}
+
+// ScanGitRef reads the HOLON.md files under root as they are at the git
+// ref (a branch, tag, or commit), without checking it out. root must be
+// inside a git work tree; only the part of the tree below root is
+// scanned. Reported paths are where each file would be in the work
+// tree, under root.
+//
+// Hidden directories, IgnoreMarker, MaxFileSize, MaxResults, Filter,
+// and OnError behave as in ScanWithOptions.
+func ScanGitRef(root, ref string, opts ScanOptions, onFound func(LocatedIdentity)) error {
+	if strings.TrimSpace(ref) == "" {
+		return errors.New("git ref is required")
+	}
+	if strings.HasPrefix(ref, "-") {
+		return fmt.Errorf("invalid git ref %q", ref)
+	}
+	if _, err := runGit(root, "rev-parse", "--verify", "--quiet", ref+"^{tree}"); err != nil {
+		return fmt.Errorf("unknown git ref %q in %s: %w", ref, root, err)
+	}
+
+	out, err := runGit(root, "ls-tree", "-r", "-l", "-z", ref, "--", ".")
+	if err != nil {
+		return err
+	}
+	blobs, err := parseLsTree(out, opts)
+	if err != nil {
+		return err
+	}
+
+	maxSize := opts.MaxFileSize
+	if maxSize == 0 {
+		maxSize = DefaultMaxFileSize
+	}
+	var wanted []gitBlob
+	for _, b := range blobs {
+		filePath := filepath.Join(root, filepath.FromSlash(b.path))
+		if maxSize > 0 && b.size > maxSize {
+			if opts.OnError != nil {
+				opts.OnError(filePath, fmt.Errorf("%s:%s is %d bytes: %w (limit %d)", ref, b.path, b.size, ErrFileTooLarge, maxSize))
+			}
+			continue
+		}
+		wanted = append(wanted, b)
+	}
+	if len(wanted) == 0 {
+		return nil
+	}
+
+	found := 0
+	return catBlobs(root, wanted, func(b gitBlob, data []byte) bool {
+		id, _, err := ParseFrontmatter(data)
+		if err != nil {
+			return true
+		}
+		located := LocatedIdentity{Identity: id, Path: filepath.Join(root, filepath.FromSlash(b.path))}
+		if opts.Filter != nil && !opts.Filter(located) {
+			return true
+		}
+		found++
+		if onFound != nil {
+			onFound(located)
+		}
+		return opts.MaxResults <= 0 || found < opts.MaxResults
+	})
+}
+
+// ChangedHolonFiles returns the HOLON.md files under root that differ
+// between the git ref and the work tree, as git diff --name-only
+// reports them: modified, added to the index, or deleted since ref.
+// Untracked files are not included. Paths are under root, as
+// ScanWithOptions and ScanGitRef report them, and cleaned.
+func ChangedHolonFiles(root, ref string) (map[string]bool, error) {
+	if strings.TrimSpace(ref) == "" {
+		return nil, errors.New("git ref is required")
+	}
+	if strings.HasPrefix(ref, "-") {
+		return nil, fmt.Errorf("invalid git ref %q", ref)
+	}
+	if _, err := runGit(root, "rev-parse", "--verify", "--quiet", ref+"^{commit}"); err != nil {
+		return nil, fmt.Errorf("unknown git ref %q in %s: %w", ref, root, err)
+	}
+
+	out, err := runGit(root, "diff", "--name-only", "-z", "--relative", ref, "--", ".")
+	if err != nil {
+		return nil, err
+	}
+	changed := map[string]bool{}
+	for _, name := range strings.Split(string(out), "\x00") {
+		if path.Base(name) == "HOLON.md" {
+			changed[filepath.Join(root, filepath.FromSlash(name))] = true
+		}
+	}
+	return changed, nil
+}
+
+// parseLsTree returns the HOLON.md blobs in the NUL-separated output of
+// git ls-tree -r -l -z, applying the same exclusions as walkHolonFiles.
+func parseLsTree(out []byte, opts ScanOptions) ([]gitBlob, error) {
+	var blobs []gitBlob
+	ignored := map[string]bool{}
+
+	for _, record := range bytes.Split(out, []byte{0}) {
+		if len(record) == 0 {
+			continue
+		}
+		meta, name, ok := strings.Cut(string(record), "\t")
+		if !ok {
+			return nil, fmt.Errorf("unexpected git ls-tree output %q", record)
+		}
+		fields := strings.Fields(meta)
+		if len(fields) != 4 || fields[1] != "blob" {
+			continue
+		}
+		if hiddenDir(name) {
+			continue
+		}
+
+		dir, base := path.Split(name)
+		switch base {
+		case IgnoreMarker:
+			ignored[dir] = true
+		case "HOLON.md":
+			size, err := strconv.ParseInt(fields[3], 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("unexpected git ls-tree size %q for %s", fields[3], name)
+			}
+			blobs = append(blobs, gitBlob{object: fields[2], size: size, path: name})
+		}
+	}
+
+	if opts.IncludeIgnored {
+		return blobs, nil
+	}
+	kept := blobs[:0]
+	for _, b := range blobs {
+		if dir, _ := path.Split(b.path); !ignored[dir] {
+			kept = append(kept, b)
+		}
+	}
+	return kept, nil
+}
+
+// hiddenDir reports whether any directory in the slash-separated path
+// name starts with a dot.
+func hiddenDir(name string) bool {
+	dirs := strings.Split(name, "/")
+	for _, dir := range dirs[:len(dirs)-1] {
+		if strings.HasPrefix(dir, ".") {
+			return true
+		}
+	}
+	return false
+}
+
+// catBlobs streams the contents of blobs through a single
+// git cat-file --batch process, calling onBlob for each one until it
+// returns false.
+func catBlobs(dir string, blobs []gitBlob, onBlob func(gitBlob, []byte) bool) error {
+	var input bytes.Buffer
+	for _, b := range blobs {
+		input.WriteString(b.object + "\n")
+	}
+
+	cmd := gitCommand(dir, "cat-file", "--batch")
+	cmd.Stdin = &input
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("git cat-file: %w", err)
+	}
+
+	r := bufio.NewReader(stdout)
+	readErr := func() error {
+		for _, b := range blobs {
+			header, err := r.ReadString('\n')
+			if err != nil {
+				return fmt.Errorf("git cat-file: reading %s: %w", b.path, err)
+			}
+			fields := strings.Fields(header)
+			if len(fields) != 3 || fields[1] != "blob" {
+				return fmt.Errorf("git cat-file: unexpected header %q for %s", strings.TrimSpace(header), b.path)
+			}
+			size, err := strconv.ParseInt(fields[2], 10, 64)
+			if err != nil {
+				return fmt.Errorf("git cat-file: unexpected size %q for %s", fields[2], b.path)
+			}
+			data := make([]byte, size+1) // contents and the trailing newline
+			if _, err := io.ReadFull(r, data); err != nil {
+				return fmt.Errorf("git cat-file: reading %s: %w", b.path, err)
+			}
+			if !onBlob(b, data[:size]) {
+				return nil
+			}
+		}
+		return nil
+	}()
+
+	// Drain what is left so git can exit if onBlob ended the scan early.
+	_, _ = io.Copy(io.Discard, r)
+	if err := cmd.Wait(); err != nil && readErr == nil {
+		return fmt.Errorf("git cat-file: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return readErr
+}
+
+// runGit runs git in dir and returns its standard output.
+func runGit(dir string, args ...string) ([]byte, error) {
+	cmd := gitCommand(dir, args...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("git %s: %s", args[0], msg)
+		}
+		return nil, fmt.Errorf("git %s: %w", args[0], err)
+	}
+	return out, nil
+}
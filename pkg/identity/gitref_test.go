@@ -0,0 +1,91 @@
+package identity
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+// gitRepo initialises a throwaway repository in a temp dir, isolated
+// from the user's git configuration.
+func gitRepo(t *testing.T) (dir string, git func(args ...string)) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	t.Setenv("GIT_CONFIG_GLOBAL", os.DevNull)
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+	t.Setenv("GIT_AUTHOR_NAME", "test")
+	t.Setenv("GIT_AUTHOR_EMAIL", "test@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "test")
+	t.Setenv("GIT_COMMITTER_EMAIL", "test@example.com")
+
+	dir = t.TempDir()
+	git = func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	git("init", "-q", "-b", "main")
+	return dir, git
+}
+
+func writeGitHolon(t *testing.T, dir string, id Identity) {
+	t.Helper()
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteHolonMD(id, filepath.Join(dir, "HOLON.md")); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestScanGitRef(t *testing.T) {
+	repo, git := gitRepo(t)
+	root := filepath.Join(repo, "org")
+
+	committed := New()
+	committed.GivenName = "Committed"
+	outside := New()
+	outside.GivenName = "Outside"
+	ignored := New()
+	ignored.GivenName = "Template"
+	writeGitHolon(t, filepath.Join(root, "holons", "committed"), committed)
+	writeGitHolon(t, filepath.Join(repo, "other", "outside"), outside)
+	writeGitHolon(t, filepath.Join(root, "holons", "template"), ignored)
+	if err := os.WriteFile(filepath.Join(root, "holons", "template", IgnoreMarker), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	git("add", ".")
+	git("commit", "-q", "-m", "holons")
+
+	// Work tree changes after the commit must not show up.
+	committed.GivenName = "Edited"
+	writeGitHolon(t, filepath.Join(root, "holons", "committed"), committed)
+	writeGitHolon(t, filepath.Join(root, "holons", "uncommitted"), New())
+
+	var found []LocatedIdentity
+	if err := ScanGitRef(root, "main", ScanOptions{}, func(h LocatedIdentity) {
+		found = append(found, h)
+	}); err != nil {
+		t.Fatalf("ScanGitRef failed: %v", err)
+	}
+
+	if len(found) != 1 {
+		t.Fatalf("found %d holons, want only the committed one under root: %+v", len(found), found)
+	}
+	if found[0].Identity.GivenName != "Committed" {
+		t.Errorf("given_name = %q, want the committed Committed", found[0].Identity.GivenName)
+	}
+	if want := filepath.Join(root, "holons", "committed", "HOLON.md"); found[0].Path != want {
+		t.Errorf("path = %q, want %q", found[0].Path, want)
+	}
+
+	if err := ScanGitRef(root, "no-such-branch", ScanOptions{}, nil); err == nil {
+		t.Error("ScanGitRef accepted an unknown ref")
+	}
+}
@@ -0,0 +1,58 @@
+package identity
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// HookRunner runs a hook command with stdin as its standard input and
+// returns what it wrote to standard error. A non-nil error means the
+// hook failed or exited non-zero.
+type HookRunner func(command string, stdin []byte) (stderr []byte, err error)
+
+// ShellHookRunner runs command through sh -c (cmd /C on Windows).
+func ShellHookRunner(command string, stdin []byte) ([]byte, error) {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.Command("cmd", "/C", command)
+	} else {
+		cmd = exec.Command("sh", "-c", command)
+	}
+	var stderr bytes.Buffer
+	cmd.Stdin = bytes.NewReader(stdin)
+	cmd.Stderr = &stderr
+	err := cmd.Run()
+	return stderr.Bytes(), err
+}
+
+// HookRejectedError reports a pre-write hook that refused a HOLON.md.
+type HookRejectedError struct {
+	Command string
+	Reason  string // the hook's standard error, or why it could not run
+}
+
+func (e *HookRejectedError) Error() string {
+	return fmt.Sprintf("pre-write hook %q rejected the holon: %s", e.Command, e.Reason)
+}
+
+// RunPreWriteHook passes the rendered HOLON.md content to command on
+// stdin, using run (ShellHookRunner when nil). The write may proceed
+// only if it returns nil; otherwise the error is a *HookRejectedError
+// carrying the hook's stderr as the reason.
+func RunPreWriteHook(run HookRunner, command string, content []byte) error {
+	if run == nil {
+		run = ShellHookRunner
+	}
+	stderr, err := run(command, content)
+	if err == nil {
+		return nil
+	}
+	reason := strings.TrimSpace(string(stderr))
+	if reason == "" {
+		reason = err.Error()
+	}
+	return &HookRejectedError{Command: command, Reason: reason}
+}
@@ -0,0 +1,44 @@
+package identity
+
+import (
+	"errors"
+	"runtime"
+	"testing"
+)
+
+func TestRunPreWriteHook(t *testing.T) {
+	var got []byte
+	allow := func(command string, stdin []byte) ([]byte, error) {
+		got = stdin
+		return nil, nil
+	}
+	if err := RunPreWriteHook(allow, "policy", []byte("content")); err != nil {
+		t.Fatalf("passing hook rejected the write: %v", err)
+	}
+	if string(got) != "content" {
+		t.Errorf("hook stdin = %q, want the rendered content", got)
+	}
+
+	deny := func(string, []byte) ([]byte, error) {
+		return []byte("names must start with a capital\n"), errors.New("exit status 1")
+	}
+	err := RunPreWriteHook(deny, "policy", []byte("content"))
+	var rejected *HookRejectedError
+	if !errors.As(err, &rejected) || rejected.Reason != "names must start with a capital" {
+		t.Fatalf("failing hook: err = %v, want a rejection with the hook's stderr", err)
+	}
+}
+
+func TestShellHookRunner(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh")
+	}
+	if err := RunPreWriteHook(nil, `grep -q "^uuid:"`, []byte("uuid: x\n")); err != nil {
+		t.Errorf("grep hook rejected matching content: %v", err)
+	}
+	err := RunPreWriteHook(nil, `echo "no uuid" >&2; exit 3`, nil)
+	var rejected *HookRejectedError
+	if !errors.As(err, &rejected) || rejected.Reason != "no uuid" {
+		t.Errorf("failing shell hook: err = %v", err)
+	}
+}
@@ -0,0 +1,153 @@
+package identity
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	mathrand "math/rand/v2"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ID schemes for the uuid field. The field keeps its name whichever
+// scheme generated it.
+const (
+	IDSchemeUUID = "uuid" // random UUID (version 4), the default
+	IDSchemeULID = "ulid" // lexicographically sortable ULID
+)
+
+// IDGenerator produces the identifiers Config.New assigns.
+type IDGenerator interface {
+	NewID() string
+}
+
+// IDGeneratorFunc adapts an ordinary function to IDGenerator.
+type IDGeneratorFunc func() string
+
+// NewID calls f.
+func (f IDGeneratorFunc) NewID() string { return f() }
+
+// NewID returns a fresh random UUID.
+func NewID() string {
+	return Config{}.NewID()
+}
+
+// NewID returns a fresh identifier from c.Generator, or in c.IDScheme
+// when Generator is nil: a random UUID unless the scheme is ulid.
+func (c Config) NewID() string {
+	if c.Generator != nil {
+		return c.Generator.NewID()
+	}
+	if c.IDScheme == IDSchemeULID {
+		return NewULID(time.Now())
+	}
+	return uuid.New().String()
+}
+
+// seededGenerator derives version 4 UUIDs from a seeded PRNG.
+type seededGenerator struct {
+	mu  sync.Mutex
+	rng *mathrand.Rand
+}
+
+// NewSeededGenerator returns an IDGenerator producing the same sequence
+// of version 4 UUIDs for the same seed. It ignores the ID scheme: a ULID
+// embeds the current time and cannot be reproduced. The IDs are not
+// suitable for anything but tests and fixtures.
+func NewSeededGenerator(seed uint64) IDGenerator {
+	return &seededGenerator{rng: mathrand.New(mathrand.NewPCG(seed, seed))}
+}
+
+func (g *seededGenerator) NewID() string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	var b [16]byte
+	binary.BigEndian.PutUint64(b[:8], g.rng.Uint64())
+	binary.BigEndian.PutUint64(b[8:], g.rng.Uint64())
+	id, err := uuid.NewRandomFromReader(bytes.NewReader(b[:]))
+	if err != nil {
+		panic(fmt.Sprintf("identity: cannot generate seeded UUID: %v", err))
+	}
+	return id.String()
+}
+
+// crockford is the Crockford base32 alphabet used by ULIDs.
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// NewULID returns a ULID for t: a 48-bit millisecond timestamp followed
+// by 80 random bits, as 26 Crockford base32 characters.
+func NewULID(t time.Time) string {
+	var b [16]byte
+	ms := uint64(t.UnixMilli())
+	for i := range 6 {
+		b[i] = byte(ms >> (40 - 8*i))
+	}
+	if _, err := rand.Read(b[6:]); err != nil {
+		panic(fmt.Sprintf("identity: cannot generate ULID: %v", err))
+	}
+
+	// 128 bits in 26 five-bit groups, the first group holding 3 bits.
+	var out [26]byte
+	hi := uint64(b[0])<<56 | uint64(b[1])<<48 | uint64(b[2])<<40 | uint64(b[3])<<32 |
+		uint64(b[4])<<24 | uint64(b[5])<<16 | uint64(b[6])<<8 | uint64(b[7])
+	lo := uint64(b[8])<<56 | uint64(b[9])<<48 | uint64(b[10])<<40 | uint64(b[11])<<32 |
+		uint64(b[12])<<24 | uint64(b[13])<<16 | uint64(b[14])<<8 | uint64(b[15])
+	for i := 25; i >= 0; i-- {
+		out[i] = crockford[lo&31]
+		lo = lo>>5 | hi<<59
+		hi >>= 5
+	}
+	return string(out[:])
+}
+
+// IsULID reports whether s is a canonical ULID: 26 upper-case Crockford
+// base32 characters whose value fits in 128 bits.
+func IsULID(s string) bool {
+	if len(s) != 26 || s[0] > '7' {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if strings.IndexByte(crockford, s[i]) < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// IsUUID reports whether s is a UUID in the canonical 36-character
+// hyphenated form.
+func IsUUID(s string) bool {
+	if len(s) != 36 {
+		return false
+	}
+	_, err := uuid.Parse(s)
+	return err == nil
+}
+
+// ShortIDLength is the number of leading characters ShortUUID keeps.
+const ShortIDLength = 8
+
+// ShortUUID returns the first ShortIDLength characters of id, for
+// display where a full UUID would waste space. The result is a prefix,
+// so ResolveTarget still finds the holon unless another shares it.
+func ShortUUID(id string) string {
+	if len(id) <= ShortIDLength {
+		return id
+	}
+	return id[:ShortIDLength]
+}
+
+// CheckID reports an error unless s is a UUID or a ULID. Validate does
+// not call it, since older holons have free-form ids; Lint warns about
+// them instead.
+func CheckID(s string) error {
+	if IsUUID(s) || IsULID(s) {
+		return nil
+	}
+	return fmt.Errorf("%q is not a UUID or ULID", s)
+}
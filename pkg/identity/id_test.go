@@ -0,0 +1,113 @@
+package identity
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewULID(t *testing.T) {
+	earlier := NewULID(time.UnixMilli(1_700_000_000_000))
+	later := NewULID(time.UnixMilli(1_700_000_000_001))
+	for _, id := range []string{earlier, later} {
+		if !IsULID(id) || CheckID(id) != nil {
+			t.Fatalf("NewULID produced %q, which is not a valid ULID", id)
+		}
+	}
+	if earlier >= later {
+		t.Errorf("ULIDs do not sort by time: %s >= %s", earlier, later)
+	}
+	// The timestamp occupies the first 10 characters.
+	if got := NewULID(time.UnixMilli(0)); !strings.HasPrefix(got, "0000000000") {
+		t.Errorf("NewULID(epoch) = %s, want a zero timestamp prefix", got)
+	}
+}
+
+func TestULIDHolon(t *testing.T) {
+	id := validIdentity()
+	id.UUID = Config{IDScheme: IDSchemeULID}.New().UUID
+	if !IsULID(id.UUID) {
+		t.Fatalf("New() with the ulid scheme gave %q", id.UUID)
+	}
+	if err := id.Validate(); err != nil {
+		t.Fatalf("Validate rejected a ULID: %v", err)
+	}
+
+	root := t.TempDir()
+	path := filepath.Join(root, "holons", "ulid", "HOLON.md")
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteHolonMD(id, path); err != nil {
+		t.Fatal(err)
+	}
+	for _, target := range []string{id.UUID, id.UUID[:12]} {
+		found, err := FindByUUID(root, target)
+		if err != nil || found != path {
+			t.Errorf("FindByUUID(%q) = %q, %v, want %s", target, found, err, path)
+		}
+	}
+}
+
+func TestMalformedIDIsALintWarning(t *testing.T) {
+	for _, bad := range []string{
+		"not-an-id",
+		"01J9ZQ3V5X8K2M4N6P7R9S0T1",              // ULID one character short
+		"01J9ZQ3V5X8K2M4N6P7R9S0TIU",             // I and U are not Crockford
+		"81J9ZQ3V5X8K2M4N6P7R9S0T1V",             // overflows 128 bits
+		"{c9f1e2d3-0000-4000-8000-000000000001}", // non-canonical UUID
+		"c9f1e2d3-0000-4000-8000-00000000000z",   // not hex
+	} {
+		id := validIdentity()
+		id.UUID = bad
+		if err := id.Validate(); err != nil {
+			t.Errorf("Validate(uuid %q) = %v, want legacy ids accepted", bad, err)
+		}
+		findings := Lint(LocatedIdentity{Identity: id}, DefaultLintOptions())
+		if len(findings) != 1 || findings[0].Rule != "id-format" || findings[0].Severity != SeverityWarning ||
+			!strings.Contains(findings[0].Message, "not a UUID or ULID") {
+			t.Errorf("Lint(uuid %q) = %+v, want one id-format warning", bad, findings)
+		}
+	}
+}
+
+func TestGeneratorOverridesNewID(t *testing.T) {
+	const fixed = "0b5e1f9c-0000-4000-8000-000000000042"
+	cfg := Config{IDScheme: IDSchemeULID, Generator: IDGeneratorFunc(func() string { return fixed })}
+	if got := cfg.New().UUID; got != fixed {
+		t.Errorf("New().UUID = %q, want the injected %q", got, fixed)
+	}
+	if got := New().UUID; got == fixed || !IsUUID(got) {
+		t.Errorf("New().UUID = %q, want a random UUID without the config", got)
+	}
+
+	first := NewSeededGenerator(42)
+	second := NewSeededGenerator(42)
+	for range 3 {
+		a, b := first.NewID(), second.NewID()
+		if a != b {
+			t.Fatalf("same seed gave %q and %q", a, b)
+		}
+		if !IsUUID(a) || a[14] != '4' {
+			t.Errorf("seeded ID %q is not a version 4 UUID", a)
+		}
+	}
+	if NewSeededGenerator(43).NewID() == NewSeededGenerator(42).NewID() {
+		t.Error("different seeds gave the same first ID")
+	}
+}
+
+func TestShortUUID(t *testing.T) {
+	for in, want := range map[string]string{
+		"c9f1e2d3-0000-4000-8000-000000000001": "c9f1e2d3",
+		"01ARZ3NDEKTSV4RRFFQ69G5FAV":           "01ARZ3ND",
+		"c9f1":                                 "c9f1",
+		"":                                     "",
+	} {
+		if got := ShortUUID(in); got != want {
+			t.Errorf("ShortUUID(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
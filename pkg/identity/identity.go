@@ -4,35 +4,74 @@
 package identity
 
 import (
+	"fmt"
+	"slices"
+	"strings"
 	"time"
 
-	"github.com/google/uuid"
+	"gopkg.in/yaml.v3"
 )
 
 // Identity holds all fields of a holon's civil status.
 // This struct mirrors the HOLON.md YAML frontmatter defined in IDENTITY.md.
 type Identity struct {
 	// Required
-	UUID       string `yaml:"uuid"`
-	GivenName  string `yaml:"given_name"`
-	FamilyName string `yaml:"family_name"`
-	Motto      string `yaml:"motto"`
-	Composer   string `yaml:"composer"`
-	Clade      string `yaml:"clade"`
-	Status     string `yaml:"status"`
-	Born       string `yaml:"born"`
+	UUID       string `yaml:"uuid" json:"uuid"`
+	GivenName  string `yaml:"given_name" json:"given_name"`
+	FamilyName string `yaml:"family_name" json:"family_name"`
+	Motto      string `yaml:"motto" json:"motto"`
+	Composer   string `yaml:"composer" json:"composer"`
+	Clade      string `yaml:"clade" json:"clade"`
+	Status     string `yaml:"status" json:"status"`
+	Born       string `yaml:"born" json:"born"`
+	Died       string `yaml:"died,omitempty" json:"died,omitempty"` // set when status becomes dead
 
 	// Lineage
-	Parents      []string `yaml:"parents"`
-	Reproduction string   `yaml:"reproduction"`
+	Parents      StringList `yaml:"parents" json:"parents"`
+	Reproduction string     `yaml:"reproduction" json:"reproduction"`
+	Children     StringList `yaml:"children,omitempty" json:"children,omitempty"` // kept only when tracked, see AppendChild
 
 	// Optional
-	Aliases []string `yaml:"aliases,omitempty"`
+	Aliases StringList `yaml:"aliases,omitempty" json:"aliases,omitempty"`
+	Tags    StringList `yaml:"tags,omitempty" json:"tags,omitempty"` // lowercase, see NormalizeTags
 
 	// Metadata
-	GeneratedBy string `yaml:"generated_by"`
-	Lang        string `yaml:"lang"`
-	ProtoStatus string `yaml:"proto_status"`
+	GeneratedBy string `yaml:"generated_by" json:"generated_by"`
+	Lang        string `yaml:"lang" json:"lang"`
+	ProtoStatus string `yaml:"proto_status" json:"proto_status"`
+}
+
+// StringList is a list of strings that also accepts, when decoded from
+// YAML, a single comma-separated string: `parents: "a, b"` is read as
+// `parents: [a, b]` instead of silently losing the values.
+type StringList []string
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (l *StringList) UnmarshalYAML(value *yaml.Node) error {
+	switch value.Kind {
+	case yaml.SequenceNode:
+		var items []string
+		if err := value.Decode(&items); err != nil {
+			return err
+		}
+		*l = items
+		return nil
+	case yaml.ScalarNode:
+		if value.Tag == "!!null" {
+			*l = nil
+			return nil
+		}
+		items := StringList{}
+		for _, item := range strings.Split(value.Value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		*l = items
+		return nil
+	default:
+		return &yaml.TypeError{Errors: []string{fmt.Sprintf("line %d: cannot unmarshal a mapping into a string list", value.Line)}}
+	}
 }
 
 // Clades enumerates valid computational nature classifications.
@@ -45,20 +84,55 @@ var Clades = []string{
 	"probabilistic/adaptive",
 }
 
-// Statuses enumerates valid lifecycle stages.
+// Statuses enumerates the built-in lifecycle stages. Config.Statuses
+// adds custom ones, accepted through ValidateOptions.
 var Statuses = []string{"draft", "stable", "deprecated", "dead"}
 
 // ReproductionModes enumerates how a holon can be created.
 var ReproductionModes = []string{"manual", "assisted", "automatic", "autopoietic", "bred"}
 
-// New creates a fresh identity with a generated UUID and today's date.
+// Version is the sophia-who release recorded in generated_by.
+const Version = "0.1.0"
+
+// GeneratedBy is the generated_by value written on identities this
+// tool creates.
+const GeneratedBy = "sophia-who/" + Version
+
+// New creates a fresh identity with an ID from NewID and today's date.
 func New() Identity {
+	return Config{}.New()
+}
+
+// New is the package-level New, numbering the identity with c.NewID.
+func (c Config) New() Identity {
 	return Identity{
-		UUID:        uuid.New().String(),
+		UUID:        c.NewID(),
 		Status:      "draft",
-		Born:        time.Now().Format("2006-01-02"),
+		Born:        time.Now().Format(DateLayout),
 		Parents:     []string{},
-		GeneratedBy: "sophia-who",
+		GeneratedBy: GeneratedBy,
 		ProtoStatus: "draft",
 	}
 }
+
+// Slug returns the conventional directory name for a holon:
+// lowercase "<given>-<family>" with spaces replaced by dashes.
+func (id Identity) Slug() string {
+	return slugify(id.GivenName + "-" + strings.TrimSuffix(id.FamilyName, "?"))
+}
+
+// HasTags reports whether id carries every one of tags, compared
+// case-insensitively. It is true when tags is empty.
+func (id Identity) HasTags(tags []string) bool {
+	for _, tag := range tags {
+		if !slices.ContainsFunc(id.Tags, func(t string) bool { return strings.EqualFold(t, strings.TrimSpace(tag)) }) {
+			return false
+		}
+	}
+	return true
+}
+
+// slugify lowercases s and replaces spaces with dashes.
+func slugify(s string) string {
+	return strings.ReplaceAll(strings.ToLower(s), " ", "-")
+}
@@ -1,6 +1,7 @@
 package identity
 
 import (
+	"reflect"
 	"strings"
 	"testing"
 )
@@ -23,8 +24,8 @@ func TestNew(t *testing.T) {
 	if id.Born == "" {
 		t.Fatal("Born must not be empty")
 	}
-	if id.GeneratedBy != "sophia-who" {
-		t.Errorf("GeneratedBy = %q, want %q", id.GeneratedBy, "sophia-who")
+	if id.GeneratedBy != "sophia-who/"+Version {
+		t.Errorf("GeneratedBy = %q, want %q", id.GeneratedBy, "sophia-who/"+Version)
 	}
 	if id.ProtoStatus != "draft" {
 		t.Errorf("ProtoStatus = %q, want %q", id.ProtoStatus, "draft")
@@ -41,3 +42,49 @@ func TestNewGeneratesUniqueUUIDs(t *testing.T) {
 		t.Errorf("two calls to New() produced the same UUID: %s", a.UUID)
 	}
 }
+
+func TestSlug(t *testing.T) {
+	id := Identity{GivenName: "Sophia", FamilyName: "Who?"}
+	if got := id.Slug(); got != "sophia-who" {
+		t.Errorf("Slug() = %q, want %q", got, "sophia-who")
+	}
+
+	id = Identity{GivenName: "Deep Blue", FamilyName: "Prober"}
+	if got := id.Slug(); got != "deep-blue-prober" {
+		t.Errorf("Slug() = %q, want %q", got, "deep-blue-prober")
+	}
+}
+
+func TestStringListAcceptsListOrCommaString(t *testing.T) {
+	list := "---\nuuid: \"l\"\nparents: [\"p-1\", \"p-2\"]\naliases:\n  - a\n  - b\n---\n"
+	comma := "---\nuuid: \"c\"\nparents: \"p-1, p-2\"\naliases: \"a,b\"\n---\n"
+
+	fromList, _, err := ParseFrontmatter([]byte(list))
+	if err != nil {
+		t.Fatalf("ParseFrontmatter(list) failed: %v", err)
+	}
+	fromComma, _, err := ParseFrontmatter([]byte(comma))
+	if err != nil {
+		t.Fatalf("ParseFrontmatter(comma) failed: %v", err)
+	}
+
+	if !reflect.DeepEqual(fromList.Parents, fromComma.Parents) {
+		t.Errorf("Parents: list %q != comma %q", fromList.Parents, fromComma.Parents)
+	}
+	if !reflect.DeepEqual(fromList.Aliases, fromComma.Aliases) {
+		t.Errorf("Aliases: list %q != comma %q", fromList.Aliases, fromComma.Aliases)
+	}
+	if len(fromComma.Parents) != 2 || fromComma.Parents[1] != "p-2" {
+		t.Errorf("Parents = %q, want [p-1 p-2]", fromComma.Parents)
+	}
+}
+
+func TestStringListEmptyString(t *testing.T) {
+	id, _, err := ParseFrontmatter([]byte("---\nuuid: \"e\"\nparents: \"\"\n---\n"))
+	if err != nil {
+		t.Fatalf("ParseFrontmatter failed: %v", err)
+	}
+	if len(id.Parents) != 0 {
+		t.Errorf("Parents = %q, want empty", id.Parents)
+	}
+}
@@ -0,0 +1,283 @@
+package identity
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Errors returned, wrapped, by Reparent and VerifyLineage.
+var (
+	// ErrInvalidParent reports a parent that is unknown, ambiguous, or
+	// listed more than once.
+	ErrInvalidParent = errors.New("invalid parent")
+
+	// ErrLineageCycle reports a parent that is the holon itself or one
+	// of its descendants.
+	ErrLineageCycle = errors.New("lineage cycle")
+
+	// ErrLineageMismatch reports a holon lacking an expected parent.
+	ErrLineageMismatch = errors.New("lineage mismatch")
+)
+
+// Reparent replaces the parents of the holon whose HOLON.md is at path
+// with parents, each the UUID or a unique UUID prefix of a holon under
+// root. Every parent must exist and be listed once, and none may be the
+// holon itself or descend from it, which would make the lineage a
+// cycle. Only the parents line is rewritten; the body and the rest of
+// the frontmatter are preserved. It returns the updated identity and
+// the parents it had before.
+func Reparent(root, path string, parents []string) (Identity, []string, error) {
+	var id Identity
+	var previous []string
+	err := RewriteHolonFile(path, func(data []byte) ([]byte, error) {
+		var err error
+		if id, _, err = ParseFrontmatter(data); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+
+		lineage, err := scanLineage(root)
+		if err != nil {
+			return nil, err
+		}
+
+		resolved := []string{}
+		seen := make(map[string]bool, len(parents))
+		for _, parent := range parents {
+			parent = strings.TrimSpace(parent)
+			if parent == "" {
+				continue
+			}
+			uuid, err := resolveParentUUID(lineage, parent)
+			if err != nil {
+				return nil, err
+			}
+			if seen[uuid] {
+				return nil, fmt.Errorf("%w: %s is listed more than once", ErrInvalidParent, uuid)
+			}
+			seen[uuid] = true
+			if uuid == id.UUID {
+				return nil, fmt.Errorf("%w: a holon cannot be its own parent", ErrLineageCycle)
+			}
+			if descendsFrom(lineage, uuid, id.UUID) {
+				return nil, fmt.Errorf("%w: parent %s descends from %s", ErrLineageCycle, uuid, id.UUID)
+			}
+			resolved = append(resolved, uuid)
+		}
+
+		previous = []string(id.Parents)
+		id.Parents = resolved
+		updated, err := UpdateFrontmatter(data, map[string]any{"parents": resolved})
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		return updated, nil
+	})
+	if err != nil {
+		return Identity{}, nil, err
+	}
+	return id, previous, nil
+}
+
+// VerifyLineage checks that each of expected, the UUID or a unique UUID
+// prefix of a holon under root, is a parent of id. A mismatch is
+// reported with an error wrapping ErrLineageMismatch that names the
+// parents id actually has.
+func VerifyLineage(root string, id Identity, expected []string) error {
+	lineage, err := scanLineage(root)
+	if err != nil {
+		return err
+	}
+	for _, parent := range expected {
+		parent = strings.TrimSpace(parent)
+		if parent == "" {
+			continue
+		}
+		uuid := parent
+		if !slices.Contains(id.Parents, parent) {
+			if uuid, err = resolveParentUUID(lineage, parent); err != nil {
+				return err
+			}
+		}
+		if !slices.Contains(id.Parents, uuid) {
+			return fmt.Errorf("%w: %s %s (%s) has parents [%s], not %s", ErrLineageMismatch,
+				id.GivenName, id.FamilyName, id.UUID, strings.Join(id.Parents, ", "), uuid)
+		}
+	}
+	return nil
+}
+
+// FindChildren returns the holons under root that list parent, a full
+// UUID, among their parents, in scan order.
+func FindChildren(root, parent string) ([]LocatedIdentity, error) {
+	var children []LocatedIdentity
+	err := ScanAllWithPaths(root, 0, func(h LocatedIdentity) {
+		if slices.Contains(h.Identity.Parents, parent) {
+			children = append(children, h)
+		}
+	}, nil)
+	return children, err
+}
+
+// scanLineage maps the UUID of every holon under root to its parents.
+func scanLineage(root string) (map[string][]string, error) {
+	holons, err := FindAllWithPaths(root)
+	if err != nil {
+		return nil, err
+	}
+	lineage := make(map[string][]string, len(holons))
+	for _, h := range holons {
+		lineage[h.Identity.UUID] = append(lineage[h.Identity.UUID], h.Identity.Parents...)
+	}
+	return lineage, nil
+}
+
+// resolveParentUUID expands a parent UUID or unique prefix to the full
+// UUID of a known holon.
+func resolveParentUUID(lineage map[string][]string, parent string) (string, error) {
+	if _, ok := lineage[parent]; ok {
+		return parent, nil
+	}
+	var matches []string
+	for uuid := range lineage {
+		if strings.HasPrefix(uuid, parent) {
+			matches = append(matches, uuid)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("%w: unknown parent %q", ErrInvalidParent, parent)
+	case 1:
+		return matches[0], nil
+	default:
+		return "", fmt.Errorf("%w: %q matches %d holons", ErrInvalidParent, parent, len(matches))
+	}
+}
+
+// descendsFrom reports whether ancestor appears anywhere in the lineage
+// of uuid. Existing cycles in the data are tolerated.
+func descendsFrom(lineage map[string][]string, uuid, ancestor string) bool {
+	visited := map[string]bool{}
+	queue := []string{uuid}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, parent := range lineage[current] {
+			if parent == ancestor {
+				return true
+			}
+			if !visited[parent] {
+				visited[parent] = true
+				queue = append(queue, parent)
+			}
+		}
+	}
+	return false
+}
+
+// AppendChild adds child, a UUID, to the children list of the holon
+// whose HOLON.md is at path, unless it is already there. The file is
+// rewritten through RewriteHolonFile, so concurrent calls never lose a
+// child.
+func AppendChild(path, child string) error {
+	return RewriteHolonFile(path, func(data []byte) ([]byte, error) {
+		id, _, err := ParseFrontmatter(data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		if slices.Contains(id.Children, child) {
+			return nil, nil
+		}
+		children := append(slices.Clone([]string(id.Children)), child)
+		updated, err := UpdateFrontmatter(data, map[string]any{"children": children})
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		return updated, nil
+	})
+}
+
+// RewriteHolonFile replaces the HOLON.md at path with what rewrite
+// returns for its current content, or leaves it as it is when rewrite
+// returns nil. Every rewrite of a HOLON.md goes through here: writers
+// of the same file, in this process or another, take turns through a
+// lock file next to it (see lockHolonFile), and the file is replaced
+// atomically with its permissions kept, so no update is lost and
+// readers never see a partial file. Errors from rewrite are returned
+// as they are.
+func RewriteHolonFile(path string, rewrite func(data []byte) ([]byte, error)) error {
+	unlock, err := lockHolonFile(path)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	data, err := ReadHolonFile(path, 0)
+	if err != nil {
+		return err
+	}
+	updated, err := rewrite(data)
+	if err != nil || updated == nil {
+		return err
+	}
+	return replaceFile(path, updated, info.Mode().Perm())
+}
+
+// lockWait bounds how long lockHolonFile waits for another writer.
+var lockWait = 10 * time.Second
+
+// lockHolonFile takes the lock of a HOLON.md: the exclusive creation of
+// path + ".lock". It retries until lockWait has passed, then reports
+// the lock file, which a crashed writer may have left behind. The
+// returned func releases the lock.
+func lockHolonFile(path string) (func(), error) {
+	lock := path + ".lock"
+	deadline := time.Now().Add(lockWait)
+	for {
+		f, err := os.OpenFile(lock, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+		if err == nil {
+			f.Close()
+			return func() { os.Remove(lock) }, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return nil, fmt.Errorf("lock %s: %w", path, err)
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("%s is locked; remove %s if no other who command is running", path, lock)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+// replaceFile writes data to a temporary file next to path and renames
+// it over path, so the content changes in one step.
+func replaceFile(path string, data []byte, perm os.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return fmt.Errorf("write %s: %w", path, err)
+	}
+	defer os.Remove(tmp.Name())
+
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(tmp.Name(), perm)
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+	if err != nil {
+		return fmt.Errorf("write %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,282 @@
+package identity
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// writeLineage writes one holon per name under root, each with the
+// given parents, and returns their UUIDs and HOLON.md paths by name.
+func writeLineage(t *testing.T, root string, names []string, parents map[string][]string) (map[string]string, map[string]string) {
+	t.Helper()
+	uuids := map[string]string{}
+	for _, name := range names {
+		uuids[name] = NewID()
+	}
+	paths := map[string]string{}
+	for _, name := range names {
+		id := validIdentity()
+		id.UUID = uuids[name]
+		id.GivenName = name
+		for _, p := range parents[name] {
+			id.Parents = append(id.Parents, uuids[p])
+		}
+		path := filepath.Join(root, name, "HOLON.md")
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := WriteHolonMD(id, path); err != nil {
+			t.Fatal(err)
+		}
+		paths[name] = path
+	}
+	return uuids, paths
+}
+
+func TestReparent(t *testing.T) {
+	root := t.TempDir()
+	uuids, paths := writeLineage(t, root, []string{"grandparent", "parent", "child", "adopter"}, map[string][]string{
+		"parent": {"grandparent"},
+		"child":  {"parent"},
+	})
+
+	before, err := os.ReadFile(paths["child"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	id, previous, err := Reparent(root, paths["child"], []string{uuids["adopter"][:13]})
+	if err != nil {
+		t.Fatalf("Reparent failed: %v", err)
+	}
+	if !reflect.DeepEqual(previous, []string{uuids["parent"]}) {
+		t.Errorf("previous parents = %v, want [%s]", previous, uuids["parent"])
+	}
+	if !reflect.DeepEqual([]string(id.Parents), []string{uuids["adopter"]}) {
+		t.Errorf("parents = %v, want the full adopter UUID", id.Parents)
+	}
+
+	after, err := os.ReadFile(paths["child"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed, body, err := ParseFrontmatter(after)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual([]string(parsed.Parents), []string{uuids["adopter"]}) {
+		t.Errorf("written parents = %v", parsed.Parents)
+	}
+	if _, oldBody, _ := ParseFrontmatter(before); body != oldBody {
+		t.Errorf("body changed:\n%s\nwant:\n%s", body, oldBody)
+	}
+	if got := strings.Count(string(after), "\n"); got != strings.Count(string(before), "\n") {
+		t.Errorf("line count changed from %d to %d", strings.Count(string(before), "\n"), got)
+	}
+}
+
+func TestReparentRejectsCyclesAndUnknownParents(t *testing.T) {
+	root := t.TempDir()
+	uuids, paths := writeLineage(t, root, []string{"grandparent", "parent", "child", "stranger"}, map[string][]string{
+		"parent": {"grandparent"},
+		"child":  {"parent"},
+	})
+	original, err := os.ReadFile(paths["grandparent"])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		parents []string
+		want    error
+	}{
+		{"descendant", []string{uuids["child"]}, ErrLineageCycle},
+		{"self", []string{uuids["grandparent"]}, ErrLineageCycle},
+		{"unknown", []string{"ffffffff-0000-4000-8000-000000000000"}, ErrInvalidParent},
+		{"duplicate", []string{uuids["stranger"][:8], uuids["stranger"]}, ErrInvalidParent},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := Reparent(root, paths["grandparent"], tt.parents); !errors.Is(err, tt.want) {
+				t.Errorf("Reparent(%v) = %v, want %v", tt.parents, err, tt.want)
+			}
+		})
+	}
+
+	if data, _ := os.ReadFile(paths["grandparent"]); string(data) != string(original) {
+		t.Error("a refused reparent rewrote the file")
+	}
+}
+
+func TestVerifyLineage(t *testing.T) {
+	root := t.TempDir()
+	uuids, _ := writeLineage(t, root, []string{"generator", "other", "generated"}, map[string][]string{
+		"generated": {"generator"},
+	})
+	h, err := ResolveTarget(root, uuids["generated"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := h.Identity
+
+	for _, expected := range [][]string{{uuids["generator"]}, {uuids["generator"][:13]}, nil} {
+		if err := VerifyLineage(root, id, expected); err != nil {
+			t.Errorf("VerifyLineage(%v) = %v, want success", expected, err)
+		}
+	}
+
+	err = VerifyLineage(root, id, []string{uuids["generator"], uuids["other"]})
+	if !errors.Is(err, ErrLineageMismatch) {
+		t.Fatalf("VerifyLineage(wrong parent) = %v, want ErrLineageMismatch", err)
+	}
+	if !strings.Contains(err.Error(), uuids["other"]) || !strings.Contains(err.Error(), uuids["generator"]) {
+		t.Errorf("error %q should name the expected and the actual parents", err)
+	}
+	if err := VerifyLineage(root, id, []string{"ffffffff"}); !errors.Is(err, ErrInvalidParent) {
+		t.Errorf("VerifyLineage(unknown) = %v, want ErrInvalidParent", err)
+	}
+}
+
+func TestFindChildren(t *testing.T) {
+	root := t.TempDir()
+	uuids, paths := writeLineage(t, root, []string{"generator", "first", "second", "other"}, map[string][]string{
+		"first":  {"generator"},
+		"second": {"generator", "other"},
+	})
+
+	children, err := FindChildren(root, uuids["generator"])
+	if err != nil {
+		t.Fatalf("FindChildren: %v", err)
+	}
+	var got []string
+	for _, c := range children {
+		got = append(got, c.Path)
+	}
+	slices.Sort(got)
+	if want := []string{paths["first"], paths["second"]}; !slices.Equal(got, want) {
+		t.Errorf("FindChildren = %v, want %v", got, want)
+	}
+
+	if children, err := FindChildren(root, uuids["first"]); err != nil || len(children) != 0 {
+		t.Errorf("FindChildren(leaf) = %v, %v, want none", children, err)
+	}
+}
+
+func TestAppendChildConcurrent(t *testing.T) {
+	root := t.TempDir()
+	_, paths := writeLineage(t, root, []string{"parent"}, nil)
+
+	children := make([]string, 20)
+	errs := make(chan error, len(children))
+	var wg sync.WaitGroup
+	for i := range children {
+		children[i] = NewID()
+		wg.Add(1)
+		go func(child string) {
+			defer wg.Done()
+			errs <- AppendChild(paths["parent"], child)
+		}(children[i])
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("AppendChild failed: %v", err)
+		}
+	}
+
+	// Appending a child already listed changes nothing.
+	if err := AppendChild(paths["parent"], children[0]); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(paths["parent"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	id, body, err := ParseFrontmatter(data)
+	if err != nil {
+		t.Fatalf("parent no longer parses: %v", err)
+	}
+	got := slices.Sorted(slices.Values(id.Children))
+	if want := slices.Sorted(slices.Values(children)); !slices.Equal(got, want) {
+		t.Errorf("children = %d entries %v, want all %d", len(got), got, len(want))
+	}
+	if !strings.Contains(body, "## Description") {
+		t.Errorf("body was not preserved:\n%s", body)
+	}
+
+	entries, err := os.ReadDir(filepath.Dir(paths["parent"]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("AppendChild left files behind: %v", entries)
+	}
+}
+
+func TestRewritesOfOneFileTakeTurns(t *testing.T) {
+	root := t.TempDir()
+	_, paths := writeLineage(t, root, []string{"parent"}, nil)
+
+	children := make([]string, 10)
+	errs := make(chan error, 2*len(children))
+	var wg sync.WaitGroup
+	for i := range children {
+		children[i] = NewID()
+		wg.Add(2)
+		go func(child string) {
+			defer wg.Done()
+			errs <- AppendChild(paths["parent"], child)
+		}(children[i])
+		go func(status string) {
+			defer wg.Done()
+			_, _, err := SetStatus(paths["parent"], status, ValidateOptions{})
+			errs <- err
+		}([]string{"draft", "stable"}[i%2])
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("concurrent rewrite failed: %v", err)
+		}
+	}
+
+	data, err := os.ReadFile(paths["parent"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	id, _, err := ParseFrontmatter(data)
+	if err != nil {
+		t.Fatalf("parent no longer parses: %v", err)
+	}
+	if got := len(id.Children); got != len(children) {
+		t.Errorf("children = %d entries, want %d: a status rewrite lost some", got, len(children))
+	}
+	if id.Status != "draft" && id.Status != "stable" {
+		t.Errorf("status = %q, want draft or stable", id.Status)
+	}
+}
+
+func TestAppendChildLocked(t *testing.T) {
+	root := t.TempDir()
+	_, paths := writeLineage(t, root, []string{"parent"}, nil)
+	if err := os.WriteFile(paths["parent"]+".lock", nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	defer func(wait time.Duration) { lockWait = wait }(lockWait)
+	lockWait = 20 * time.Millisecond
+
+	err := AppendChild(paths["parent"], NewID())
+	if err == nil || !strings.Contains(err.Error(), "locked") {
+		t.Errorf("AppendChild on a locked file: err = %v, want a lock error", err)
+	}
+}
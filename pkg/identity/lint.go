@@ -0,0 +1,235 @@
+package identity
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// Severity levels for lint findings.
+const (
+	SeverityWarning = "warning"
+	SeverityError   = "error"
+)
+
+// Finding is a single diagnostic produced by a lint rule.
+type Finding struct {
+	Severity string `json:"severity"`
+	Rule     string `json:"rule"`
+	Path     string `json:"path"`
+	UUID     string `json:"uuid"`
+	Message  string `json:"message"`
+}
+
+// DefaultMaxMottoLength is the motto length, in runes, above which
+// Lint warns by default. A motto is meant to be a single sentence.
+const DefaultMaxMottoLength = 120
+
+// LintOptions selects which lint rules run.
+type LintOptions struct {
+	// StatusConsistency flags status/proto_status pairs that are
+	// unlikely to be intentional (e.g. a dead holon with a stable contract).
+	StatusConsistency bool
+
+	// MaxMottoLength flags mottos longer than this many runes.
+	// Zero disables the check.
+	MaxMottoLength int
+
+	// GeneratedBy flags generated_by values that are not a "name" or
+	// "name/version" tool identifier.
+	GeneratedBy bool
+
+	// DuplicateUUIDs flags, as errors, holons that share a UUID with
+	// another holon in the same LintAll run.
+	DuplicateUUIDs bool
+
+	// Lineage flags parents that are not the UUID of any holon in the
+	// same LintAll run.
+	Lineage bool
+
+	// Names flags the identities Identity.Warnings reports, such as a
+	// given name equal to the family name.
+	Names bool
+
+	// IDFormat flags uuid values that are neither a UUID nor a ULID,
+	// such as the free-form ids of older holons.
+	IDFormat bool
+}
+
+// DefaultLintOptions enables every lint rule.
+func DefaultLintOptions() LintOptions {
+	return LintOptions{
+		StatusConsistency: true,
+		MaxMottoLength:    DefaultMaxMottoLength,
+		GeneratedBy:       true,
+		DuplicateUUIDs:    true,
+		Lineage:           true,
+		Names:             true,
+		IDFormat:          true,
+	}
+}
+
+// allowedProtoStatuses lists, for each holon status that constrains its
+// contract, the proto_status values considered consistent with it.
+var allowedProtoStatuses = map[string][]string{
+	"deprecated": {"stable", "deprecated", "dead"},
+	"dead":       {"deprecated", "dead"},
+}
+
+// Lint runs the enabled rules against a located identity.
+func Lint(h LocatedIdentity, opts LintOptions) []Finding {
+	var findings []Finding
+
+	if opts.StatusConsistency {
+		if msg := checkStatusConsistency(h.Identity); msg != "" {
+			findings = append(findings, Finding{
+				Severity: SeverityWarning,
+				Rule:     "status-consistency",
+				Path:     h.Path,
+				UUID:     h.Identity.UUID,
+				Message:  msg,
+			})
+		}
+	}
+
+	if opts.MaxMottoLength > 0 {
+		if n := utf8.RuneCountInString(h.Identity.Motto); n > opts.MaxMottoLength {
+			findings = append(findings, Finding{
+				Severity: SeverityWarning,
+				Rule:     "motto-length",
+				Path:     h.Path,
+				UUID:     h.Identity.UUID,
+				Message:  fmt.Sprintf("motto is %d characters long (max %d)", n, opts.MaxMottoLength),
+			})
+		}
+	}
+
+	if opts.GeneratedBy {
+		if err := CheckGeneratedBy(h.Identity.GeneratedBy); err != nil {
+			findings = append(findings, Finding{
+				Severity: SeverityWarning,
+				Rule:     "generated-by",
+				Path:     h.Path,
+				UUID:     h.Identity.UUID,
+				Message:  err.Error(),
+			})
+		}
+	}
+
+	if opts.Names {
+		for _, w := range h.Identity.Warnings() {
+			findings = append(findings, Finding{
+				Severity: SeverityWarning,
+				Rule:     "names",
+				Path:     h.Path,
+				UUID:     h.Identity.UUID,
+				Message:  w.Error(),
+			})
+		}
+	}
+
+	if opts.IDFormat && h.Identity.UUID != "" {
+		if err := CheckID(h.Identity.UUID); err != nil {
+			findings = append(findings, Finding{
+				Severity: SeverityWarning,
+				Rule:     "id-format",
+				Path:     h.Path,
+				UUID:     h.Identity.UUID,
+				Message:  "uuid " + err.Error(),
+			})
+		}
+	}
+
+	return findings
+}
+
+// LintAll runs Lint against each holon, then the rules that compare
+// holons with one another: duplicate UUIDs and broken lineage.
+func LintAll(holons []LocatedIdentity, opts LintOptions) []Finding {
+	var findings []Finding
+	for _, h := range holons {
+		findings = append(findings, Lint(h, opts)...)
+	}
+
+	first := make(map[string]string, len(holons))
+	for _, h := range holons {
+		id := h.Identity.UUID
+		if id == "" {
+			continue
+		}
+		if path, seen := first[id]; seen {
+			if opts.DuplicateUUIDs {
+				findings = append(findings, Finding{
+					Severity: SeverityError,
+					Rule:     "duplicate-uuid",
+					Path:     h.Path,
+					UUID:     id,
+					Message:  fmt.Sprintf("uuid is already used by %s", path),
+				})
+			}
+			continue
+		}
+		first[id] = h.Path
+	}
+
+	if opts.Lineage {
+		for _, h := range holons {
+			for _, parent := range h.Identity.Parents {
+				if _, known := first[parent]; !known {
+					findings = append(findings, Finding{
+						Severity: SeverityWarning,
+						Rule:     "unknown-parent",
+						Path:     h.Path,
+						UUID:     h.Identity.UUID,
+						Message:  fmt.Sprintf("parent %q is not a known holon", parent),
+					})
+				}
+			}
+		}
+	}
+
+	return findings
+}
+
+// generatedByPattern matches "name" or "name/version", e.g.
+// "sophia-who/0.1.0".
+var generatedByPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*(/[A-Za-z0-9][A-Za-z0-9.+_-]*)?$`)
+
+// placeholderGeneratedBy lists values left behind by templates or
+// hand edits that say nothing about provenance.
+var placeholderGeneratedBy = []string{"todo", "tbd", "fixme", "unknown", "none", "n/a", "xxx", "changeme", "tool", "name/version"}
+
+// CheckGeneratedBy reports whether value is a well-formed tool
+// identifier: "name" or "name/version", without spaces or placeholder
+// content. An empty value is allowed.
+func CheckGeneratedBy(value string) error {
+	if value == "" {
+		return nil
+	}
+	for _, p := range placeholderGeneratedBy {
+		if strings.EqualFold(value, p) {
+			return fmt.Errorf("generated_by %q is a placeholder, want a tool identifier like %q", value, GeneratedBy)
+		}
+	}
+	if strings.ContainsAny(value, "<>{}") {
+		return fmt.Errorf("generated_by %q is a placeholder, want a tool identifier like %q", value, GeneratedBy)
+	}
+	if !generatedByPattern.MatchString(value) {
+		return fmt.Errorf("generated_by %q is not a \"name\" or \"name/version\" tool identifier", value)
+	}
+	return nil
+}
+
+func checkStatusConsistency(id Identity) string {
+	allowed, ok := allowedProtoStatuses[id.Status]
+	if !ok {
+		return ""
+	}
+	for _, s := range allowed {
+		if id.ProtoStatus == s {
+			return ""
+		}
+	}
+	return fmt.Sprintf("status is %q but proto_status is %q", id.Status, id.ProtoStatus)
+}
@@ -0,0 +1,129 @@
+package identity
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLintStatusConsistencyPasses(t *testing.T) {
+	h := LocatedIdentity{
+		Identity: Identity{UUID: "0c1d2e3f-0000-4000-8000-000000000001", Status: "dead", ProtoStatus: "deprecated"},
+		Path:     "holons/dead/HOLON.md",
+	}
+
+	if findings := Lint(h, DefaultLintOptions()); len(findings) != 0 {
+		t.Fatalf("Lint returned %d findings for a consistent pair, want 0: %+v", len(findings), findings)
+	}
+}
+
+func TestLintStatusConsistencyWarns(t *testing.T) {
+	h := LocatedIdentity{
+		Identity: Identity{UUID: "0c1d2e3f-0000-4000-8000-000000000002", Status: "dead", ProtoStatus: "stable"},
+		Path:     "holons/ghost/HOLON.md",
+	}
+
+	findings := Lint(h, DefaultLintOptions())
+	if len(findings) != 1 {
+		t.Fatalf("Lint returned %d findings, want 1", len(findings))
+	}
+	f := findings[0]
+	if f.Severity != SeverityWarning {
+		t.Errorf("Severity = %q, want %q", f.Severity, SeverityWarning)
+	}
+	if f.Rule != "status-consistency" {
+		t.Errorf("Rule = %q, want %q", f.Rule, "status-consistency")
+	}
+	if f.UUID != "0c1d2e3f-0000-4000-8000-000000000002" || f.Path != "holons/ghost/HOLON.md" {
+		t.Errorf("finding not attributed to the holon: %+v", f)
+	}
+}
+
+func TestLintStatusConsistencyDisabled(t *testing.T) {
+	h := LocatedIdentity{Identity: Identity{Status: "dead", ProtoStatus: "draft"}}
+
+	if findings := Lint(h, LintOptions{}); len(findings) != 0 {
+		t.Fatalf("Lint returned %d findings with the rule disabled, want 0", len(findings))
+	}
+}
+
+func TestLintMottoLength(t *testing.T) {
+	motto := strings.Repeat("é", DefaultMaxMottoLength)
+	h := LocatedIdentity{Identity: Identity{UUID: "0c1d2e3f-0000-4000-8000-000000000003", Motto: motto}, Path: "holons/m/HOLON.md"}
+
+	// Multibyte runes count once each: exactly at the limit passes.
+	if findings := Lint(h, DefaultLintOptions()); len(findings) != 0 {
+		t.Fatalf("Lint returned %d findings at the limit, want 0: %+v", len(findings), findings)
+	}
+
+	h.Identity.Motto += "!"
+	findings := Lint(h, DefaultLintOptions())
+	if len(findings) != 1 {
+		t.Fatalf("Lint returned %d findings over the limit, want 1", len(findings))
+	}
+	if findings[0].Rule != "motto-length" || findings[0].Severity != SeverityWarning {
+		t.Errorf("finding = %+v, want a motto-length warning", findings[0])
+	}
+
+	if findings := Lint(h, LintOptions{}); len(findings) != 0 {
+		t.Fatalf("Lint returned %d findings with the rule disabled, want 0", len(findings))
+	}
+}
+
+func TestLintNames(t *testing.T) {
+	h := LocatedIdentity{Identity: Identity{UUID: "0c1d2e3f-0000-4000-8000-000000000004", GivenName: "Echo", FamilyName: "echo"}, Path: "holons/echo/HOLON.md"}
+	findings := Lint(h, DefaultLintOptions())
+	if len(findings) != 1 || findings[0].Rule != "names" || findings[0].Severity != SeverityWarning {
+		t.Fatalf("Lint = %+v, want one names warning", findings)
+	}
+	if findings := Lint(h, LintOptions{}); len(findings) != 0 {
+		t.Fatalf("Lint returned %d findings with the rule disabled, want 0", len(findings))
+	}
+}
+
+func TestLintGeneratedBy(t *testing.T) {
+	for _, value := range []string{"", "manual", "sophia-who", GeneratedBy, "codex/1.2.3-beta+7"} {
+		h := LocatedIdentity{Identity: Identity{UUID: "0c1d2e3f-0000-4000-8000-000000000005", GeneratedBy: value}}
+		if findings := Lint(h, DefaultLintOptions()); len(findings) != 0 {
+			t.Errorf("generated_by %q: findings = %+v, want none", value, findings)
+		}
+	}
+
+	for _, value := range []string{"sophia who", "my tool/1.0", "TODO", "<tool>/<version>", "a//b"} {
+		h := LocatedIdentity{Identity: Identity{UUID: "0c1d2e3f-0000-4000-8000-000000000006", GeneratedBy: value}}
+		findings := Lint(h, DefaultLintOptions())
+		if len(findings) != 1 || findings[0].Rule != "generated-by" || findings[0].Severity != SeverityWarning {
+			t.Errorf("generated_by %q: findings = %+v, want one generated-by warning", value, findings)
+		}
+	}
+
+	h := LocatedIdentity{Identity: Identity{UUID: "0c1d2e3f-0000-4000-8000-000000000007", GeneratedBy: "sophia who"}}
+	if findings := Lint(h, LintOptions{}); len(findings) != 0 {
+		t.Errorf("findings with the rule disabled = %+v, want none", findings)
+	}
+}
+
+func TestLintAllDuplicatesAndLineage(t *testing.T) {
+	holons := []LocatedIdentity{
+		{Identity: Identity{UUID: "a"}, Path: "holons/a/HOLON.md"},
+		{Identity: Identity{UUID: "a"}, Path: "holons/copy/HOLON.md"},
+		{Identity: Identity{UUID: "b", Parents: []string{"a", "missing"}}, Path: "holons/b/HOLON.md"},
+	}
+
+	rules := map[string]Finding{}
+	for _, f := range LintAll(holons, DefaultLintOptions()) {
+		rules[f.Rule] = f
+	}
+
+	dup, ok := rules["duplicate-uuid"]
+	if !ok || dup.Severity != SeverityError || dup.Path != "holons/copy/HOLON.md" {
+		t.Errorf("duplicate-uuid finding = %+v, want an error on the copy", dup)
+	}
+	lineage, ok := rules["unknown-parent"]
+	if !ok || !strings.Contains(lineage.Message, "missing") {
+		t.Errorf("unknown-parent finding = %+v, want one naming the missing parent", lineage)
+	}
+
+	if findings := LintAll(holons, LintOptions{}); len(findings) != 0 {
+		t.Errorf("LintAll with every rule disabled returned %+v", findings)
+	}
+}
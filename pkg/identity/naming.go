@@ -0,0 +1,63 @@
+package identity
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+// NameTemplateData is what a given-name template is executed with.
+type NameTemplateData struct {
+	Counter    int      // 1, 2, ... until the name is free
+	FamilyName string   // family name of the new holon
+	Parents    []string // given names of its parents, in order
+}
+
+// maxNameAttempts bounds the counter of GenerateGivenName.
+const maxNameAttempts = 100000
+
+// ParseNameTemplate parses a text/template for given names, such as
+// `Holon-{{printf "%04d" .Counter}}` (see NameTemplateData).
+func ParseNameTemplate(text string) (*template.Template, error) {
+	tmpl, err := template.New("given_name").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid name template: %w", err)
+	}
+	return tmpl, nil
+}
+
+// GenerateGivenName executes tmpl with Counter 1, 2, ... and returns the
+// first given name that, with data.FamilyName, no holon in existing
+// already has (compared case-insensitively, as ResolveTarget does).
+func GenerateGivenName(tmpl *template.Template, data NameTemplateData, existing []Identity) (string, error) {
+	taken := make(map[string]bool, len(existing))
+	for _, id := range existing {
+		taken[fullNameKey(id.GivenName, id.FamilyName)] = true
+	}
+
+	previous := ""
+	for data.Counter = 1; data.Counter <= maxNameAttempts; data.Counter++ {
+		var b strings.Builder
+		if err := tmpl.Execute(&b, data); err != nil {
+			return "", fmt.Errorf("name template: %w", err)
+		}
+		name := strings.TrimSpace(b.String())
+		if name == "" || strings.ContainsFunc(name, unicode.IsControl) {
+			return "", fmt.Errorf("name template produced an invalid given name %q", name)
+		}
+		if !taken[fullNameKey(name, data.FamilyName)] {
+			return name, nil
+		}
+		if name == previous {
+			// The template ignores the counter: no other name will come.
+			return "", fmt.Errorf("name template always produces %q, which is taken", name)
+		}
+		previous = name
+	}
+	return "", fmt.Errorf("name template produced no free name in %d attempts", maxNameAttempts)
+}
+
+func fullNameKey(given, family string) string {
+	return strings.ToLower(strings.TrimSpace(given + " " + family))
+}
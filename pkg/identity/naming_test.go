@@ -0,0 +1,35 @@
+package identity
+
+import "testing"
+
+func TestGenerateGivenName(t *testing.T) {
+	existing := []Identity{
+		{GivenName: "mother-1", FamilyName: "Bred"},
+		{GivenName: "Mother-2", FamilyName: "bred"},
+		{GivenName: "Mother-3", FamilyName: "Other"},
+	}
+
+	tmpl, err := ParseNameTemplate(`{{index .Parents 0}}-{{.Counter}}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	name, err := GenerateGivenName(tmpl, NameTemplateData{FamilyName: "Bred", Parents: []string{"Mother"}}, existing)
+	if err != nil {
+		t.Fatalf("GenerateGivenName failed: %v", err)
+	}
+	if name != "Mother-3" {
+		t.Errorf("name = %q, want Mother-3 (1 and 2 are taken in family Bred)", name)
+	}
+
+	fixed, err := ParseNameTemplate(`Mother-1`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := GenerateGivenName(fixed, NameTemplateData{FamilyName: "Bred"}, existing); err == nil {
+		t.Error("a template ignoring the counter looped or reused a taken name")
+	}
+
+	if _, err := ParseNameTemplate(`{{.Counter`); err == nil {
+		t.Error("malformed template accepted")
+	}
+}
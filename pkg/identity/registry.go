@@ -1,9 +1,16 @@
 package identity
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
+	"io"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
 	"strings"
 
 	"gopkg.in/yaml.v3"
@@ -15,6 +22,163 @@ type LocatedIdentity struct {
 	Path     string
 }
 
+// IgnoreMarker is the file name that, placed next to a HOLON.md, excludes
+// that HOLON.md from scans (e.g. for templates and examples).
+const IgnoreMarker = ".holonignore"
+
+// RootMarker is the conventional ScanOptions.AnchorMarker: a file placed
+// at the root of a holon tree, e.g. a repository, so that scans stay
+// inside it.
+const RootMarker = ".holon-root"
+
+// DefaultMaxFileSize is the largest HOLON.md read by default. Identity
+// cards are a few kilobytes; anything near this size is a mistake or an
+// attempt to exhaust memory.
+const DefaultMaxFileSize = 4 << 20
+
+// ErrFileTooLarge is returned, wrapped, for HOLON.md files over the
+// size limit.
+var ErrFileTooLarge = errors.New("file too large")
+
+// ErrNotHolonFile is returned, wrapped, when a scan root is a file
+// other than a HOLON.md.
+var ErrNotHolonFile = errors.New("not a directory or HOLON.md file")
+
+// HolonFileRoot reports whether root is a HOLON.md file rather than a
+// directory; scans of such a root find that one holon. Any other file is
+// an error wrapping ErrNotHolonFile. Roots that do not exist are left to
+// the scan, which finds nothing there.
+func HolonFileRoot(root string) (bool, error) {
+	info, err := os.Stat(root)
+	if err != nil || info.IsDir() {
+		return false, nil
+	}
+	if filepath.Base(root) != "HOLON.md" {
+		return false, fmt.Errorf("%s: %w", root, ErrNotHolonFile)
+	}
+	return true, nil
+}
+
+// ErrSymlink is wrapped by the error ending a scan under SymlinkReject
+// when it meets a symlinked HOLON.md.
+var ErrSymlink = errors.New("HOLON.md is a symbolic link")
+
+// ErrSymlinkOutsideRoot is wrapped by the warnings of scans following a
+// symlinked HOLON.md whose target lies outside the scanned root.
+var ErrSymlinkOutsideRoot = errors.New("symbolic link points outside the root")
+
+// SymlinkPolicy selects how scans treat a HOLON.md that is a symbolic
+// link, which may point anywhere on the file system.
+type SymlinkPolicy int
+
+const (
+	// SymlinkFollow reads the link target, reporting a warning wrapping
+	// ErrSymlinkOutsideRoot if it lies outside the scanned root.
+	SymlinkFollow SymlinkPolicy = iota
+	// SymlinkSkip ignores symlinked HOLON.md files.
+	SymlinkSkip
+	// SymlinkReject ends the scan with an error wrapping ErrSymlink.
+	SymlinkReject
+)
+
+var symlinkPolicyNames = []string{"follow", "skip", "reject"}
+
+// String returns the name ParseSymlinkPolicy accepts for p.
+func (p SymlinkPolicy) String() string {
+	if p >= 0 && int(p) < len(symlinkPolicyNames) {
+		return symlinkPolicyNames[p]
+	}
+	return fmt.Sprintf("SymlinkPolicy(%d)", int(p))
+}
+
+// ParseSymlinkPolicy parses "follow", "skip", or "reject".
+func ParseSymlinkPolicy(s string) (SymlinkPolicy, error) {
+	if i := slices.Index(symlinkPolicyNames, strings.ToLower(strings.TrimSpace(s))); i >= 0 {
+		return SymlinkPolicy(i), nil
+	}
+	return 0, fmt.Errorf("unknown symlink policy %q (want %s)", s, strings.Join(symlinkPolicyNames, ", "))
+}
+
+// ScanOptions tunes HOLON.md discovery. The zero value is the default.
+type ScanOptions struct {
+	// ProgressEvery reports progress every N scanned files (0: only at the end).
+	ProgressEvery int
+
+	// IncludeIgnored disables IgnoreMarker handling, so HOLON.md files
+	// next to a .holonignore are scanned like any other.
+	IncludeIgnored bool
+
+	// MaxResults stops the scan once this many holons have been found
+	// (0: no limit).
+	MaxResults int
+
+	// Filter, if set, keeps only the holons it returns true for. The
+	// others are not reported and do not count toward MaxResults.
+	Filter func(LocatedIdentity) bool
+
+	// MaxFileSize skips HOLON.md files larger than this many bytes
+	// without reading them (0: DefaultMaxFileSize, negative: no limit).
+	MaxFileSize int64
+
+	// OnError, if set, is called for each HOLON.md skipped because it
+	// could not be read, including files over MaxFileSize.
+	OnError func(path string, err error)
+
+	// Symlinks says what to do with HOLON.md files that are symbolic
+	// links (default SymlinkFollow).
+	Symlinks SymlinkPolicy
+
+	// OnWarning, if set, is called for each HOLON.md scanned despite a
+	// problem, such as a symlink followed outside the root.
+	OnWarning func(path string, err error)
+
+	// SkipDirs lists directories that are not descended into, e.g. a
+	// holon cache that happens to live under the scanned root.
+	SkipDirs []string
+
+	// AnchorMarker, when set, is the name of a marker file (usually
+	// RootMarker) anchoring scans: if the scanned tree holds any, only
+	// the directories holding one are scanned, with their subtrees.
+	// Without a marker anywhere the whole tree is scanned, as usual.
+	AnchorMarker string
+}
+
+// ReadHolonFile reads the file at path unless it is larger than maxSize
+// bytes (0: DefaultMaxFileSize, negative: no limit), in which case the
+// error wraps ErrFileTooLarge and nothing is read.
+func ReadHolonFile(path string, maxSize int64) ([]byte, error) {
+	if maxSize == 0 {
+		maxSize = DefaultMaxFileSize
+	}
+	if maxSize < 0 {
+		return os.ReadFile(path)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if info.Size() > maxSize {
+		return nil, fmt.Errorf("%s is %d bytes: %w (limit %d)", path, info.Size(), ErrFileTooLarge, maxSize)
+	}
+
+	// The file may grow between Stat and the read; never read past the limit.
+	data, err := io.ReadAll(io.LimitReader(f, maxSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > maxSize {
+		return nil, fmt.Errorf("%s is over %d bytes: %w", path, maxSize, ErrFileTooLarge)
+	}
+	return data, nil
+}
+
 // ScanProgress reports scan progress for HOLON.md discovery.
 type ScanProgress struct {
 	ScannedFiles int
@@ -53,6 +217,26 @@ func FindAllWithPaths(root string) ([]LocatedIdentity, error) {
 // Each parsed holon is emitted through onFound as soon as it is discovered.
 // If onProgress is provided, it is called periodically and once at the end.
 func ScanAllWithPaths(root string, progressEvery int, onFound func(LocatedIdentity), onProgress func(ScanProgress)) error {
+	return ScanWithOptions(root, ScanOptions{ProgressEvery: progressEvery}, onFound, onProgress)
+}
+
+// ScanWithOptions is ScanAllWithPaths with explicit ScanOptions.
+func ScanWithOptions(root string, opts ScanOptions, onFound func(LocatedIdentity), onProgress func(ScanProgress)) error {
+	return scanUntil(root, opts, func(h LocatedIdentity) bool {
+		if onFound != nil {
+			onFound(h)
+		}
+		return true
+	}, onProgress)
+}
+
+// scanUntil is ScanWithOptions stopping as soon as onFound returns
+// false. Progress is still reported once at the end.
+func scanUntil(root string, opts ScanOptions, onFound func(LocatedIdentity) bool, onProgress func(ScanProgress)) error {
+	if _, err := HolonFileRoot(root); err != nil {
+		return err
+	}
+	progressEvery := opts.ProgressEvery
 	if progressEvery < 0 {
 		progressEvery = 0
 	}
@@ -71,45 +255,35 @@ func ScanAllWithPaths(root string, progressEvery int, onFound func(LocatedIdenti
 		}
 	}
 
-	err := filepath.WalkDir(root, func(path string, d os.DirEntry, err error) error {
-		if err != nil {
-			return nil
-		}
-		if d.IsDir() {
-			name := d.Name()
-			if name != "." && strings.HasPrefix(name, ".") {
-				return filepath.SkipDir
-			}
-			return nil
-		}
-
+	err := walkHolonFiles(root, opts, func() {
 		scanned++
 		reportProgress(false)
-
-		if d.Name() != "HOLON.md" {
-			return nil
-		}
-
-		data, err := os.ReadFile(path)
+	}, func(path string) bool {
+		data, err := ReadHolonFile(path, opts.MaxFileSize)
 		if err != nil {
-			return nil
+			if opts.OnError != nil {
+				opts.OnError(path, err)
+			}
+			return true
 		}
 
 		id, _, err := ParseFrontmatter(data)
 		if err != nil {
-			return nil
+			return true
 		}
 
 		located := LocatedIdentity{
 			Identity: id,
 			Path:     path,
 		}
+		if opts.Filter != nil && !opts.Filter(located) {
+			return true
+		}
 		found++
-		if onFound != nil {
-			onFound(located)
+		if !onFound(located) {
+			return false
 		}
-
-		return nil
+		return opts.MaxResults <= 0 || found < opts.MaxResults
 	})
 
 	if err != nil {
@@ -122,66 +296,342 @@ func ScanAllWithPaths(root string, progressEvery int, onFound func(LocatedIdenti
 
 // FindByUUID locates a HOLON.md file by full UUID or prefix.
 func FindByUUID(root, target string) (string, error) {
+	return FindByUUIDWithOptions(root, target, ScanOptions{})
+}
+
+// FindByUUIDWithOptions is FindByUUID with explicit ScanOptions.
+func FindByUUIDWithOptions(root, target string, opts ScanOptions) (string, error) {
+	return FindByUUIDWithProgress(root, target, opts, nil)
+}
+
+// FindByUUIDWithProgress is FindByUUIDWithOptions reporting progress
+// through onProgress as ScanWithOptions does: every opts.ProgressEvery
+// scanned files and once at the end. The scan stops at the first match.
+func FindByUUIDWithProgress(root, target string, opts ScanOptions, onProgress func(ScanProgress)) (string, error) {
 	var found string
+	err := scanUntil(root, opts, func(h LocatedIdentity) bool {
+		if strings.HasPrefix(h.Identity.UUID, target) {
+			found = h.Path
+			return false
+		}
+		return true
+	}, onProgress)
+	if err != nil {
+		return "", err
+	}
+	if found == "" {
+		return "", fmt.Errorf("holon not found: %s", target)
+	}
+	return found, nil
+}
 
-	err := filepath.WalkDir(root, func(path string, d os.DirEntry, err error) error {
-		if err != nil || d.IsDir() || d.Name() != "HOLON.md" {
-			return nil
+// FindEnclosing walks up from dir to the nearest directory holding a
+// HOLON.md, dir itself included, and returns the parsed identity.
+func FindEnclosing(dir string) (LocatedIdentity, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return LocatedIdentity{}, err
+	}
+
+	for start := dir; ; {
+		path := filepath.Join(dir, "HOLON.md")
+		if data, err := ReadHolonFile(path, 0); err == nil {
+			id, _, err := ParseFrontmatter(data)
+			if err != nil {
+				return LocatedIdentity{}, fmt.Errorf("%s: %w", path, err)
+			}
+			return LocatedIdentity{Identity: id, Path: path}, nil
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return LocatedIdentity{}, fmt.Errorf("no HOLON.md found in %s or any parent directory", start)
 		}
+		dir = parent
+	}
+}
 
-		data, err := os.ReadFile(path)
+// HolonFiles returns the path of every HOLON.md under root that a scan
+// would visit, whether or not its frontmatter parses.
+func HolonFiles(root string, opts ScanOptions) ([]string, error) {
+	var paths []string
+	err := walkHolonFiles(root, opts, nil, func(path string) bool {
+		paths = append(paths, path)
+		return true
+	})
+	return paths, err
+}
+
+// walkHolonFiles walks root, or its anchors under opts.AnchorMarker,
+// skipping hidden directories, and calls onFile for each HOLON.md not
+// excluded by an IgnoreMarker, stopping as soon as onFile returns false.
+// onScanned, if set, is called for every regular file visited.
+// Unreadable entries are skipped.
+func walkHolonFiles(root string, opts ScanOptions, onScanned func(), onFile func(path string) bool) error {
+	roots := []string{root}
+	if opts.AnchorMarker != "" {
+		anchors, err := anchorDirs(root, opts)
 		if err != nil {
+			return err
+		}
+		if len(anchors) > 0 {
+			roots = anchors
+		}
+	}
+
+	stopped := false
+	for _, dir := range roots {
+		err := walkTree(dir, opts, onScanned, func(path string) bool {
+			stopped = !onFile(path)
+			return !stopped
+		})
+		if err != nil || stopped {
+			return err
+		}
+	}
+	return nil
+}
+
+// anchorDirs returns the outermost directories under root, root
+// included, that hold opts.AnchorMarker. Markers below an anchor are
+// part of its tree and are not reported separately.
+func anchorDirs(root string, opts ScanOptions) ([]string, error) {
+	var anchors []string
+	skip := skippedWalkPaths(root, opts.SkipDirs)
+	err := filepath.WalkDir(root, func(path string, d os.DirEntry, err error) error {
+		if err != nil || !d.IsDir() {
 			return nil
 		}
+		if name := d.Name(); path != root && (strings.HasPrefix(name, ".") || skip[path]) {
+			return filepath.SkipDir
+		}
+		if _, err := os.Stat(filepath.Join(path, opts.AnchorMarker)); err == nil {
+			anchors = append(anchors, path)
+			return filepath.SkipDir
+		}
+		return nil
+	})
+	return anchors, err
+}
 
-		id, _, err := ParseFrontmatter(data)
+// walkTree is walkHolonFiles for a single tree, ignoring AnchorMarker.
+func walkTree(root string, opts ScanOptions, onScanned func(), onFile func(path string) bool) error {
+	skip := skippedWalkPaths(root, opts.SkipDirs)
+	return filepath.WalkDir(root, func(path string, d os.DirEntry, err error) error {
 		if err != nil {
 			return nil
 		}
+		if d.IsDir() {
+			name := d.Name()
+			if name != "." && strings.HasPrefix(name, ".") {
+				return filepath.SkipDir
+			}
+			if skip[path] {
+				return filepath.SkipDir
+			}
+			return nil
+		}
 
-		if id.UUID == target || strings.HasPrefix(id.UUID, target) {
-			found = path
+		if onScanned != nil {
+			onScanned()
+		}
+		if d.Name() != "HOLON.md" || isIgnored(path, opts) {
+			return nil
+		}
+		if d.Type()&fs.ModeSymlink != 0 {
+			switch opts.Symlinks {
+			case SymlinkSkip:
+				return nil
+			case SymlinkReject:
+				return fmt.Errorf("%s: %w", path, ErrSymlink)
+			}
+			if opts.OnWarning != nil && linksOutside(root, path) {
+				opts.OnWarning(path, fmt.Errorf("%s: %w", path, ErrSymlinkOutsideRoot))
+			}
+		}
+		if !onFile(path) {
 			return filepath.SkipAll
 		}
-
 		return nil
 	})
+}
 
+// linksOutside reports whether the symlink at path, found by a walk of
+// root, resolves outside root. A root naming the file itself stands for
+// its directory. Dangling links are left to the read, which fails.
+func linksOutside(root, path string) bool {
+	if path == root {
+		root = filepath.Dir(root)
+	}
+	target, err := filepath.EvalSymlinks(path)
 	if err != nil {
-		return "", err
+		return false
 	}
-	if found == "" {
-		return "", fmt.Errorf("holon not found: %s", target)
+	if target, err = filepath.Abs(target); err != nil {
+		return false
 	}
-	return found, nil
+	realRoot, err := filepath.EvalSymlinks(root)
+	if err != nil {
+		return false
+	}
+	if realRoot, err = filepath.Abs(realRoot); err != nil {
+		return false
+	}
+	rel, err := filepath.Rel(realRoot, target)
+	return err != nil || !filepath.IsLocal(rel)
+}
+
+// skippedWalkPaths returns the dirs under root, spelled as WalkDir
+// will report them when walking root, so that they can be matched
+// without resolving every visited path. dirs outside root are dropped.
+func skippedWalkPaths(root string, dirs []string) map[string]bool {
+	if len(dirs) == 0 {
+		return nil
+	}
+	absRoot, err := filepath.Abs(root)
+	if err != nil {
+		return nil
+	}
+	skip := make(map[string]bool, len(dirs))
+	for _, dir := range dirs {
+		absDir, err := filepath.Abs(dir)
+		if err != nil {
+			continue
+		}
+		rel, err := filepath.Rel(absRoot, absDir)
+		if err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			continue
+		}
+		skip[filepath.Join(root, rel)] = true
+	}
+	return skip
+}
+
+// isIgnored reports whether the HOLON.md at path sits next to an
+// IgnoreMarker and should be skipped under opts.
+func isIgnored(path string, opts ScanOptions) bool {
+	if opts.IncludeIgnored {
+		return false
+	}
+	_, err := os.Stat(filepath.Join(filepath.Dir(path), IgnoreMarker))
+	return err == nil
 }
 
 // ParseFrontmatter extracts the YAML frontmatter and the remaining
 // markdown body from a HOLON.md file.
 func ParseFrontmatter(data []byte) (Identity, string, error) {
-	content := string(data)
+	yamlBlock, body, err := splitFrontmatterBytes(data)
+	if err != nil {
+		return Identity{}, "", err
+	}
 
-	if !strings.HasPrefix(content, "---") {
-		return Identity{}, "", fmt.Errorf("no YAML frontmatter found")
+	var doc yaml.Node
+	if err := yaml.Unmarshal(yamlBlock, &doc); err != nil {
+		return Identity{}, "", newParseError(err)
+	}
+	if len(doc.Content) == 0 {
+		return Identity{}, "", &ParseError{Line: 1, Msg: "frontmatter is empty"}
+	}
+	// Anything but a mapping would decode to an all-empty ghost identity.
+	if root := doc.Content[0]; root.Kind != yaml.MappingNode {
+		return Identity{}, "", newParseError(fmt.Errorf("line %d: frontmatter must be a mapping of keys to values, not a %s", root.Line, nodeKindName(root.Kind)))
 	}
 
-	rest := content[3:]
-	if len(rest) > 0 && rest[0] == '\n' {
-		rest = rest[1:]
+	var id Identity
+	if err := doc.Decode(&id); err != nil {
+		return Identity{}, "", newParseError(err)
 	}
 
-	end := strings.Index(rest, "\n---")
-	if end < 0 {
-		return Identity{}, "", fmt.Errorf("unclosed YAML frontmatter")
+	return id, string(body), nil
+}
+
+func nodeKindName(kind yaml.Kind) string {
+	switch kind {
+	case yaml.SequenceNode:
+		return "list"
+	case yaml.ScalarNode:
+		return "single value"
+	case yaml.AliasNode:
+		return "alias"
+	default:
+		return "document"
 	}
+}
 
-	yamlBlock := rest[:end]
-	body := rest[end+4:]
+// ParseError reports malformed YAML frontmatter. Line numbers, both in
+// Line and in the message, refer to the HOLON.md file rather than the
+// YAML block, so they can be used to jump straight to the problem.
+type ParseError struct {
+	Line int // first offending 1-based file line, 0 when unknown
+	Msg  string
+	Err  error
+}
 
-	var id Identity
-	if err := yaml.Unmarshal([]byte(yamlBlock), &id); err != nil {
-		return Identity{}, "", fmt.Errorf("YAML parse error: %w", err)
+func (e *ParseError) Error() string {
+	return "YAML parse error: " + e.Msg
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+var yamlLineRe = regexp.MustCompile(`line (\d+)`)
+
+// newParseError shifts the block-relative line numbers reported by the
+// YAML decoder by frontmatterOffset.
+func newParseError(err error) *ParseError {
+	pe := &ParseError{Err: err}
+	pe.Msg = yamlLineRe.ReplaceAllStringFunc(err.Error(), func(m string) string {
+		n, convErr := strconv.Atoi(m[len("line "):])
+		if convErr != nil {
+			return m
+		}
+		n += frontmatterOffset
+		if pe.Line == 0 {
+			pe.Line = n
+		}
+		return fmt.Sprintf("line %d", n)
+	})
+	return pe
+}
+
+// RawFrontmatter returns the YAML block between the opening and closing
+// `---` markers of a HOLON.md file, exactly as written.
+func RawFrontmatter(data []byte) (string, error) {
+	yamlBlock, _, err := splitFrontmatter(data)
+	return yamlBlock, err
+}
+
+// splitFrontmatter separates the raw YAML block from the markdown body.
+func splitFrontmatter(data []byte) (string, string, error) {
+	yamlBlock, body, err := splitFrontmatterBytes(data)
+	if err != nil {
+		return "", "", err
+	}
+	return string(yamlBlock), string(body), nil
+}
+
+var (
+	frontmatterDelim = []byte("---")
+	closingDelim     = []byte("\n---")
+)
+
+// splitFrontmatterBytes is splitFrontmatter without copies: both results
+// are subslices of data. Scans parse thousands of files through it.
+func splitFrontmatterBytes(data []byte) ([]byte, []byte, error) {
+	if !bytes.HasPrefix(data, frontmatterDelim) {
+		return nil, nil, fmt.Errorf("no YAML frontmatter found")
+	}
+
+	rest := data[len(frontmatterDelim):]
+	if len(rest) > 0 && rest[0] == '\n' {
+		rest = rest[1:]
+	}
+
+	end := bytes.Index(rest, closingDelim)
+	if end < 0 {
+		return nil, nil, fmt.Errorf("unclosed YAML frontmatter")
 	}
 
-	return id, body, nil
+	return rest[:end], rest[end+len(closingDelim):], nil
 }
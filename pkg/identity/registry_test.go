@@ -1,8 +1,11 @@
 package identity
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
 )
 
@@ -181,6 +184,29 @@ func TestFindByUUIDNotFound(t *testing.T) {
 	}
 }
 
+func TestFindByUUIDWithProgressStopsAtMatch(t *testing.T) {
+	root := t.TempDir()
+	names := []string{"a", "b", "c", "d", "e", "f"}
+	uuids, paths := writeLineage(t, root, names, nil)
+
+	var reports []ScanProgress
+	path, err := FindByUUIDWithProgress(root, uuids["b"][:13], ScanOptions{ProgressEvery: 1}, func(p ScanProgress) {
+		reports = append(reports, p)
+	})
+	if err != nil {
+		t.Fatalf("FindByUUIDWithProgress failed: %v", err)
+	}
+	if path != paths["b"] {
+		t.Errorf("path = %s, want %s", path, paths["b"])
+	}
+	if len(reports) < 2 {
+		t.Fatalf("progress reported %d times, want per file and at the end", len(reports))
+	}
+	if last := reports[len(reports)-1]; last.ScannedFiles != 2 || last.HolonsFound != 2 {
+		t.Errorf("final progress = %+v, want the scan to stop at the second of %d holons", last, len(names))
+	}
+}
+
 func TestFindAllSkipsUnparseableFiles(t *testing.T) {
 	root := t.TempDir()
 
@@ -326,6 +352,72 @@ func TestFindAllSkipsDotHolonDir(t *testing.T) {
 	}
 }
 
+func TestScanWithOptionsSkipDirs(t *testing.T) {
+	root := setupTestDir(t)
+
+	var found []string
+	err := ScanWithOptions(root, ScanOptions{SkipDirs: []string{filepath.Join(root, "holon-b"), t.TempDir()}}, func(h LocatedIdentity) {
+		found = append(found, h.Identity.UUID)
+	}, nil)
+	if err != nil {
+		t.Fatalf("ScanWithOptions failed: %v", err)
+	}
+	if len(found) != 1 || found[0] != "aaaa-1111" {
+		t.Errorf("found %v, want only aaaa-1111 outside the skipped dir", found)
+	}
+
+	// Skipping the root itself would hide everything; it is ignored.
+	holons, err := HolonFiles(root, ScanOptions{SkipDirs: []string{root}})
+	if err != nil || len(holons) != 2 {
+		t.Errorf("HolonFiles with root skipped = %v, %v; want both holons", holons, err)
+	}
+}
+
+func TestScanWithOptionsAnchorMarker(t *testing.T) {
+	root := t.TempDir()
+	write := func(rel, content string) {
+		t.Helper()
+		path := filepath.Join(root, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	holon := func(uuid string) string {
+		return strings.Replace(validFrontmatter, "test-uuid-1234", uuid, 1)
+	}
+	write("unrelated/HOLON.md", holon("outside"))
+	write("work/repo/"+RootMarker, "")
+	write("work/repo/HOLON.md", holon("repo"))
+	write("work/repo/holons/a/HOLON.md", holon("nested"))
+	write("work/repo/sub/"+RootMarker, "") // inside an anchor already
+
+	scan := func(dir string, opts ScanOptions) []string {
+		t.Helper()
+		var found []string
+		if err := ScanWithOptions(dir, opts, func(h LocatedIdentity) {
+			found = append(found, h.Identity.UUID)
+		}, nil); err != nil {
+			t.Fatalf("ScanWithOptions failed: %v", err)
+		}
+		slices.Sort(found)
+		return found
+	}
+
+	anchored := ScanOptions{AnchorMarker: RootMarker}
+	if got := scan(root, anchored); !slices.Equal(got, []string{"nested", "repo"}) {
+		t.Errorf("anchored scan found %v, want only the holons under the marker", got)
+	}
+	if got := scan(root, ScanOptions{}); len(got) != 3 {
+		t.Errorf("scan without AnchorMarker found %v, want all 3", got)
+	}
+	if got := scan(filepath.Join(root, "unrelated"), anchored); !slices.Equal(got, []string{"outside"}) {
+		t.Errorf("anchored scan of a tree without marker found %v, want [outside]", got)
+	}
+}
+
 func TestScanAllWithPathsStreamsFoundAndProgress(t *testing.T) {
 	root := setupTestDir(t)
 
@@ -368,3 +460,318 @@ func TestScanAllWithPathsStreamsFoundAndProgress(t *testing.T) {
 		t.Fatal("last progress scanned files should be > 0")
 	}
 }
+
+func TestRawFrontmatter(t *testing.T) {
+	raw, err := RawFrontmatter([]byte(validFrontmatter))
+	if err != nil {
+		t.Fatalf("RawFrontmatter failed: %v", err)
+	}
+	if !strings.HasPrefix(raw, "uuid: \"test-uuid-1234\"") {
+		t.Errorf("raw frontmatter should start with the uuid line, got %q", raw)
+	}
+	if !strings.HasSuffix(raw, "proto_status: draft") {
+		t.Errorf("raw frontmatter should end with the last key, got %q", raw)
+	}
+	if strings.Contains(raw, "---") {
+		t.Error("raw frontmatter must not include the --- markers")
+	}
+}
+
+func TestRawFrontmatterNoFrontmatter(t *testing.T) {
+	if _, err := RawFrontmatter([]byte("# no frontmatter")); err == nil {
+		t.Fatal("expected error for missing frontmatter")
+	}
+}
+
+func TestFindAllHonorsHolonIgnore(t *testing.T) {
+	root := setupTestDir(t)
+	if err := os.WriteFile(filepath.Join(root, "holon-b", IgnoreMarker), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	holons, err := FindAll(root)
+	if err != nil {
+		t.Fatalf("FindAll failed: %v", err)
+	}
+	if len(holons) != 1 || holons[0].UUID != "aaaa-1111" {
+		t.Fatalf("FindAll = %+v, want only holon-a (holon-b is ignored)", holons)
+	}
+
+	if _, err := FindByUUID(root, "bbbb-2222"); err == nil {
+		t.Error("FindByUUID should skip an ignored HOLON.md")
+	}
+}
+
+func TestScanWithOptionsIncludeIgnored(t *testing.T) {
+	root := setupTestDir(t)
+	if err := os.WriteFile(filepath.Join(root, "holon-b", IgnoreMarker), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	found := 0
+	err := ScanWithOptions(root, ScanOptions{IncludeIgnored: true}, func(LocatedIdentity) { found++ }, nil)
+	if err != nil {
+		t.Fatalf("ScanWithOptions failed: %v", err)
+	}
+	if found != 2 {
+		t.Errorf("found %d holons with IncludeIgnored, want 2", found)
+	}
+
+	if _, err := FindByUUIDWithOptions(root, "bbbb-2222", ScanOptions{IncludeIgnored: true}); err != nil {
+		t.Errorf("FindByUUIDWithOptions with IncludeIgnored failed: %v", err)
+	}
+}
+
+func TestParseFrontmatterErrorLineNumber(t *testing.T) {
+	// Line 1 is the opening ---, so the broken key sits on file line 4.
+	content := "---\nuuid: \"abc\"\ngiven_name: \"Line\"\nparents: \"oops\": broken\nstatus: draft\n---\n"
+
+	_, _, err := ParseFrontmatter([]byte(content))
+	var pe *ParseError
+	if !errors.As(err, &pe) {
+		t.Fatalf("error = %v, want *ParseError", err)
+	}
+	if pe.Line != 4 {
+		t.Errorf("Line = %d, want 4", pe.Line)
+	}
+	if !strings.Contains(err.Error(), "line 4") {
+		t.Errorf("error %q should mention line 4", err)
+	}
+}
+
+func TestParseFrontmatterTypeErrorLineNumber(t *testing.T) {
+	content := "---\nuuid: \"abc\"\ngiven_name: \"Line\"\nfamily_name: \"Test\"\naliases:\n  nested: map\n---\n"
+
+	_, _, err := ParseFrontmatter([]byte(content))
+	var pe *ParseError
+	if !errors.As(err, &pe) {
+		t.Fatalf("error = %v, want *ParseError", err)
+	}
+	if pe.Line != 6 {
+		t.Errorf("Line = %d, want 6 (%v)", pe.Line, err)
+	}
+}
+
+func TestParseFrontmatterRejectsNonMapping(t *testing.T) {
+	tests := map[string]string{
+		"sequence": "---\n- item\n- other\n---\n",
+		"scalar":   "---\njust text\n---\n",
+		"empty":    "---\n# only a comment\n---\n",
+	}
+	for name, content := range tests {
+		_, _, err := ParseFrontmatter([]byte(content))
+		var pe *ParseError
+		if !errors.As(err, &pe) {
+			t.Errorf("%s: err = %v, want a *ParseError", name, err)
+			continue
+		}
+		if pe.Line != 2 && name != "empty" {
+			t.Errorf("%s: Line = %d, want 2", name, pe.Line)
+		}
+	}
+
+	_, _, err := ParseFrontmatter([]byte(tests["sequence"]))
+	if err == nil || !strings.Contains(err.Error(), "line 2: frontmatter must be a mapping of keys to values, not a list") {
+		t.Errorf("sequence error = %v", err)
+	}
+}
+
+// legacySplitFrontmatter is the string-based splitter ParseFrontmatter
+// used before it worked on byte slices; splitFrontmatter must match it.
+func legacySplitFrontmatter(data []byte) (string, string, error) {
+	content := string(data)
+	if !strings.HasPrefix(content, "---") {
+		return "", "", errors.New("no YAML frontmatter found")
+	}
+	rest := content[3:]
+	if len(rest) > 0 && rest[0] == '\n' {
+		rest = rest[1:]
+	}
+	end := strings.Index(rest, "\n---")
+	if end < 0 {
+		return "", "", errors.New("unclosed YAML frontmatter")
+	}
+	return rest[:end], rest[end+4:], nil
+}
+
+func TestSplitFrontmatterParity(t *testing.T) {
+	samples := []string{
+		validFrontmatter,
+		"---\nuuid: \"x\"\n---",
+		"---uuid: \"x\"\n---\nbody",
+		"---\r\nuuid: \"x\"\r\n---\r\nbody\r\n",
+		"---\n---\n",
+		"---\n\n---\n",
+		"---\nuuid: \"x\"\n---\n---\nsecond block\n",
+		"# Just markdown\n",
+		"---\nunclosed: true\n",
+		"",
+	}
+	for _, sample := range samples {
+		wantYAML, wantBody, wantErr := legacySplitFrontmatter([]byte(sample))
+		gotYAML, gotBody, gotErr := splitFrontmatter([]byte(sample))
+		if gotYAML != wantYAML || gotBody != wantBody || (gotErr == nil) != (wantErr == nil) {
+			t.Errorf("splitFrontmatter(%q) = %q, %q, %v; want %q, %q, %v",
+				sample, gotYAML, gotBody, gotErr, wantYAML, wantBody, wantErr)
+		}
+	}
+}
+
+func BenchmarkParseFrontmatter(b *testing.B) {
+	data := []byte(validFrontmatter + strings.Repeat("Body text that a scan never needs.\n", 200))
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, _, err := ParseFrontmatter(data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkLegacySplitFrontmatter(b *testing.B) {
+	data := []byte(validFrontmatter + strings.Repeat("Body text that a scan never needs.\n", 200))
+	b.ReportAllocs()
+	for b.Loop() {
+		yamlBlock, _, err := legacySplitFrontmatter(data)
+		if err != nil {
+			b.Fatal(err)
+		}
+		_ = []byte(yamlBlock)
+	}
+}
+
+func BenchmarkSplitFrontmatterBytes(b *testing.B) {
+	data := []byte(validFrontmatter + strings.Repeat("Body text that a scan never needs.\n", 200))
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, _, err := splitFrontmatterBytes(data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestScanSkipsOversizedFiles(t *testing.T) {
+	root := t.TempDir()
+	small := filepath.Join(root, "small", "HOLON.md")
+	big := filepath.Join(root, "big", "HOLON.md")
+	for _, p := range []string{small, big} {
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(small, []byte(validFrontmatter), 0644); err != nil {
+		t.Fatal(err)
+	}
+	padded := validFrontmatter + strings.Repeat("x", 4096)
+	if err := os.WriteFile(big, []byte(padded), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var found []string
+	skipped := map[string]error{}
+	opts := ScanOptions{
+		MaxFileSize: 2048,
+		OnError:     func(path string, err error) { skipped[path] = err },
+	}
+	err := ScanWithOptions(root, opts, func(h LocatedIdentity) {
+		found = append(found, h.Path)
+	}, nil)
+	if err != nil {
+		t.Fatalf("ScanWithOptions failed: %v", err)
+	}
+
+	if len(found) != 1 || found[0] != small {
+		t.Errorf("found = %q, want only %s", found, small)
+	}
+	if err := skipped[big]; !errors.Is(err, ErrFileTooLarge) {
+		t.Errorf("oversized file reported as %v, want ErrFileTooLarge", err)
+	}
+	if len(skipped) != 1 {
+		t.Errorf("skipped = %v, want only the oversized file", skipped)
+	}
+
+	if _, err := ReadHolonFile(big, -1); err != nil {
+		t.Errorf("ReadHolonFile without a limit failed: %v", err)
+	}
+}
+
+// symlinkFixture builds a root holding a regular holon, a holon whose
+// HOLON.md links inside root, and one whose HOLON.md links outside it.
+func symlinkFixture(t *testing.T) (root, regular, inside, outside string) {
+	t.Helper()
+	root = t.TempDir()
+	elsewhere := t.TempDir()
+	regular = filepath.Join(root, "regular", "HOLON.md")
+	inside = filepath.Join(root, "inside", "HOLON.md")
+	outside = filepath.Join(root, "outside", "HOLON.md")
+	for _, p := range []string{regular, inside, outside, filepath.Join(root, "store", "x")} {
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(regular, []byte(validFrontmatter), 0644); err != nil {
+		t.Fatal(err)
+	}
+	for link, target := range map[string]string{
+		inside:  filepath.Join(root, "store", "inside.md"),
+		outside: filepath.Join(elsewhere, "outside.md"),
+	} {
+		if err := os.WriteFile(target, []byte(validFrontmatter), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Symlink(target, link); err != nil {
+			t.Skipf("symlinks unsupported: %v", err)
+		}
+	}
+	return root, regular, inside, outside
+}
+
+func TestScanSymlinkPolicies(t *testing.T) {
+	root, regular, inside, outside := symlinkFixture(t)
+	scan := func(policy SymlinkPolicy) ([]string, map[string]error, error) {
+		var found []string
+		warned := map[string]error{}
+		opts := ScanOptions{
+			Symlinks:  policy,
+			OnWarning: func(path string, err error) { warned[path] = err },
+		}
+		err := ScanWithOptions(root, opts, func(h LocatedIdentity) {
+			found = append(found, h.Path)
+		}, nil)
+		slices.Sort(found)
+		return found, warned, err
+	}
+
+	found, warned, err := scan(SymlinkFollow)
+	if err != nil {
+		t.Fatalf("follow: %v", err)
+	}
+	if want := []string{inside, outside, regular}; !slices.Equal(found, want) {
+		t.Errorf("follow found %q, want %q", found, want)
+	}
+	if len(warned) != 1 || !errors.Is(warned[outside], ErrSymlinkOutsideRoot) {
+		t.Errorf("follow warned %v, want only the link leaving root", warned)
+	}
+
+	found, warned, err = scan(SymlinkSkip)
+	if err != nil {
+		t.Fatalf("skip: %v", err)
+	}
+	if !slices.Equal(found, []string{regular}) || len(warned) != 0 {
+		t.Errorf("skip found %q, warned %v, want only %s", found, warned, regular)
+	}
+
+	if _, _, err := scan(SymlinkReject); !errors.Is(err, ErrSymlink) {
+		t.Errorf("reject = %v, want ErrSymlink", err)
+	}
+}
+
+func TestParseSymlinkPolicy(t *testing.T) {
+	for _, p := range []SymlinkPolicy{SymlinkFollow, SymlinkSkip, SymlinkReject} {
+		if got, err := ParseSymlinkPolicy(p.String()); err != nil || got != p {
+			t.Errorf("ParseSymlinkPolicy(%q) = %v, %v", p, got, err)
+		}
+	}
+	if _, err := ParseSymlinkPolicy("chase"); err == nil {
+		t.Error("ParseSymlinkPolicy(chase) succeeded, want an error")
+	}
+}
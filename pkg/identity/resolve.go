@@ -0,0 +1,123 @@
+package identity
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// AmbiguousTargetError is returned by ResolveTarget when a target
+// matches more than one holon at the first lookup step that matches.
+type AmbiguousTargetError struct {
+	Target  string
+	Matches []LocatedIdentity
+}
+
+func (e *AmbiguousTargetError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%q matches %d holons:", e.Target, len(e.Matches))
+	for _, m := range e.Matches {
+		fmt.Fprintf(&b, " %s (%s)", m.Identity.UUID, m.Path)
+	}
+	return b.String()
+}
+
+// ResolveTarget finds the holon a user means by target, trying in
+// order: a path to a HOLON.md or its directory inside root, a full
+// UUID, a UUID prefix, a "Given Family" name (case-insensitive), and an
+// alias. The first step with any match decides: several matches give an
+// *AmbiguousTargetError, none at all a "holon not found" error.
+func ResolveTarget(root, target string) (LocatedIdentity, error) {
+	return ResolveTargetWithProgress(root, target, 0, nil)
+}
+
+// ResolveTargetWithProgress is ResolveTarget reporting the progress of
+// its scan through onProgress, as ScanAllWithPaths does. Resolution
+// needs every match to detect ambiguity, so the whole tree is scanned.
+func ResolveTargetWithProgress(root, target string, progressEvery int, onProgress func(ScanProgress)) (LocatedIdentity, error) {
+	target = strings.TrimSpace(target)
+	if target == "" {
+		return LocatedIdentity{}, fmt.Errorf("holon not found: empty target")
+	}
+
+	if h, ok := resolvePath(root, target); ok {
+		return h, nil
+	}
+
+	var holons []LocatedIdentity
+	err := ScanAllWithPaths(root, progressEvery, func(h LocatedIdentity) {
+		holons = append(holons, h)
+	}, onProgress)
+	if err != nil {
+		return LocatedIdentity{}, err
+	}
+
+	steps := []func(Identity) bool{
+		func(id Identity) bool { return id.UUID == target },
+		func(id Identity) bool { return strings.HasPrefix(id.UUID, target) },
+		func(id Identity) bool {
+			return strings.EqualFold(strings.TrimSpace(id.GivenName+" "+id.FamilyName), target)
+		},
+		func(id Identity) bool { return slices.Contains(id.Aliases, target) },
+	}
+	for _, match := range steps {
+		var matches []LocatedIdentity
+		for _, h := range holons {
+			if match(h.Identity) {
+				matches = append(matches, h)
+			}
+		}
+		switch len(matches) {
+		case 0:
+			continue
+		case 1:
+			return matches[0], nil
+		default:
+			return LocatedIdentity{}, &AmbiguousTargetError{Target: target, Matches: matches}
+		}
+	}
+	return LocatedIdentity{}, fmt.Errorf("holon not found: %s", target)
+}
+
+// resolvePath treats target as a HOLON.md file or holon directory,
+// relative to root unless absolute. Paths outside root never match, so
+// a server cannot be asked to read arbitrary files.
+func resolvePath(root, target string) (LocatedIdentity, bool) {
+	path := target
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(root, path)
+	}
+	absRoot, err := filepath.Abs(root)
+	if err != nil {
+		return LocatedIdentity{}, false
+	}
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return LocatedIdentity{}, false
+	}
+	if rel, err := filepath.Rel(absRoot, absPath); err != nil || !filepath.IsLocal(rel) && rel != "." {
+		return LocatedIdentity{}, false
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return LocatedIdentity{}, false
+	}
+	if info.IsDir() {
+		path = filepath.Join(path, "HOLON.md")
+	} else if filepath.Base(path) != "HOLON.md" {
+		return LocatedIdentity{}, false
+	}
+
+	data, err := ReadHolonFile(path, 0)
+	if err != nil {
+		return LocatedIdentity{}, false
+	}
+	id, _, err := ParseFrontmatter(data)
+	if err != nil {
+		return LocatedIdentity{}, false
+	}
+	return LocatedIdentity{Identity: id, Path: path}, true
+}
@@ -0,0 +1,74 @@
+package identity
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// seedResolve writes id to root/dir/HOLON.md.
+func seedResolve(t *testing.T, root, dir string, id Identity) {
+	t.Helper()
+	path := filepath.Join(root, dir, "HOLON.md")
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteHolonMD(id, path); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestResolveTarget(t *testing.T) {
+	root := t.TempDir()
+
+	swift := validIdentity()
+	swift.UUID = "aaaa1111-0000-4000-8000-000000000001"
+	swift.GivenName = "Swift"
+	swift.Aliases = []string{"sw"}
+	deep := validIdentity()
+	deep.UUID = "aaaa2222-0000-4000-8000-000000000002"
+	deep.GivenName = "Deep"
+	deep.Aliases = []string{"dp"}
+	seedResolve(t, root, "holons/swift", swift)
+	seedResolve(t, root, "holons/deep", deep)
+
+	tests := []struct {
+		target string
+		want   string
+	}{
+		{"holons/swift", swift.UUID},
+		{filepath.Join("holons", "deep", "HOLON.md"), deep.UUID},
+		{filepath.Join(root, "holons", "deep"), deep.UUID},
+		{swift.UUID, swift.UUID},
+		{"aaaa2", deep.UUID},
+		{"swift holon", swift.UUID},
+		{"dp", deep.UUID},
+	}
+	for _, tt := range tests {
+		h, err := ResolveTarget(root, tt.target)
+		if err != nil {
+			t.Errorf("ResolveTarget(%q) failed: %v", tt.target, err)
+			continue
+		}
+		if h.Identity.UUID != tt.want {
+			t.Errorf("ResolveTarget(%q) = %s, want %s", tt.target, h.Identity.UUID, tt.want)
+		}
+	}
+
+	_, err := ResolveTarget(root, "aaaa")
+	var ambiguous *AmbiguousTargetError
+	if !errors.As(err, &ambiguous) || len(ambiguous.Matches) != 2 {
+		t.Errorf("ResolveTarget(aaaa) error = %v, want an ambiguity between two holons", err)
+	}
+
+	// A path outside the root is not followed, even when a holon is there.
+	outside := validIdentity()
+	seedResolve(t, root, "outside", outside)
+	for _, target := range []string{"nobody", "", "..", filepath.Join("..", "outside"), filepath.Join(root, "outside")} {
+		if _, err := ResolveTarget(filepath.Join(root, "holons"), target); err == nil || !strings.HasPrefix(err.Error(), "holon not found") {
+			t.Errorf("ResolveTarget(%q) error = %v, want holon not found", target, err)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package identity
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// SetStatus moves the holon whose HOLON.md is at path to status,
+// rewriting only the status line (and died) in place. Moving a holon to
+// "dead" records today's date as died unless one is already set. It
+// returns the updated identity and the status it had before. status
+// must be a built-in stage or one of the custom opts.Statuses.
+func SetStatus(path, status string, opts ValidateOptions) (Identity, string, error) {
+	if !opts.KnownStatus(status) {
+		return Identity{}, "", fmt.Errorf("unknown status %q", status)
+	}
+
+	var id Identity
+	var previous string
+	err := RewriteHolonFile(path, func(data []byte) ([]byte, error) {
+		var err error
+		if id, _, err = ParseFrontmatter(data); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+
+		previous = id.Status
+		set := map[string]any{"status": status}
+		id.Status = status
+		if status == "dead" && id.Died == "" {
+			id.Died = time.Now().Format(DateLayout)
+			set["died"] = id.Died
+		}
+
+		updated, err := UpdateFrontmatter(data, set)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		return updated, nil
+	})
+	if err != nil {
+		return Identity{}, "", err
+	}
+	return id, previous, nil
+}
+
+// ErrIllegalTransition reports a proto_status change that moves the
+// contract backward in its lifecycle.
+var ErrIllegalTransition = errors.New("illegal proto_status transition")
+
+// protoLifecycle is the order a holon's contract goes through.
+var protoLifecycle = []string{"draft", "stable", "deprecated", "dead"}
+
+// CheckProtoStatusTransition checks that proto_status may move from
+// from to to: forward along draft → stable → deprecated → dead, possibly
+// skipping stages, but never backward. A proto_status outside that
+// lifecycle, such as an unset one or a custom stage, may enter it at any
+// stage, but once in it may not leave: stable → "" → draft would
+// otherwise move backward in two steps.
+func CheckProtoStatusTransition(from, to string) error {
+	i, j := slices.Index(protoLifecycle, from), slices.Index(protoLifecycle, to)
+	switch {
+	case i < 0 || j >= i:
+		return nil
+	case j < 0:
+		return fmt.Errorf("%w: %s → %q leaves the lifecycle %s", ErrIllegalTransition,
+			from, to, strings.Join(protoLifecycle, " → "))
+	}
+	return fmt.Errorf("%w: %s → %s moves backward (want %s)", ErrIllegalTransition,
+		from, to, strings.Join(protoLifecycle, " → "))
+}
@@ -0,0 +1,43 @@
+package identity
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCheckProtoStatusTransition(t *testing.T) {
+	tests := []struct {
+		from, to string
+		legal    bool
+	}{
+		{"draft", "stable", true},
+		{"stable", "deprecated", true},
+		{"draft", "dead", true},
+		{"stable", "stable", true},
+		{"", "stable", true},
+		{"experimental", "stable", true},
+		{"", "dead", true},
+		{"stable", "experimental", false},
+		{"stable", "", false},
+		{"stable", "draft", false},
+		{"dead", "deprecated", false},
+	}
+	for _, tt := range tests {
+		err := CheckProtoStatusTransition(tt.from, tt.to)
+		if tt.legal && err != nil {
+			t.Errorf("%s → %s: %v, want legal", tt.from, tt.to, err)
+		}
+		if !tt.legal && !errors.Is(err, ErrIllegalTransition) {
+			t.Errorf("%s → %s: %v, want ErrIllegalTransition", tt.from, tt.to, err)
+		}
+	}
+}
+
+// Leaving the lifecycle would allow moving backward in two steps.
+func TestCheckProtoStatusTransitionTwoSteps(t *testing.T) {
+	for _, via := range []string{"", "experimental"} {
+		if CheckProtoStatusTransition("stable", via) == nil && CheckProtoStatusTransition(via, "draft") == nil {
+			t.Errorf("stable → %q → draft is accepted", via)
+		}
+	}
+}
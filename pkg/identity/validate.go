@@ -0,0 +1,422 @@
+package identity
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+
+	"gopkg.in/yaml.v3"
+)
+
+// CommandNames lists the subcommands of the who CLI. Aliases may not
+// take these names, so that tooling splicing an alias into a command
+// line can never have it read as a subcommand.
+var CommandNames = []string{"new", "show", "list", "rename", "status", "reparent", "verify-lineage", "validate", "whoami", "migrate-layout", "audit", "export", "doctor", "fmt", "serve", "client"}
+
+// reservedAliases lists aliases that would be ambiguous in name-based
+// lookup or CLI parsing. ValidateOptions.ReservedAliases extends it.
+var reservedAliases = append([]string{"all", "any", "none", "help", ".", ".."}, CommandNames...)
+
+// FieldError describes a single invalid field.
+type FieldError struct {
+	Field   string
+	Message string
+	Line    int // 1-based line in the HOLON.md file, 0 when unknown
+}
+
+func (e FieldError) Error() string {
+	msg := e.Message
+	if e.Field != "" {
+		msg = e.Field + ": " + msg
+	}
+	if e.Line > 0 {
+		msg = fmt.Sprintf("line %d: %s", e.Line, msg)
+	}
+	return msg
+}
+
+// ValidationError collects every FieldError found by Validate.
+type ValidationError struct {
+	Errors []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, fe := range e.Errors {
+		msgs[i] = fe.Error()
+	}
+	return "invalid identity: " + strings.Join(msgs, "; ")
+}
+
+// ValidateOptions tunes ValidateWithOptions.
+type ValidateOptions struct {
+	// Statuses lists the custom lifecycle stages accepted besides the
+	// built-in Statuses, usually Config.Statuses.
+	Statuses []string
+
+	// ReservedAliases lists aliases refused besides the built-in
+	// reserved ones, usually Config.ReservedAliases.
+	ReservedAliases []string
+}
+
+// KnownStatus reports whether status is a built-in lifecycle stage or
+// one of o.Statuses.
+func (o ValidateOptions) KnownStatus(status string) bool {
+	return slices.Contains(Statuses, status) || slices.Contains(o.Statuses, status)
+}
+
+// Validate checks that id is a well-formed identity with a built-in
+// status. It returns a *ValidationError listing every invalid field, or
+// nil.
+func (id Identity) Validate() error {
+	return id.ValidateWithOptions(ValidateOptions{})
+}
+
+// ValidateWithOptions is Validate, also accepting the custom statuses
+// of opts.
+func (id Identity) ValidateWithOptions(opts ValidateOptions) error {
+	var errs []FieldError
+	require := func(field, value string) {
+		if strings.TrimSpace(value) == "" {
+			errs = append(errs, FieldError{Field: field, Message: "is required"})
+		}
+	}
+
+	require("uuid", id.UUID)
+	require("given_name", id.GivenName)
+	require("family_name", id.FamilyName)
+	require("motto", id.Motto)
+	require("composer", id.Composer)
+
+	// These fields are interpolated into single lines of the HOLON.md
+	// body; a line break or other control character would corrupt it.
+	singleLine := func(field, value string) {
+		if strings.ContainsFunc(value, unicode.IsControl) {
+			errs = append(errs, FieldError{Field: field, Message: "must not contain line breaks or control characters"})
+		}
+	}
+	singleLine("given_name", id.GivenName)
+	singleLine("family_name", id.FamilyName)
+	singleLine("motto", id.Motto)
+	singleLine("composer", id.Composer)
+
+	if id.Clade != "" && !slices.Contains(Clades, id.Clade) {
+		errs = append(errs, FieldError{Field: "clade", Message: fmt.Sprintf("unknown clade %q", id.Clade)})
+	}
+	if id.Status != "" && !opts.KnownStatus(id.Status) {
+		errs = append(errs, FieldError{Field: "status", Message: fmt.Sprintf("unknown status %q", id.Status)})
+	}
+	if id.Reproduction != "" && !slices.Contains(ReproductionModes, id.Reproduction) {
+		errs = append(errs, FieldError{Field: "reproduction", Message: fmt.Sprintf("unknown reproduction mode %q", id.Reproduction)})
+	}
+
+	for _, alias := range id.Aliases {
+		if err := ValidateAliasWithOptions(alias, opts); err != nil {
+			errs = append(errs, FieldError{Field: "aliases", Message: err.Error()})
+		}
+	}
+
+	for _, tag := range id.Tags {
+		if err := ValidateTag(tag); err != nil {
+			errs = append(errs, FieldError{Field: "tags", Message: err.Error()})
+		}
+	}
+
+	if id.Reproduction == "bred" {
+		errs = append(errs, checkBredParents(id)...)
+	}
+
+	if len(errs) > 0 {
+		return &ValidationError{Errors: errs}
+	}
+	return nil
+}
+
+// Warnings returns the problems with id that Validate accepts because
+// they are legal, but that are almost always data-entry mistakes: a
+// given name equal to the family name (compared case-insensitively),
+// which also makes an odd slug.
+func (id Identity) Warnings() []FieldError {
+	var warnings []FieldError
+	given, family := strings.TrimSpace(id.GivenName), strings.TrimSpace(id.FamilyName)
+	if given != "" && strings.EqualFold(given, family) {
+		warnings = append(warnings, FieldError{Field: "family_name", Message: fmt.Sprintf("is the same as given_name %q", given)})
+	}
+	return warnings
+}
+
+// ValidateStrict is Validate, with Warnings reported as errors too.
+func (id Identity) ValidateStrict() error {
+	return id.ValidateStrictWithOptions(ValidateOptions{})
+}
+
+// ValidateStrictWithOptions is ValidateStrict, validating with opts.
+func (id Identity) ValidateStrictWithOptions(opts ValidateOptions) error {
+	warnings := id.Warnings()
+	err := id.ValidateWithOptions(opts)
+	if len(warnings) == 0 {
+		return err
+	}
+	verr, ok := err.(*ValidationError)
+	if !ok {
+		verr = &ValidationError{}
+	}
+	verr.Errors = append(verr.Errors, warnings...)
+	return verr
+}
+
+// checkBredParents rejects self-breeding and duplicate parents: a bred
+// holon descends from distinct parents, none of which is itself.
+func checkBredParents(id Identity) []FieldError {
+	var errs []FieldError
+	seen := make(map[string]bool, len(id.Parents))
+	for _, parent := range id.Parents {
+		switch {
+		case id.UUID != "" && parent == id.UUID:
+			errs = append(errs, FieldError{Field: "parents", Message: fmt.Sprintf("parent %q is the holon itself", parent)})
+		case seen[parent]:
+			errs = append(errs, FieldError{Field: "parents", Message: fmt.Sprintf("parent %q is listed more than once", parent)})
+		}
+		seen[parent] = true
+	}
+	return errs
+}
+
+// ValidateContent parses and validates raw HOLON.md content without
+// touching the filesystem. It returns every problem found, with line
+// numbers pointing into data where they can be determined; an empty
+// result means the content is valid.
+func ValidateContent(data []byte) []FieldError {
+	return ValidateContentWithOptions(data, ValidateOptions{})
+}
+
+// ValidateContentWithOptions is ValidateContent, validating with opts.
+func ValidateContentWithOptions(data []byte, opts ValidateOptions) []FieldError {
+	yamlBlock, _, err := splitFrontmatter(data)
+	if err != nil {
+		return []FieldError{{Message: err.Error(), Line: 1}}
+	}
+
+	id, _, err := ParseFrontmatter(data)
+	if err != nil {
+		fe := FieldError{Message: err.Error()}
+		if pe, ok := err.(*ParseError); ok {
+			fe.Line = pe.Line
+		}
+		return []FieldError{fe}
+	}
+
+	verr, ok := id.ValidateWithOptions(opts).(*ValidationError)
+	if !ok {
+		return nil
+	}
+
+	lines := keyLines(yamlBlock)
+	errs := make([]FieldError, len(verr.Errors))
+	for i, fe := range verr.Errors {
+		if line, ok := lines[fe.Field]; ok {
+			fe.Line = line + frontmatterOffset
+		}
+		errs[i] = fe
+	}
+	return errs
+}
+
+// FileValidation is the outcome of validating one HOLON.md file.
+type FileValidation struct {
+	Path   string
+	Errors []FieldError // empty when the file is valid
+}
+
+// ValidateFiles validates every HOLON.md under root that a scan would
+// visit, whether or not its frontmatter parses. onResult is called for
+// each file as soon as it is checked; returning false stops the scan. A
+// file that cannot be read is reported with that error. onProgress, if
+// set, is called every opts.ProgressEvery scanned files and once at the
+// end, with HolonsFound counting the files validated.
+func ValidateFiles(root string, opts ScanOptions, onResult func(FileValidation) bool, onProgress func(ScanProgress)) error {
+	return ValidateFilesWithOptions(root, opts, ValidateOptions{}, onResult, onProgress)
+}
+
+// ValidateFilesWithOptions is ValidateFiles, validating each file with
+// vopts.
+func ValidateFilesWithOptions(root string, opts ScanOptions, vopts ValidateOptions, onResult func(FileValidation) bool, onProgress func(ScanProgress)) error {
+	var progress ScanProgress
+	report := func(force bool) {
+		if onProgress == nil {
+			return
+		}
+		if force || (opts.ProgressEvery > 0 && progress.ScannedFiles%opts.ProgressEvery == 0) {
+			onProgress(progress)
+		}
+	}
+
+	err := walkHolonFiles(root, opts, func() {
+		progress.ScannedFiles++
+		report(false)
+	}, func(path string) bool {
+		result := FileValidation{Path: path}
+		if data, err := ReadHolonFile(path, opts.MaxFileSize); err != nil {
+			result.Errors = []FieldError{{Message: err.Error()}}
+		} else {
+			result.Errors = ValidateContentWithOptions(data, vopts)
+		}
+		progress.HolonsFound++
+		return onResult(result)
+	})
+	if err != nil {
+		return err
+	}
+
+	report(true)
+	return nil
+}
+
+// frontmatterOffset converts a line within the YAML block into a file
+// line: the block starts right after the opening "---" line.
+const frontmatterOffset = 1
+
+// keyLines maps each top-level frontmatter key to its 1-based line
+// within the YAML block.
+func keyLines(yamlBlock string) map[string]int {
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(yamlBlock), &doc); err != nil || len(doc.Content) == 0 {
+		return nil
+	}
+	mapping := doc.Content[0]
+	if mapping.Kind != yaml.MappingNode {
+		return nil
+	}
+	lines := make(map[string]int, len(mapping.Content)/2)
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		lines[mapping.Content[i].Value] = mapping.Content[i].Line
+	}
+	return lines
+}
+
+// ValidateOutputDir checks a holon output directory. A relative dir is
+// taken relative to root; relative or absolute, it must lie inside root.
+// An existing dir must be empty or already hold a HOLON.md: a non-empty
+// directory without one belongs to something else.
+func ValidateOutputDir(root, dir string) error {
+	if strings.TrimSpace(dir) == "" {
+		return fmt.Errorf("output directory must not be empty")
+	}
+
+	absRoot, err := filepath.Abs(root)
+	if err != nil {
+		return fmt.Errorf("cannot resolve root directory: %w", err)
+	}
+	path := dir
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(absRoot, path)
+	}
+	if rel, err := filepath.Rel(absRoot, filepath.Clean(path)); err != nil || !filepath.IsLocal(rel) {
+		return fmt.Errorf("output directory %q escapes the root directory", dir)
+	}
+
+	entries, err := os.ReadDir(path)
+	if err != nil || len(entries) == 0 {
+		// Missing directories are created; other errors surface on write.
+		return nil
+	}
+	if _, err := os.Stat(filepath.Join(path, "HOLON.md")); err != nil {
+		return fmt.Errorf("output directory %q is not empty and holds no HOLON.md", dir)
+	}
+	return nil
+}
+
+// DateLayout is the date format of the born and died fields.
+const DateLayout = "2006-01-02"
+
+// ValidateBorn checks a born date for backfilled identities: it must be
+// a YYYY-MM-DD date no later than today.
+func ValidateBorn(born string) error {
+	date, err := time.ParseInLocation(DateLayout, born, time.Local)
+	if err != nil {
+		return fmt.Errorf("born %q is not a YYYY-MM-DD date", born)
+	}
+	if date.After(time.Now()) {
+		return fmt.Errorf("born %q is in the future", born)
+	}
+	return nil
+}
+
+// ValidateAlias rejects aliases that are empty, look like CLI flags,
+// contain whitespace or commas, or are reserved, such as "all" or the
+// name of a subcommand (see CommandNames).
+func ValidateAlias(alias string) error {
+	return ValidateAliasWithOptions(alias, ValidateOptions{})
+}
+
+// ValidateAliasWithOptions is ValidateAlias, also refusing the
+// opts.ReservedAliases.
+func ValidateAliasWithOptions(alias string, opts ValidateOptions) error {
+	if alias == "" {
+		return fmt.Errorf("alias must not be empty")
+	}
+	if strings.HasPrefix(alias, "-") {
+		return fmt.Errorf("alias %q must not start with '-'", alias)
+	}
+	if strings.ContainsFunc(alias, func(r rune) bool { return unicode.IsSpace(r) || r == ',' }) {
+		return fmt.Errorf("alias %q must not contain whitespace or commas", alias)
+	}
+	for _, reserved := range slices.Concat(reservedAliases, opts.ReservedAliases) {
+		if strings.EqualFold(alias, reserved) {
+			return fmt.Errorf("alias %q is reserved", alias)
+		}
+	}
+	return nil
+}
+
+// NormalizeAliases trims whitespace and drops empty and duplicate aliases,
+// preserving the order of first appearance.
+func NormalizeAliases(aliases []string) []string {
+	if aliases == nil {
+		return nil
+	}
+	seen := make(map[string]struct{}, len(aliases))
+	out := make([]string, 0, len(aliases))
+	for _, a := range aliases {
+		a = strings.TrimSpace(a)
+		if a == "" {
+			continue
+		}
+		if _, dup := seen[a]; dup {
+			continue
+		}
+		seen[a] = struct{}{}
+		out = append(out, a)
+	}
+	return out
+}
+
+// ValidateTag rejects tags that are empty or contain whitespace, commas,
+// or control characters, any of which would split or corrupt the tag
+// in a comma-separated list.
+func ValidateTag(tag string) error {
+	if tag == "" {
+		return fmt.Errorf("tag must not be empty")
+	}
+	if strings.ContainsFunc(tag, func(r rune) bool { return unicode.IsSpace(r) || unicode.IsControl(r) || r == ',' }) {
+		return fmt.Errorf("tag %q must not contain whitespace or commas", tag)
+	}
+	return nil
+}
+
+// NormalizeTags trims and lowercases tags and drops empty and duplicate
+// ones, preserving the order of first appearance.
+func NormalizeTags(tags []string) []string {
+	if tags == nil {
+		return nil
+	}
+	lowered := make([]string, len(tags))
+	for i, t := range tags {
+		lowered[i] = strings.ToLower(t)
+	}
+	return NormalizeAliases(lowered)
+}
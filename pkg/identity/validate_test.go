@@ -0,0 +1,271 @@
+package identity
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// validIdentity returns an identity that passes Validate.
+func validIdentity() Identity {
+	id := New()
+	id.GivenName = "Valid"
+	id.FamilyName = "Holon"
+	id.Motto = "Pass every check."
+	id.Composer = "Test Suite"
+	id.Clade = "deterministic/pure"
+	id.Reproduction = "manual"
+	return id
+}
+
+func TestValidateValid(t *testing.T) {
+	if err := validIdentity().Validate(); err != nil {
+		t.Fatalf("Validate failed on a valid identity: %v", err)
+	}
+}
+
+func TestValidateMissingRequired(t *testing.T) {
+	id := validIdentity()
+	id.GivenName = ""
+	id.Composer = "  "
+
+	err := id.Validate()
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("Validate error = %v, want *ValidationError", err)
+	}
+	fields := map[string]bool{}
+	for _, fe := range verr.Errors {
+		fields[fe.Field] = true
+	}
+	if !fields["given_name"] || !fields["composer"] {
+		t.Errorf("missing fields not reported: %+v", verr.Errors)
+	}
+}
+
+func TestValidateRejectsControlCharacters(t *testing.T) {
+	id := validIdentity()
+	id.Motto = "Two\nlines"
+	id.GivenName = "Tab\tbed"
+	err := id.Validate()
+	verr, ok := err.(*ValidationError)
+	if !ok || len(verr.Errors) != 2 {
+		t.Fatalf("Validate() = %v, want errors for motto and given_name", err)
+	}
+}
+
+func TestValidateRejectsLeadingDashAlias(t *testing.T) {
+	id := validIdentity()
+	id.Aliases = []string{"ok", "-v"}
+
+	if err := id.Validate(); err == nil {
+		t.Fatal("expected error for alias starting with '-'")
+	}
+}
+
+func TestValidateRejectsReservedAlias(t *testing.T) {
+	id := validIdentity()
+	id.Aliases = []string{"List"}
+
+	if err := id.Validate(); err == nil {
+		t.Fatal("expected error for reserved alias")
+	}
+}
+
+func TestValidateBredParents(t *testing.T) {
+	bred := func(parents ...string) Identity {
+		id := validIdentity()
+		id.Reproduction = "bred"
+		id.Parents = parents
+		return id
+	}
+
+	if err := bred("parent-a", "parent-b").Validate(); err != nil {
+		t.Fatalf("distinct parents rejected: %v", err)
+	}
+
+	if err := bred("parent-a", "parent-a").Validate(); err == nil || !strings.Contains(err.Error(), `"parent-a" is listed more than once`) {
+		t.Errorf("identical parents: err = %v", err)
+	}
+
+	self := bred("parent-a")
+	self.Parents = append(self.Parents, self.UUID)
+	if err := self.Validate(); err == nil || !strings.Contains(err.Error(), "is the holon itself") {
+		t.Errorf("self parent: err = %v", err)
+	}
+
+	// Other reproduction modes are not constrained.
+	manual := bred("parent-a", "parent-a")
+	manual.Reproduction = "manual"
+	if err := manual.Validate(); err != nil {
+		t.Errorf("manual holon with duplicate parents rejected: %v", err)
+	}
+}
+
+func TestValidateRejectsSubcommandAliases(t *testing.T) {
+	for _, name := range CommandNames {
+		id := validIdentity()
+		id.Aliases = []string{name}
+		if err := id.Validate(); err == nil || !strings.Contains(err.Error(), "reserved") {
+			t.Errorf("alias %q: Validate() = %v, want a reserved-alias error", name, err)
+		}
+	}
+}
+
+func TestReservedAliasesConfigurable(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	root := t.TempDir()
+	writeConfig(t, root, "reserved_aliases: [legacy]\n")
+	cfg, err := LoadConfig(root)
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	opts := cfg.ValidateOptions()
+
+	if err := ValidateAliasWithOptions("legacy", opts); err == nil {
+		t.Fatal("expected error for alias reserved in the config")
+	}
+	if err := ValidateAlias("legacy"); err != nil {
+		t.Fatalf("alias reserved by the config refused without it: %v", err)
+	}
+	if err := ValidateAliasWithOptions("modern", opts); err != nil {
+		t.Fatalf("unexpected error for non-reserved alias: %v", err)
+	}
+	if err := ValidateAliasWithOptions("all", opts); err == nil {
+		t.Fatal("built-in reserved alias accepted with the config")
+	}
+
+	id := validIdentity()
+	id.Aliases = StringList{"legacy"}
+	if err := id.ValidateWithOptions(opts); err == nil || !strings.Contains(err.Error(), "reserved") {
+		t.Errorf("ValidateWithOptions = %v, want a reserved-alias error", err)
+	}
+}
+
+func TestNormalizeAliases(t *testing.T) {
+	got := NormalizeAliases([]string{" rt ", "", "round", "rt"})
+	want := []string{"rt", "round"}
+	if len(got) != len(want) {
+		t.Fatalf("NormalizeAliases = %q, want %q", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("NormalizeAliases = %q, want %q", got, want)
+		}
+	}
+}
+
+func TestWarningsSameNames(t *testing.T) {
+	for _, tt := range []struct {
+		given, family string
+		warn          bool
+	}{
+		{"Echo", "Echo", true},
+		{"echo ", "ECHO", true},
+		{"Echo", "Chamber", false},
+		{"Echo", "Echoes", false},
+		{"", "", false}, // missing names are Validate's business
+	} {
+		id := validIdentity()
+		id.GivenName, id.FamilyName = tt.given, tt.family
+		warnings := id.Warnings()
+		if got := len(warnings) > 0; got != tt.warn || (got && warnings[0].Field != "family_name") {
+			t.Errorf("Warnings(%q, %q) = %v, want a family_name warning: %v", tt.given, tt.family, warnings, tt.warn)
+		}
+	}
+}
+
+func TestValidateStrict(t *testing.T) {
+	id := validIdentity()
+	id.FamilyName = id.GivenName
+	if err := id.Validate(); err != nil {
+		t.Errorf("Validate = %v, want identical names to be only a warning", err)
+	}
+	verr, ok := id.ValidateStrict().(*ValidationError)
+	if !ok || len(verr.Errors) != 1 || !strings.Contains(verr.Error(), "same as given_name") {
+		t.Errorf("ValidateStrict = %v, want the names warning as an error", id.ValidateStrict())
+	}
+
+	id.Motto = ""
+	if verr, ok := id.ValidateStrict().(*ValidationError); !ok || len(verr.Errors) != 2 {
+		t.Errorf("ValidateStrict = %v, want the motto error and the names warning", id.ValidateStrict())
+	}
+	if err := validIdentity().ValidateStrict(); err != nil {
+		t.Errorf("ValidateStrict(valid) = %v", err)
+	}
+}
+
+func TestNormalizeTags(t *testing.T) {
+	got := NormalizeTags([]string{" Audio ", "", "audio", "EXP", "exp"})
+	if want := []string{"audio", "exp"}; !slices.Equal(got, want) {
+		t.Errorf("NormalizeTags = %q, want %q", got, want)
+	}
+}
+
+func TestValidateTags(t *testing.T) {
+	id := validIdentity()
+	id.Tags = []string{"audio", "two words", "a,b"}
+	verr, ok := id.Validate().(*ValidationError)
+	if !ok || len(verr.Errors) != 2 || verr.Errors[0].Field != "tags" || verr.Errors[1].Field != "tags" {
+		t.Fatalf("Validate = %v, want two tags errors", id.Validate())
+	}
+}
+
+func TestValidateContentValid(t *testing.T) {
+	content := "---\nuuid: \"c9f1e2d3-0000-4000-8000-000000000001\"\ngiven_name: \"Valid\"\nfamily_name: \"Holon\"\nmotto: \"Ok.\"\ncomposer: \"Test\"\nclade: \"deterministic/pure\"\nstatus: draft\n---\n"
+	if errs := ValidateContent([]byte(content)); len(errs) != 0 {
+		t.Fatalf("ValidateContent = %+v, want no errors", errs)
+	}
+}
+
+func TestValidateContentReportsFieldAndLine(t *testing.T) {
+	content := "---\nuuid: \"c9f1e2d3-0000-4000-8000-000000000002\"\ngiven_name: \"Valid\"\nfamily_name: \"Holon\"\nmotto: \"Ok.\"\ncomposer: \"Test\"\nclade: \"quantum\"\n---\n"
+
+	errs := ValidateContent([]byte(content))
+	if len(errs) != 1 {
+		t.Fatalf("ValidateContent = %+v, want exactly one error", errs)
+	}
+	if errs[0].Field != "clade" {
+		t.Errorf("Field = %q, want clade", errs[0].Field)
+	}
+	if errs[0].Line != 7 {
+		t.Errorf("Line = %d, want 7", errs[0].Line)
+	}
+}
+
+func TestValidateContentNoFrontmatter(t *testing.T) {
+	errs := ValidateContent([]byte("# Just markdown"))
+	if len(errs) != 1 || errs[0].Line != 1 {
+		t.Fatalf("ValidateContent = %+v, want one error on line 1", errs)
+	}
+}
+
+func TestValidateOutputDir(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "empty"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(root, "foreign"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "foreign", "main.go"), []byte("package main\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	valid := []string{"holons/new-one", "empty", "a/../b", filepath.Join(root, "elsewhere")}
+	for _, dir := range valid {
+		if err := ValidateOutputDir(root, dir); err != nil {
+			t.Errorf("ValidateOutputDir(%q) = %v, want nil", dir, err)
+		}
+	}
+
+	invalid := []string{"", "..", "../sibling", "holons/../../up", "foreign", filepath.Dir(root), filepath.Join(root, "..", "sibling")}
+	for _, dir := range invalid {
+		if err := ValidateOutputDir(root, dir); err == nil {
+			t.Errorf("ValidateOutputDir(%q) = nil, want error", dir)
+		}
+	}
+}
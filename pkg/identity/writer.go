@@ -1,14 +1,17 @@
 package identity
 
 import (
+	"bytes"
 	"fmt"
 	"os"
+	"slices"
 	"strings"
 	"text/template"
+	"time"
 )
 
-// holonTemplate generates the complete HOLON.md file content.
-var holonTemplate = `---
+// holonHeaderTemplate generates the frontmatter and title of HOLON.md.
+const holonHeaderTemplate = `---
 # Holon Identity v1
 uuid: {{ .UUID | quote }}
 given_name: {{ .GivenName | quote }}
@@ -18,6 +21,9 @@ composer: {{ .Composer | quote }}
 clade: {{ .Clade | quote }}
 status: {{ .Status }}
 born: {{ .Born | quote }}
+{{- if .Died }}
+died: {{ .Died | quote }}
+{{- end }}
 
 # Lineage
 parents: [{{ joinQuoted .Parents }}]
@@ -25,6 +31,9 @@ reproduction: {{ .Reproduction | quote }}
 
 # Optional
 aliases: [{{ joinQuoted .Aliases }}]
+{{- if .Tags }}
+tags: [{{ joinQuoted .Tags }}]
+{{- end }}
 
 # Metadata
 generated_by: {{ .GeneratedBy | quote }}
@@ -32,9 +41,12 @@ lang: {{ .Lang | quote }}
 proto_status: {{ .ProtoStatus }}
 ---
 
-# {{ .GivenName }} {{ .FamilyName }}
+# {{ .GivenName | markdown }} {{ .FamilyName | markdown }}
+`
 
-> *"{{ .Motto }}"*
+// holonTemplate generates the complete HOLON.md file content.
+var holonTemplate = holonHeaderTemplate + `
+> *"{{ .Motto | markdown }}"*
 
 ## Description
 
@@ -56,23 +68,164 @@ var tmplFuncs = template.FuncMap{
 		}
 		return strings.Join(quoted, ", ")
 	},
+	"markdown": EscapeMarkdown,
+	"upper":    strings.ToUpper,
+	"lower":    strings.ToLower,
+	"slug":     slugify,
+	"year": func() int {
+		return time.Now().Year()
+	},
+}
+
+// markdownEscaper backslash-escapes the characters that would end or
+// restyle the inline context a field is interpolated into, such as the
+// quoted, italic motto line.
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	`"`, `\"`,
+	"*", `\*`,
+	"_", `\_`,
+	"`", "\\`",
+	"[", `\[`,
+	"]", `\]`,
+	"<", `\<`,
+	">", `\>`,
+	"#", `\#`,
+)
+
+// EscapeMarkdown makes s safe to interpolate into a single line of the
+// HOLON.md body: markdown punctuation is escaped and line breaks, which
+// would end the line and could start a new block, become spaces.
+func EscapeMarkdown(s string) string {
+	s = strings.Join(strings.FieldsFunc(s, func(r rune) bool { return r == '\n' || r == '\r' }), " ")
+	return markdownEscaper.Replace(s)
+}
+
+// templateData is what HOLON.md templates are executed against: the
+// identity's fields, plus the render time for dates in boilerplate.
+type templateData struct {
+	Identity
+	Now  time.Time
+	Year int
+}
+
+// RenderHolonMD renders id with the default HOLON.md template.
+func RenderHolonMD(id Identity) ([]byte, error) {
+	return RenderTemplate(holonTemplate, id)
+}
+
+// RenderOptions tune RenderHolonMDWithOptions and
+// WriteHolonMDWithOptions.
+type RenderOptions struct {
+	// SortLists renders aliases and parents in sorted order, so that an
+	// edit reordering them does not change the file. Leave it unset
+	// where their order carries meaning.
+	SortLists bool
+
+	// Minimal renders the frontmatter and title line only, as
+	// RenderMinimalHolonMD does.
+	Minimal bool
+
+	// Template, when set, is a custom template rendered instead of the
+	// built-in ones (see RenderTemplate). Minimal is then ignored.
+	Template string
+}
+
+// RenderHolonMDWithOptions renders id with the template opts select,
+// the default HOLON.md template unless told otherwise, and its lists
+// ordered as opts ask. id itself is not modified.
+func RenderHolonMDWithOptions(id Identity, opts RenderOptions) ([]byte, error) {
+	if opts.SortLists {
+		id.Aliases = slices.Sorted(slices.Values(id.Aliases))
+		id.Parents = slices.Sorted(slices.Values(id.Parents))
+	}
+	switch {
+	case opts.Template != "":
+		return RenderTemplate(opts.Template, id)
+	case opts.Minimal:
+		return RenderMinimalHolonMD(id)
+	default:
+		return RenderHolonMD(id)
+	}
+}
+
+// RenderMinimalHolonMD renders id as the frontmatter and title line
+// only, without the motto line and the Description and Introspection
+// scaffold of RenderHolonMD.
+func RenderMinimalHolonMD(id Identity) ([]byte, error) {
+	return RenderTemplate(holonHeaderTemplate, id)
+}
+
+// RenderTemplate renders id with a custom HOLON.md template. Besides
+// the Identity fields, templates can use .Now and .Year and the
+// functions quote, joinQuoted, markdown, upper, lower, slug, and year.
+// The output always ends with a single newline.
+func RenderTemplate(text string, id Identity) ([]byte, error) {
+	tmpl, err := template.New("holon").Funcs(tmplFuncs).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("template error: %w", err)
+	}
+
+	now := time.Now()
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, templateData{Identity: id, Now: now, Year: now.Year()}); err != nil {
+		return nil, fmt.Errorf("template execution error: %w", err)
+	}
+	return withTrailingNewline(buf.Bytes()), nil
+}
+
+// withTrailingNewline returns data ending in exactly one newline, so that
+// templates ending with none or several do not cause noisy diffs.
+func withTrailingNewline(data []byte) []byte {
+	trimmed := bytes.TrimRight(data, "\n")
+	return append(trimmed[:len(trimmed):len(trimmed)], '\n')
 }
 
 // WriteHolonMD renders an Identity to a HOLON.md file at the given path.
+// An existing file is truncated and overwritten.
 func WriteHolonMD(id Identity, path string) error {
-	tmpl, err := template.New("holon").Funcs(tmplFuncs).Parse(holonTemplate)
+	data, err := RenderHolonMD(id)
+	if err != nil {
+		return err
+	}
+	return writeFile(path, data, os.O_WRONLY|os.O_CREATE|os.O_TRUNC)
+}
+
+// WriteHolonMDWithOptions is WriteHolonMD rendering with opts.
+func WriteHolonMDWithOptions(id Identity, path string, opts RenderOptions) error {
+	data, err := RenderHolonMDWithOptions(id, opts)
+	if err != nil {
+		return err
+	}
+	return writeFile(path, data, os.O_WRONLY|os.O_CREATE|os.O_TRUNC)
+}
+
+// WriteHolonMDExcl renders an Identity to a new HOLON.md file at the given
+// path. It fails, leaving the file untouched, if the path already exists;
+// the returned error then satisfies errors.Is(err, os.ErrExist).
+func WriteHolonMDExcl(id Identity, path string) error {
+	data, err := RenderHolonMD(id)
 	if err != nil {
-		return fmt.Errorf("template error: %w", err)
+		return err
 	}
+	return CreateHolonMD(path, data)
+}
+
+// CreateHolonMD writes already-rendered content (see RenderTemplate) to a
+// new file, with the same no-overwrite guarantee as WriteHolonMDExcl.
+func CreateHolonMD(path string, data []byte) error {
+	return writeFile(path, data, os.O_WRONLY|os.O_CREATE|os.O_EXCL)
+}
 
-	f, err := os.Create(path)
+func writeFile(path string, data []byte, flag int) error {
+	f, err := os.OpenFile(path, flag, 0644)
 	if err != nil {
 		return fmt.Errorf("cannot create %s: %w", path, err)
 	}
 	defer f.Close()
 
-	if err := tmpl.Execute(f, id); err != nil {
-		return fmt.Errorf("template execution error: %w", err)
+	if _, err := f.Write(data); err != nil {
+		return fmt.Errorf("cannot write %s: %w", path, err)
 	}
 	return nil
 }
@@ -1,9 +1,15 @@
 package identity
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestWriteHolonMD(t *testing.T) {
@@ -45,6 +51,7 @@ func TestWriteHolonMDRoundTrip(t *testing.T) {
 	original.Reproduction = "assisted"
 	original.Lang = "go"
 	original.Aliases = []string{"rt", "round"}
+	original.Tags = []string{"audio", "experimental"}
 
 	if err := WriteHolonMD(original, path); err != nil {
 		t.Fatalf("WriteHolonMD failed: %v", err)
@@ -89,6 +96,9 @@ func TestWriteHolonMDRoundTrip(t *testing.T) {
 	if len(parsed.Aliases) != len(original.Aliases) {
 		t.Errorf("Aliases count: got %d, want %d", len(parsed.Aliases), len(original.Aliases))
 	}
+	if !slices.Equal(parsed.Tags, original.Tags) {
+		t.Errorf("Tags: got %q, want %q", parsed.Tags, original.Tags)
+	}
 
 	// Body should contain the holon's name
 	if body == "" {
@@ -96,6 +106,52 @@ func TestWriteHolonMDRoundTrip(t *testing.T) {
 	}
 }
 
+func TestRenderHolonMDOmitsEmptyTags(t *testing.T) {
+	data, err := RenderHolonMD(validIdentity())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "tags:") {
+		t.Errorf("untagged holon renders a tags line:\n%s", data)
+	}
+}
+
+func TestRenderHolonMDSortLists(t *testing.T) {
+	id := validIdentity()
+	id.Aliases = StringList{"zeta", "alpha", "mid"}
+	id.Parents = StringList{"c3d4e5f6-0000-4000-8000-000000000002", "a1b2c3d4-0000-4000-8000-000000000001"}
+	reordered := id
+	reordered.Aliases = StringList{"mid", "zeta", "alpha"}
+	reordered.Parents = StringList{"a1b2c3d4-0000-4000-8000-000000000001", "c3d4e5f6-0000-4000-8000-000000000002"}
+
+	sorted, err := RenderHolonMDWithOptions(id, RenderOptions{SortLists: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	again, err := RenderHolonMDWithOptions(reordered, RenderOptions{SortLists: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(sorted, again) {
+		t.Errorf("sorted renders differ across input orders:\n%s\n---\n%s", sorted, again)
+	}
+	if !strings.Contains(string(sorted), `aliases: ["alpha", "mid", "zeta"]`) {
+		t.Errorf("aliases not sorted:\n%s", sorted)
+	}
+	if id.Aliases[0] != "zeta" {
+		t.Errorf("rendering sorted the caller's slice: %q", id.Aliases)
+	}
+
+	kept, err := RenderHolonMDWithOptions(id, RenderOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(kept), `aliases: ["zeta", "alpha", "mid"]`) ||
+		!strings.Contains(string(kept), `parents: ["c3d4e5f6-0000-4000-8000-000000000002", "a1b2c3d4`) {
+		t.Errorf("default render does not keep the input order:\n%s", kept)
+	}
+}
+
 func TestWriteHolonMDInvalidPath(t *testing.T) {
 	id := New()
 	id.GivenName = "Bad"
@@ -106,3 +162,149 @@ func TestWriteHolonMDInvalidPath(t *testing.T) {
 		t.Fatal("expected error writing to invalid path")
 	}
 }
+
+func TestWriteHolonMDExclRefusesExisting(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "HOLON.md")
+
+	first := New()
+	first.GivenName = "First"
+	first.FamilyName = "Writer"
+	if err := WriteHolonMDExcl(first, path); err != nil {
+		t.Fatalf("first WriteHolonMDExcl failed: %v", err)
+	}
+	original, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	second := New()
+	second.GivenName = "Second"
+	second.FamilyName = "Writer"
+	err = WriteHolonMDExcl(second, path)
+	if err == nil {
+		t.Fatal("expected error on second exclusive write")
+	}
+	if !errors.Is(err, os.ErrExist) {
+		t.Errorf("error = %v, want os.ErrExist", err)
+	}
+
+	after, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(after) != string(original) {
+		t.Error("original file was modified by the failed exclusive write")
+	}
+}
+
+func TestRenderTemplateVariables(t *testing.T) {
+	id := New()
+	id.GivenName = "Swift"
+	id.FamilyName = "Transcriber"
+	id.Composer = "B. Alter"
+	id.Born = "2026-03-04"
+
+	tmpl := "born {{.Born}} ({{ year }}, {{.Year}})\n© {{.Now.Year}} {{ upper .Composer }} — {{ slug .GivenName }}\n"
+	out, err := RenderTemplate(tmpl, id)
+	if err != nil {
+		t.Fatalf("RenderTemplate failed: %v", err)
+	}
+
+	year := time.Now().Year()
+	want := fmt.Sprintf("born 2026-03-04 (%d, %d)\n© %d B. ALTER — swift\n", year, year, year)
+	if string(out) != want {
+		t.Errorf("RenderTemplate = %q, want %q", out, want)
+	}
+}
+
+func TestRenderTemplateParseError(t *testing.T) {
+	if _, err := RenderTemplate("{{ .Born ", New()); err == nil {
+		t.Fatal("RenderTemplate accepted a malformed template")
+	}
+}
+
+func TestRenderHolonMDEscapesBodyFields(t *testing.T) {
+	id := New()
+	id.GivenName = "Star_Child"
+	id.FamilyName = "Holon"
+	id.Motto = "Quote \"this\"\nand # that"
+
+	data, err := RenderHolonMD(id)
+	if err != nil {
+		t.Fatalf("RenderHolonMD failed: %v", err)
+	}
+	parsed, body, err := ParseFrontmatter(data)
+	if err != nil {
+		t.Fatalf("rendered HOLON.md does not reparse: %v", err)
+	}
+	if parsed.Motto != id.Motto {
+		t.Errorf("motto = %q, want %q", parsed.Motto, id.Motto)
+	}
+	for _, want := range []string{
+		"\n# Star\\_Child Holon\n",
+		"\n> *\"Quote \\\"this\\\" and \\# that\"*\n",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("body missing %q:\n%s", want, body)
+		}
+	}
+}
+
+func TestRenderMinimalHolonMD(t *testing.T) {
+	id := validIdentity()
+	data, err := RenderMinimalHolonMD(id)
+	if err != nil {
+		t.Fatalf("RenderMinimalHolonMD failed: %v", err)
+	}
+	for _, unwanted := range []string{"## Description", "## Introspection Notes", "> *"} {
+		if strings.Contains(string(data), unwanted) {
+			t.Errorf("minimal HOLON.md contains %q:\n%s", unwanted, data)
+		}
+	}
+
+	got, body, err := ParseFrontmatter(data)
+	if err != nil {
+		t.Fatalf("minimal HOLON.md does not parse: %v\n%s", err, data)
+	}
+	if got.UUID != id.UUID || got.Motto != id.Motto {
+		t.Errorf("parsed identity = %+v, want %+v", got, id)
+	}
+	if want := "# " + id.GivenName + " " + id.FamilyName; strings.TrimSpace(body) != want {
+		t.Errorf("body = %q, want the title line %q", body, want)
+	}
+
+	// Without even the title, the body is empty.
+	got, body, err = ParseFrontmatter([]byte("---\nuuid: \"" + id.UUID + "\"\n---\n"))
+	if err != nil || got.UUID != id.UUID || strings.TrimSpace(body) != "" {
+		t.Errorf("ParseFrontmatter(frontmatter only) = %q, %q, %v", got.UUID, body, err)
+	}
+}
+
+func TestWriteHolonMDSingleTrailingNewline(t *testing.T) {
+	for name, text := range map[string]string{
+		"default":  holonTemplate,
+		"none":     "---\nuuid: {{ .UUID | quote }}\n---",
+		"repeated": "---\nuuid: {{ .UUID | quote }}\n---\n\n\n",
+	} {
+		data, err := RenderTemplate(text, New())
+		if err != nil {
+			t.Fatalf("%s: RenderTemplate failed: %v", name, err)
+		}
+		if !strings.HasSuffix(string(data), "\n") || strings.HasSuffix(string(data), "\n\n") {
+			t.Errorf("%s: rendered content ends with %q, want exactly one newline", name, data[max(0, len(data)-3):])
+		}
+	}
+
+	path := filepath.Join(t.TempDir(), "HOLON.md")
+	if err := WriteHolonMD(validIdentity(), path); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasSuffix(string(data), "\n") || strings.HasSuffix(string(data), "\n\n") {
+		t.Errorf("written file does not end with a single newline")
+	}
+}